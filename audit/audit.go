@@ -0,0 +1,352 @@
+// Package audit reconciles FedEx invoices against the quotes shipments were
+// booked at, reporting where the billed weight, dimensions, residential
+// flag, surcharges or totals differ, and the disputes worth filing.
+package audit
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tirpitz0509/go-fedex/rate"
+	"github.com/tirpitz0509/go-fedex/surcharge"
+)
+
+type Kind string
+
+const (
+	Unmatched   Kind = "UNMATCHED"   // no record for the invoice line
+	Currency    Kind = "CURRENCY"    // billed in another currency than quoted
+	Weight      Kind = "WEIGHT"      // rated weight
+	Dimensions  Kind = "DIMENSIONS"  //
+	Residential Kind = "RESIDENTIAL" // residential flag
+	Surcharge   Kind = "SURCHARGE"   // total of one surcharge category
+	Total       Kind = "TOTAL"       // net charge
+)
+
+// Discrepancy is one way an invoice line differs from its record.
+type Discrepancy struct {
+	Kind     Kind               `json:"kind"`               //
+	Category surcharge.Category `json:"category,omitempty"` // for SURCHARGE
+	Expected string             `json:"expected"`           // as recorded
+	Billed   string             `json:"billed"`             //
+	Amount   float64            `json:"amount,omitempty"`   // billed minus expected, for money
+}
+
+func (d Discrepancy) String() string {
+	what := strings.ToLower(string(d.Kind))
+	switch d.Kind {
+	case Unmatched:
+		return "no shipment recorded"
+	case Surcharge:
+		what = strings.ToLower(strings.Replace(string(d.Category), "_", " ", -1)) + " surcharge"
+	case Total:
+		what = "net charge"
+	}
+	return what + " billed " + d.Billed + ", expected " + d.Expected
+}
+
+// Finding is an invoice line with its record, nil if unmatched, and the
+// ways they differ. For a multi-piece shipment billed a line per package,
+// Line totals the packages under the master tracking number and Pieces
+// holds the lines as billed.
+type Finding struct {
+	Line          InvoiceLine   `json:"line"`                    //
+	Pieces        []InvoiceLine `json:"pieces,omitempty"`        // when Line totals several lines
+	Record        *Record       `json:"record,omitempty"`        //
+	Discrepancies []Discrepancy `json:"discrepancies,omitempty"` //
+	Overcharge    float64       `json:"overcharge"`              // billed minus quoted net charge, 0 if billed in another currency
+}
+
+type Report struct {
+	Lines     int       `json:"lines"`     //
+	Matched   int       `json:"matched"`   //
+	Unmatched int       `json:"unmatched"` //
+	Findings  []Finding `json:"findings"`  // shipments with discrepancies, in invoice order
+}
+
+type Auditor struct {
+	Tolerance       float64 // money; 0.01 if 0
+	WeightTolerance float64 // LB; 0.5 if 0
+	DimTolerance    float64 // IN per side; 1 if 0
+}
+
+// Audit checks every invoice line against the record with its tracking
+// number, its master tracking number or, failing those, one of its
+// references. FedEx bills each package of a multi-piece shipment on its own
+// line; the lines of one record on one invoice are checked together, as the
+// shipment that was quoted.
+func (a Auditor) Audit(lines []InvoiceLine, records []Record) Report {
+	byTracking := map[string]*Record{}
+	byReference := map[string]*Record{}
+	for i := range records {
+		r := &records[i]
+		for _, tracking := range append([]string{r.TrackingNumber}, r.PackageTrackingNumbers...) {
+			if tracking = strings.TrimSpace(tracking); tracking != "" {
+				byTracking[tracking] = r
+			}
+		}
+		if r.Reference != "" {
+			byReference[strings.ToLower(strings.TrimSpace(r.Reference))] = r
+		}
+	}
+
+	type shipment struct {
+		record *Record
+		lines  []InvoiceLine
+	}
+	var shipments []shipment
+	index := map[*Record]map[string]int{} // record -> invoice number -> shipment
+	var report Report
+	for _, l := range lines {
+		report.Lines++
+		r := byTracking[l.TrackingNumber]
+		if r == nil && l.MasterTrackingNumber != "" {
+			r = byTracking[l.MasterTrackingNumber]
+		}
+		for _, ref := range l.References {
+			if r == nil {
+				r = byReference[strings.ToLower(ref)]
+			}
+		}
+		if r == nil {
+			report.Unmatched++
+			shipments = append(shipments, shipment{lines: []InvoiceLine{l}})
+			continue
+		}
+		report.Matched++
+		if index[r] == nil {
+			index[r] = map[string]int{}
+		}
+		if i, ok := index[r][l.InvoiceNumber]; ok {
+			shipments[i].lines = append(shipments[i].lines, l)
+			continue
+		}
+		index[r][l.InvoiceNumber] = len(shipments)
+		shipments = append(shipments, shipment{record: r, lines: []InvoiceLine{l}})
+	}
+
+	for _, s := range shipments {
+		if s.record == nil {
+			l := s.lines[0]
+			report.Findings = append(report.Findings, Finding{
+				Line:          l,
+				Discrepancies: []Discrepancy{{Kind: Unmatched, Billed: money(l.NetCharge, l.Currency)}},
+			})
+			continue
+		}
+		if f := a.CheckShipment(s.lines, *s.record); len(f.Discrepancies) > 0 {
+			report.Findings = append(report.Findings, f)
+		}
+	}
+	return report
+}
+
+// Check compares an invoice line with the record it bills.
+func (a Auditor) Check(l InvoiceLine, r Record) Finding {
+	return a.CheckShipment([]InvoiceLine{l}, r)
+}
+
+// CheckShipment compares the invoice lines billing one shipment, one per
+// package of a multi-piece shipment, with its record: their totals against
+// the quote and each line's dimensions against the declared packages. A
+// shipment billed in another currency than quoted reports only that; its
+// charges cannot be compared.
+func (a Auditor) CheckShipment(lines []InvoiceLine, r Record) Finding {
+	tolerance := a.Tolerance
+	if tolerance <= 0 {
+		tolerance = 0.01
+	}
+	weightTolerance := a.WeightTolerance
+	if weightTolerance <= 0 {
+		weightTolerance = 0.5
+	}
+	dimTolerance := a.DimTolerance
+	if dimTolerance <= 0 {
+		dimTolerance = 1
+	}
+
+	q := r.Quote
+	l := combine(lines)
+	if len(lines) > 1 && l.MasterTrackingNumber == "" && r.TrackingNumber != "" {
+		l.TrackingNumber = r.TrackingNumber
+	}
+	f := Finding{Line: l, Record: &r}
+	if len(lines) > 1 {
+		f.Pieces = lines
+	}
+	add := func(d Discrepancy) {
+		f.Discrepancies = append(f.Discrepancies, d)
+	}
+	currency := l.Currency
+	if currency == "" {
+		currency = q.Currency
+	}
+	sameCurrency := true
+	for _, line := range lines {
+		if line.Currency != "" && q.Currency != "" && !strings.EqualFold(line.Currency, q.Currency) {
+			add(Discrepancy{Kind: Currency, Expected: q.Currency, Billed: line.Currency})
+			sameCurrency = false
+			break
+		}
+	}
+
+	if l.RatedWeight.Value > 0 && q.BillingWeight.Value > 0 {
+		billed, expected := pounds(l.RatedWeight), pounds(q.BillingWeight)
+		if math.Abs(billed-expected) > weightTolerance {
+			add(Discrepancy{Kind: Weight, Expected: weight(q.BillingWeight), Billed: weight(l.RatedWeight)})
+		}
+	}
+
+	for _, line := range lines {
+		billed := inches(line.Dimensions)
+		if billed[0] == 0 {
+			continue
+		}
+		closest, best := rate.Dimensions{}, math.Inf(1)
+		for _, p := range r.Packages {
+			declared := inches(p.Dimensions)
+			if declared[0] == 0 {
+				continue
+			}
+			var off float64
+			for i := range billed {
+				off = math.Max(off, math.Abs(billed[i]-declared[i]))
+			}
+			if off < best {
+				closest, best = p.Dimensions, off
+			}
+		}
+		if !math.IsInf(best, 1) && best > dimTolerance {
+			add(Discrepancy{Kind: Dimensions, Expected: dimensions(closest), Billed: dimensions(line.Dimensions)})
+		}
+	}
+
+	if l.Residential != r.Residential {
+		add(Discrepancy{Kind: Residential, Expected: strconv.FormatBool(r.Residential), Billed: strconv.FormatBool(l.Residential)})
+	}
+
+	if !sameCurrency {
+		return f
+	}
+
+	billed, expected := categories(l.Charges), categories(q.Surcharges)
+	for _, category := range surcharge.Categories {
+		if diff := round(billed[category] - expected[category]); math.Abs(diff) > tolerance {
+			add(Discrepancy{
+				Kind:     Surcharge,
+				Category: category,
+				Expected: money(expected[category], currency),
+				Billed:   money(billed[category], currency),
+				Amount:   diff,
+			})
+		}
+	}
+
+	f.Overcharge = round(l.NetCharge - q.NetCharge)
+	if math.Abs(f.Overcharge) > tolerance {
+		add(Discrepancy{Kind: Total, Expected: money(q.NetCharge, currency), Billed: money(l.NetCharge, currency), Amount: f.Overcharge})
+	}
+	return f
+}
+
+// combine totals the lines of one shipment into one line under its master
+// tracking number.
+func combine(lines []InvoiceLine) InvoiceLine {
+	l := lines[0]
+	if len(lines) == 1 {
+		return l
+	}
+	l.Charges = nil
+	l.Pieces = 0
+	l.ActualWeight, l.RatedWeight = rate.Weight{}, rate.Weight{}
+	l.TransportationCharge, l.NetCharge = 0, 0
+	for _, p := range lines {
+		if l.MasterTrackingNumber == "" {
+			l.MasterTrackingNumber = p.MasterTrackingNumber
+		}
+		if l.Currency == "" {
+			l.Currency = p.Currency
+		}
+		l.Pieces += p.Pieces
+		l.ActualWeight = addWeight(l.ActualWeight, p.ActualWeight)
+		l.RatedWeight = addWeight(l.RatedWeight, p.RatedWeight)
+		l.Residential = l.Residential || p.Residential
+		l.TransportationCharge = round(l.TransportationCharge + p.TransportationCharge)
+		l.Charges = append(l.Charges, p.Charges...)
+		l.NetCharge = round(l.NetCharge + p.NetCharge)
+	}
+	if l.MasterTrackingNumber != "" {
+		l.TrackingNumber = l.MasterTrackingNumber
+	}
+	return l
+}
+
+func addWeight(total rate.Weight, w rate.Weight) rate.Weight {
+	switch {
+	case w.Value == 0:
+		return total
+	case total.Value == 0:
+		return w
+	case strings.EqualFold(total.Units, w.Units):
+		total.Value = math.Round((total.Value+w.Value)*1000) / 1000
+		return total
+	}
+	return rate.Weight{Units: "LB", Value: math.Round((pounds(total)+pounds(w))*1000) / 1000}
+}
+
+// categories totals the positive charges, i.e. not discounts, by category.
+func categories(charges []rate.QuoteCharge) map[surcharge.Category]float64 {
+	totals := map[surcharge.Category]float64{}
+	for _, c := range charges {
+		if c.Amount > 0 {
+			totals[surcharge.Classify(c.Type, c.Description)] += c.Amount
+		}
+	}
+	return totals
+}
+
+func pounds(w rate.Weight) float64 {
+	if strings.EqualFold(w.Units, "KG") {
+		return w.Value * 2.20462
+	}
+	return w.Value
+}
+
+// inches returns the sides of d in IN, longest first, so the order they
+// were measured in does not matter.
+func inches(d rate.Dimensions) [3]float64 {
+	sides := []float64{float64(d.Length), float64(d.Width), float64(d.Height)}
+	if strings.EqualFold(d.Units, "CM") {
+		for i := range sides {
+			sides[i] /= 2.54
+		}
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(sides)))
+	return [3]float64{sides[0], sides[1], sides[2]}
+}
+
+func weight(w rate.Weight) string {
+	return strconv.FormatFloat(w.Value, 'f', -1, 64) + " " + w.Units
+}
+
+func dimensions(d rate.Dimensions) string {
+	units := d.Units
+	if units == "" {
+		units = "IN"
+	}
+	return strconv.Itoa(d.Length) + "x" + strconv.Itoa(d.Width) + "x" + strconv.Itoa(d.Height) + " " + units
+}
+
+func money(amount float64, currency string) string {
+	s := strconv.FormatFloat(amount, 'f', 2, 64)
+	if currency != "" {
+		s += " " + currency
+	}
+	return s
+}
+
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
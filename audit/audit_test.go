@@ -0,0 +1,105 @@
+package audit
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tirpitz0509/go-fedex/rate"
+)
+
+func line(tracking string, master string, currency string, pounds float64, net float64) InvoiceLine {
+	return InvoiceLine{
+		InvoiceNumber:        "1-234-56789",
+		TrackingNumber:       tracking,
+		MasterTrackingNumber: master,
+		Currency:             currency,
+		RatedWeight:          rate.Weight{Units: "LB", Value: pounds},
+		NetCharge:            net,
+	}
+}
+
+func TestAudit(t *testing.T) {
+	records := []Record{
+		{
+			TrackingNumber: "794600000001",
+			Quote:          rate.Quote{Currency: "USD", NetCharge: 30, BillingWeight: rate.Weight{Units: "LB", Value: 15}},
+		},
+		{
+			TrackingNumber:         "794600000010",
+			PackageTrackingNumbers: []string{"794600000011"},
+			Quote:                  rate.Quote{Currency: "USD", NetCharge: 30, BillingWeight: rate.Weight{Units: "LB", Value: 15}},
+		},
+	}
+	tests := []struct {
+		name      string
+		lines     []InvoiceLine
+		unmatched int
+		kinds     []Kind  // of every finding, in order
+		disputed  float64 // total of the disputes
+	}{
+		{"billed as quoted", []InvoiceLine{line("794600000001", "", "USD", 15, 30)}, 0, nil, 0},
+		{"overbilled", []InvoiceLine{line("794600000001", "", "USD", 20, 36)}, 0, []Kind{Weight, Total}, 6},
+		{"unmatched", []InvoiceLine{line("794699999999", "", "USD", 15, 30)}, 1, []Kind{Unmatched}, 0},
+		{"pieces by master column", []InvoiceLine{
+			line("794600000001", "794600000001", "USD", 10, 20),
+			line("794600000002", "794600000001", "USD", 5, 10),
+		}, 0, nil, 0},
+		{"pieces by recorded package", []InvoiceLine{
+			line("794600000010", "", "USD", 10, 20),
+			line("794600000011", "", "USD", 5, 10),
+		}, 0, nil, 0},
+		{"piece overbilled", []InvoiceLine{
+			line("794600000011", "", "USD", 5, 14),
+			line("794600000010", "", "USD", 10, 20),
+		}, 0, []Kind{Total}, 4},
+		{"other currency", []InvoiceLine{line("794600000001", "", "CAD", 15, 41)}, 0, []Kind{Currency}, 0},
+		{"piece in other currency", []InvoiceLine{
+			line("794600000001", "794600000001", "USD", 10, 20),
+			line("794600000002", "794600000001", "CAD", 5, 14),
+		}, 0, []Kind{Currency}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Auditor{}.Audit(tt.lines, records)
+			if report.Lines != len(tt.lines) || report.Unmatched != tt.unmatched || report.Matched != len(tt.lines)-tt.unmatched {
+				t.Errorf("lines, matched, unmatched = %d, %d, %d", report.Lines, report.Matched, report.Unmatched)
+			}
+			var kinds []Kind
+			for _, f := range report.Findings {
+				for _, d := range f.Discrepancies {
+					kinds = append(kinds, d.Kind)
+				}
+			}
+			if !reflect.DeepEqual(kinds, tt.kinds) {
+				t.Errorf("discrepancies = %v, want %v", kinds, tt.kinds)
+			}
+			var disputed float64
+			for _, d := range report.Disputes() {
+				disputed += d.Amount
+			}
+			if disputed != tt.disputed {
+				t.Errorf("disputed %v, want %v", disputed, tt.disputed)
+			}
+		})
+	}
+}
+
+func TestCheckShipmentTotalsPieces(t *testing.T) {
+	r := Record{
+		TrackingNumber:         "794600000010",
+		PackageTrackingNumbers: []string{"794600000011"},
+		Quote:                  rate.Quote{Currency: "USD", NetCharge: 30, BillingWeight: rate.Weight{Units: "LB", Value: 15}},
+	}
+	lines := []InvoiceLine{
+		line("794600000011", "", "USD", 5, 12.5),
+		line("794600000010", "", "USD", 10, 20),
+	}
+	f := Auditor{}.CheckShipment(lines, r)
+	if f.Line.TrackingNumber != r.TrackingNumber || f.Line.NetCharge != 32.5 || f.Line.RatedWeight.Value != 15 || len(f.Pieces) != 2 {
+		t.Errorf("line = %s, %v, %v with %d pieces, want %s, 32.5, 15 LB with 2",
+			f.Line.TrackingNumber, f.Line.NetCharge, weight(f.Line.RatedWeight), len(f.Pieces), r.TrackingNumber)
+	}
+	if f.Overcharge != 2.5 {
+		t.Errorf("Overcharge = %v, want 2.5", f.Overcharge)
+	}
+}
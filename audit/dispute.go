@@ -0,0 +1,118 @@
+package audit
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Dispute is an overbilled invoice line in the shape FedEx billing disputes
+// ask for: the invoice, the tracking number, the amount and the reason.
+type Dispute struct {
+	InvoiceNumber  string  `json:"invoiceNumber"`       //
+	TrackingNumber string  `json:"trackingNumber"`      //
+	Reference      string  `json:"reference,omitempty"` //
+	Currency       string  `json:"currency"`            //
+	Quoted         float64 `json:"quoted"`              //
+	Billed         float64 `json:"billed"`              //
+	Amount         float64 `json:"amount"`              // to dispute, billed minus quoted
+	Reason         string  `json:"reason"`              //
+}
+
+// Disputes returns the matched shipments billed more than quoted, in
+// invoice order. Unmatched lines are left out, having nothing to dispute
+// against, and so are shipments billed in another currency than quoted,
+// whose charges cannot be compared.
+func (r Report) Disputes() []Dispute {
+	var disputes []Dispute
+	for _, f := range r.Findings {
+		if f.Record == nil || f.Overcharge <= 0 || f.has(Currency) {
+			continue
+		}
+		d := Dispute{
+			InvoiceNumber:  f.Line.InvoiceNumber,
+			TrackingNumber: f.Line.TrackingNumber,
+			Reference:      f.Record.Reference,
+			Currency:       f.Line.Currency,
+			Quoted:         f.Record.Quote.NetCharge,
+			Billed:         f.Line.NetCharge,
+			Amount:         f.Overcharge,
+		}
+		if d.Currency == "" {
+			d.Currency = f.Record.Quote.Currency
+		}
+		var reasons []string
+		for _, discrepancy := range f.Discrepancies {
+			if discrepancy.Kind != Total {
+				reasons = append(reasons, discrepancy.String())
+			}
+		}
+		if len(reasons) == 0 {
+			reasons = append(reasons, "billed above the quoted rate")
+		}
+		d.Reason = strings.Join(reasons, "; ")
+		disputes = append(disputes, d)
+	}
+	return disputes
+}
+
+func (f Finding) has(kind Kind) bool {
+	for _, d := range f.Discrepancies {
+		if d.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// InvoiceSummary totals the disputes of one invoice and currency.
+type InvoiceSummary struct {
+	InvoiceNumber string  `json:"invoiceNumber"` //
+	Currency      string  `json:"currency"`      //
+	Disputes      int     `json:"disputes"`      //
+	Amount        float64 `json:"amount"`        //
+}
+
+// Summaries totals disputes per invoice, by invoice number.
+func Summaries(disputes []Dispute) []InvoiceSummary {
+	index := map[[2]string]int{}
+	var summaries []InvoiceSummary
+	for _, d := range disputes {
+		key := [2]string{d.InvoiceNumber, d.Currency}
+		i, ok := index[key]
+		if !ok {
+			i = len(summaries)
+			index[key] = i
+			summaries = append(summaries, InvoiceSummary{InvoiceNumber: d.InvoiceNumber, Currency: d.Currency})
+		}
+		summaries[i].Disputes++
+		summaries[i].Amount = round(summaries[i].Amount + d.Amount)
+	}
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].InvoiceNumber < summaries[j].InvoiceNumber
+	})
+	return summaries
+}
+
+// DisputeCSVHeader is the header WriteDisputes writes.
+var DisputeCSVHeader = []string{
+	"invoiceNumber", "trackingNumber", "reference", "currency", "quoted", "billed", "amount", "reason",
+}
+
+// WriteDisputes writes disputes as CSV with a header, ready to upload or
+// attach to a dispute.
+func WriteDisputes(w io.Writer, disputes []Dispute) error {
+	c := csv.NewWriter(w)
+	c.Write(DisputeCSVHeader)
+	amount := func(f float64) string { return strconv.FormatFloat(f, 'f', 2, 64) }
+	for _, d := range disputes {
+		c.Write([]string{
+			d.InvoiceNumber, d.TrackingNumber, d.Reference, d.Currency,
+			amount(d.Quoted), amount(d.Billed), amount(d.Amount), d.Reason,
+		})
+	}
+	c.Flush()
+	return c.Error()
+}
@@ -0,0 +1,275 @@
+package audit
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/tirpitz0509/go-fedex/rate"
+	"github.com/tirpitz0509/go-fedex/surcharge"
+)
+
+// InvoiceLine is a shipment, usually one package, as billed on a FedEx
+// invoice.
+type InvoiceLine struct {
+	Line                 int                // 1-based line in the file
+	AccountNumber        string             //
+	InvoiceNumber        string             //
+	InvoiceDate          string             //
+	TrackingNumber       string             //
+	MasterTrackingNumber string             // of the multi-piece shipment the package is part of
+	References           []string           // customer reference, ref #2 and ref #3/PO, those present
+	ServiceType          string             // as printed, e.g. "FedEx Ground"
+	ShipDate             string             //
+	Currency             string             //
+	Zone                 string             //
+	Pieces               int                //
+	ActualWeight         rate.Weight        //
+	RatedWeight          rate.Weight        //
+	Dimensions           rate.Dimensions    //
+	Residential          bool               // a residential surcharge was billed
+	TransportationCharge float64            //
+	Charges              []rate.QuoteCharge // surcharges, and discounts as negative amounts
+	NetCharge            float64            //
+}
+
+// invoiceColumns maps the fields of InvoiceLine onto the header names of
+// the FedEx Billing Online CSV download and of the older electronic invoice
+// export, compared ignoring case, spaces and punctuation.
+var invoiceColumns = map[string][]string{
+	"account":        {"billtoaccountnumber", "accountnumber", "payoraccountnumber"},
+	"invoice":        {"invoicenumber"},
+	"invoiceDate":    {"invoicedate"},
+	"tracking":       {"expressorgroundtrackingid", "trackingid", "trackingnumber"},
+	"master":         {"mastertrackingid", "mastertrackingnumber", "mpsmastertrackingid"},
+	"reference":      {"originalcustomerreference", "customerreference", "reference"},
+	"reference2":     {"originalref2", "ref2"},
+	"reference3":     {"originalref3ponumber", "ref3ponumber", "ponumber"},
+	"service":        {"servicetype", "groundservice", "service"},
+	"shipDate":       {"shipmentdate", "shipdate"},
+	"currency":       {"currencycode", "currency"},
+	"zone":           {"zonecode", "zone"},
+	"pieces":         {"numberofpieces", "pieces"},
+	"actualWeight":   {"actualweightamount", "actualweight"},
+	"actualUnits":    {"actualweightunits"},
+	"ratedWeight":    {"ratedweightamount", "ratedweight", "billedweight"},
+	"ratedUnits":     {"ratedweightunits"},
+	"length":         {"dimlength"},
+	"width":          {"dimwidth"},
+	"height":         {"dimheight"},
+	"dimUnits":       {"dimunit", "dimunits"},
+	"transportation": {"transportationchargeamount", "transportationcharge"},
+	"net":            {"netchargeamount", "netcharge"},
+}
+
+// LoadInvoice reads a FedEx invoice in CSV.
+func LoadInvoice(path string) ([]InvoiceLine, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	lines, err := ReadInvoice(f)
+	if err != nil {
+		return nil, errors.New(path + ": " + err.Error())
+	}
+	return lines, nil
+}
+
+// ReadInvoice reads invoice lines from CSV with a header row. Charges are
+// taken from the repeated "Tracking ID Charge Description" and "Tracking ID
+// Charge Amount" column pairs; lines without a tracking number, such as
+// invoice level adjustments, are skipped.
+func ReadInvoice(r io.Reader) ([]InvoiceLine, error) {
+	c := csv.NewReader(r)
+	c.FieldsPerRecord = -1
+	header, err := c.Read()
+	if err == io.EOF {
+		return nil, errors.New("audit: the invoice is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	var descriptions, amounts []int
+	for i, name := range header {
+		name = columnName(name)
+		switch name {
+		case "trackingidchargedescription", "chargedescription":
+			descriptions = append(descriptions, i)
+			continue
+		case "trackingidchargeamount", "chargeamount":
+			amounts = append(amounts, i)
+			continue
+		}
+		for field, names := range invoiceColumns {
+			if _, ok := columns[field]; ok {
+				continue
+			}
+			for _, n := range names {
+				if name == n {
+					columns[field] = i
+				}
+			}
+		}
+	}
+	if _, ok := columns["tracking"]; !ok {
+		return nil, errors.New("audit: the invoice has no tracking ID column")
+	}
+	if _, ok := columns["net"]; !ok {
+		return nil, errors.New("audit: the invoice has no net charge column")
+	}
+
+	var lines []InvoiceLine
+	for n := 2; ; n++ {
+		record, err := c.Read()
+		if err == io.EOF {
+			return lines, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		get := func(field string) string {
+			if i, ok := columns[field]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if get("tracking") == "" {
+			continue
+		}
+		amount := func(field string) (float64, error) {
+			s := get(field)
+			if s == "" {
+				return 0, nil
+			}
+			v, ok := parseAmount(s)
+			if !ok {
+				return 0, errors.New("audit: line " + strconv.Itoa(n) + ": bad " + header[columns[field]] + " " + strconv.Quote(s))
+			}
+			return v, nil
+		}
+
+		l := InvoiceLine{
+			Line:                 n,
+			AccountNumber:        get("account"),
+			InvoiceNumber:        get("invoice"),
+			InvoiceDate:          get("invoiceDate"),
+			TrackingNumber:       get("tracking"),
+			MasterTrackingNumber: get("master"),
+			ServiceType:          get("service"),
+			ShipDate:             get("shipDate"),
+			Currency:             get("currency"),
+			Zone:                 get("zone"),
+		}
+		for _, field := range []string{"reference", "reference2", "reference3"} {
+			if ref := get(field); ref != "" {
+				l.References = append(l.References, ref)
+			}
+		}
+		l.Pieces, _ = strconv.Atoi(get("pieces"))
+		var values [7]float64
+		for i, field := range []string{"actualWeight", "ratedWeight", "length", "width", "height", "transportation", "net"} {
+			if values[i], err = amount(field); err != nil {
+				return nil, err
+			}
+		}
+		l.ActualWeight = rate.Weight{Units: weightUnits(get("actualUnits")), Value: values[0]}
+		l.RatedWeight = rate.Weight{Units: weightUnits(get("ratedUnits")), Value: values[1]}
+		l.Dimensions = rate.Dimensions{
+			Length: int(values[2] + 0.5),
+			Width:  int(values[3] + 0.5),
+			Height: int(values[4] + 0.5),
+			Units:  dimUnits(get("dimUnits")),
+		}
+		l.TransportationCharge, l.NetCharge = values[5], values[6]
+
+		for _, d := range descriptions {
+			a := nextAfter(amounts, d)
+			if d >= len(record) || a < 0 || a >= len(record) || strings.TrimSpace(record[d]) == "" {
+				continue
+			}
+			v, ok := parseAmount(record[a])
+			if !ok {
+				return nil, errors.New("audit: line " + strconv.Itoa(n) + ": bad charge amount " + strconv.Quote(record[a]))
+			}
+			charge := rate.QuoteCharge{Type: chargeType(record[d]), Description: strings.TrimSpace(record[d]), Amount: v}
+			l.Charges = append(l.Charges, charge)
+			if v > 0 && surcharge.Classify(charge.Type, charge.Description) == surcharge.Residential {
+				l.Residential = true
+			}
+		}
+		lines = append(lines, l)
+	}
+}
+
+// nextAfter returns the first column in columns after i, or -1.
+func nextAfter(columns []int, i int) int {
+	for _, c := range columns {
+		if c > i {
+			return c
+		}
+	}
+	return -1
+}
+
+func columnName(s string) string {
+	s = strings.ToLower(strings.TrimPrefix(s, "\ufeff"))
+	var b strings.Builder
+	for _, r := range s {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// chargeType turns an invoice charge description into a type code in the
+// style of rate replies, e.g. "Residential Delivery" into
+// RESIDENTIAL_DELIVERY.
+func chargeType(description string) string {
+	return strings.Join(strings.Fields(normalizeText(description)), "_")
+}
+
+func normalizeText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		if r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return ' '
+	}, s)
+}
+
+func weightUnits(units string) string {
+	switch strings.ToUpper(strings.TrimSpace(units)) {
+	case "K", "KG", "KGS":
+		return "KG"
+	}
+	return "LB"
+}
+
+func dimUnits(units string) string {
+	switch strings.ToUpper(strings.TrimSpace(units)) {
+	case "C", "CM":
+		return "CM"
+	}
+	return "IN"
+}
+
+func parseAmount(s string) (float64, bool) {
+	s = strings.NewReplacer("$", "", ",", "", " ", "").Replace(strings.TrimSpace(s))
+	negative := strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")")
+	s = strings.Trim(s, "()")
+	v, err := strconv.ParseFloat(s, 64)
+	if negative {
+		v = -v
+	}
+	return v, err == nil
+}
@@ -0,0 +1,83 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/tirpitz0509/go-fedex/rate"
+)
+
+// Record is a shipment as it was rated when it was shipped, kept to audit
+// the invoice later. Records are matched to invoice lines by tracking
+// number, of the shipment or of any of its packages, or failing that by
+// reference.
+type Record struct {
+	TrackingNumber         string         `json:"trackingNumber,omitempty"`         // the master tracking number of a multi-piece shipment
+	PackageTrackingNumbers []string       `json:"packageTrackingNumbers,omitempty"` // of the other packages of a multi-piece shipment
+	Reference              string         `json:"reference,omitempty"`              // e.g. the order number printed on the label
+	Residential            bool           `json:"residential"`                      //
+	Packages               []rate.Package `json:"packages,omitempty"`               // as declared
+	Quote                  rate.Quote     `json:"quote"`                            // the quote the shipment was booked at
+}
+
+// NewRecord records request as rated by quote; set TrackingNumber or
+// Reference once it has shipped.
+func NewRecord(request rate.RateRequest, quote rate.Quote) Record {
+	shipment := request.RequestedShipment
+	return Record{
+		Residential: shipment.Recipient.Address.Residential,
+		Packages:    shipment.RequestedPackageLineItems,
+		Quote:       quote,
+	}
+}
+
+// LoadRecords reads records from a JSONL file, one per line.
+func LoadRecords(path string) ([]Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var r Record
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			return nil, errors.New(path + ": line " + strconv.Itoa(n) + ": " + err.Error())
+		}
+		records = append(records, r)
+	}
+	return records, scanner.Err()
+}
+
+// AppendRecords appends records to a JSONL file, creating it if needed.
+func AppendRecords(path string, records ...Record) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	for _, r := range records {
+		content, err := json.Marshal(r)
+		if err != nil {
+			f.Close()
+			return err
+		}
+		w.Write(content)
+		w.WriteByte('\n')
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -1,11 +1,16 @@
 package auth
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
-	"log"
+	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
+
+	"github.com/tirpitz0509/go-fedex/common"
 )
 
 const (
@@ -14,11 +19,35 @@ const (
 	apiTokenUrl = "https://developer.fedex.com/api/en-ae/catalog/authorization/v1"
 )
 
+// defaultClient bounds token requests of callers without a client, which
+// would otherwise hold up every caller waiting on the token.
+var defaultClient = &http.Client{Timeout: time.Minute}
+
 type FedEXAuth struct {
-	GrantType    string `json:"grant_type"`    //
-	ClientId     string `json:"client_id"`     //
-	ClientSecret string `json:"client_secret"` //
-	TestMode     bool   `json:"-"`             //
+	GrantType       string                 `json:"grant_type"`             //
+	ClientId        string                 `json:"client_id"`              //
+	ClientSecret    string                 `json:"client_secret"`          //
+	ChildKey        string                 `json:"child_Key,omitempty"`    // csp_credentials and client_pc_credentials only
+	ChildSecret     string                 `json:"child_secret,omitempty"` //
+	TestMode        bool                   `json:"-"`                      //
+	Strategy        Strategy               `json:"-"`                      // overrides the fields above when set
+	BaseURL         string                 `json:"-"`                      // overrides the sandbox/live URL when set
+	Client          *http.Client           `json:"-"`                      // a client timing out after a minute if nil
+	Context         context.Context        `json:"-"`                      // parent of the call's span, and cancels it
+	Instrumentation common.Instrumentation `json:"-"`                      // the global one from common.Instrument if nil
+
+	CustomerTransactionID string              `json:"-"` // see common.Fedex.TransactionID
+	Middleware            []common.Middleware `json:"-"` // runs inside the global middleware from common.Use
+}
+
+func (c FedEXAuth) apiURL() string {
+	switch {
+	case c.BaseURL != "":
+		return c.BaseURL
+	case c.TestMode:
+		return apiURLTest
+	}
+	return apiURLLive
 }
 
 type FedexAuthResponse struct {
@@ -34,51 +63,105 @@ type FedexAuthResponse struct {
 }
 
 func (c FedEXAuth) Authorization() (*FedexAuthResponse, error) {
-	var _response FedexAuthResponse
-	var reqUrl string
-	client := &http.Client{}
+	return c.Authorize(c.GrantStrategy())
+}
 
-	if c.TestMode {
-		reqUrl = apiURLTest + "/oauth/token"
-	} else {
-		reqUrl = apiURLLive + "/oauth/token"
+// GrantStrategy maps the grant type and credentials set on c to a Strategy,
+// unless one was given explicitly.
+func (c FedEXAuth) GrantStrategy() Strategy {
+	if c.Strategy != nil {
+		return c.Strategy
 	}
+	switch c.GrantType {
+	case GrantCSPCredentials:
+		return CSPCredentials{ClientId: c.ClientId, ClientSecret: c.ClientSecret, ChildKey: c.ChildKey, ChildSecret: c.ChildSecret}
+	case GrantClientPCCredentials:
+		return ParentChildCredentials{ClientId: c.ClientId, ClientSecret: c.ClientSecret, ChildKey: c.ChildKey, ChildSecret: c.ChildSecret}
+	}
+	return ClientCredentials{ClientId: c.ClientId, ClientSecret: c.ClientSecret}
+}
 
-	request := strings.NewReader(
-		`grant_type=` + c.GrantType + `&client_id=` + c.ClientId + `&client_secret=` + c.ClientSecret,
-	)
+// Authorize requests a token for strategy from the environment of c, so one
+// FedEXAuth can serve many child accounts.
+func (c FedEXAuth) Authorize(strategy Strategy) (*FedexAuthResponse, error) {
+	form := strategy.Form()
+	transactionId := common.Fedex{Context: c.Context, CustomerTransactionID: c.CustomerTransactionID}.TransactionID()
+	call := common.StartCall(c.Context, c.Instrumentation, common.OperationAuth)
+	call.SetAttribute(common.AttributeGrantType, form.Get("grant_type"))
+	call.SetAttribute(common.AttributeCustomerTransactionID, transactionId)
 
-	log.Printf("%s", request)
+	var response *FedexAuthResponse
+	var statusCode int
+	ex := &common.Exchange{Operation: common.OperationAuth, Request: &form, Header: http.Header{}}
+	err := common.Chain(func(ex *common.Exchange) error {
+		var err error
+		response, statusCode, err = c.authorize(call.Context(), form, transactionId, ex)
+		ex.Result = response
+		return err
+	}, c.Middleware...)(ex)
+	if r, ok := ex.Result.(*FedexAuthResponse); ok && r != nil {
+		response = r
+	} else if response == nil {
+		response = &FedexAuthResponse{}
+	}
 
-	req, err := http.NewRequest("POST", reqUrl, request)
+	if len(response.Errors) > 0 {
+		call.ErrorCode(response.Errors[0].Code)
+	}
+	call.End(statusCode, err)
+	return response, err
+}
+
+func (c FedEXAuth) authorize(ctx context.Context, form url.Values, transactionId string, ex *common.Exchange) (*FedexAuthResponse, int, error) {
+	var _response FedexAuthResponse
+	client := c.Client
+	if client == nil {
+		client = defaultClient
+	}
+	reqUrl := c.apiURL() + "/oauth/token"
+
+	body := form.Encode()
+	req, err := http.NewRequestWithContext(ctx, "POST", reqUrl, strings.NewReader(body))
+	if err != nil {
+		return &_response, 0, err
+	}
 	req.Header.Add("Content-type", "application/x-www-form-urlencoded")
 	req.Header.Add("Accept", "application/json")
+	req.Header.Add(common.HeaderCustomerTransactionID, transactionId)
+	ex.Sent(req, []byte(body))
 
 	resp, err := client.Do(req)
 
 	if err != nil {
-		return &_response, err
+		return &_response, 0, err
 	}
 	defer resp.Body.Close()
 
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return &_response, resp.StatusCode, err
+	}
+	ex.Received(resp, content)
+
 	if resp.StatusCode == 200 {
-		data := json.NewDecoder(resp.Body)
-		errjson := data.Decode(&_response)
+		errjson := json.Unmarshal(content, &_response)
 		if errjson != nil {
-			return &_response, errjson
-		}
-		if c.TestMode {
-			_response.Url = apiURLTest
-		} else {
-			_response.Url = apiURLLive
+			return &_response, resp.StatusCode, errjson
 		}
-		return &_response, nil
+		_response.Url = c.apiURL()
+		return &_response, resp.StatusCode, nil
 	} else {
-		dataError := json.NewDecoder(resp.Body)
-		dataError.Decode(&_response)
-		return &_response, errors.New(_response.Errors[0].Message)
+		json.Unmarshal(content, &_response)
+		if len(_response.Errors) == 0 {
+			return &_response, resp.StatusCode, errors.New(resp.Status)
+		}
+		return &_response, resp.StatusCode, errors.New(_response.Errors[0].Message)
 	}
+}
 
-	return &_response, nil
-
+// TokenSource returns a function handing out access tokens together with the
+// API URL they belong to, requesting a new token shortly before the current
+// one expires. It fits rate.TokenSource.
+func (c FedEXAuth) TokenSource() func() (string, string, error) {
+	return NewTokenCache(c).TokenSource(c.GrantStrategy())
 }
@@ -0,0 +1,159 @@
+package auth_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tirpitz0509/go-fedex/auth"
+	"github.com/tirpitz0509/go-fedex/common"
+	"github.com/tirpitz0509/go-fedex/fedextest"
+)
+
+func tokenRequests(s *fedextest.Server) int {
+	n := 0
+	for _, r := range s.Requests() {
+		if r.Path == fedextest.TokenPath {
+			n++
+		}
+	}
+	return n
+}
+
+func TestTokenCacheSharesRequests(t *testing.T) {
+	tests := []struct {
+		name       string
+		strategies []auth.Strategy
+		want       int
+	}{
+		{"same key", []auth.Strategy{
+			auth.ClientCredentials{ClientId: "id", ClientSecret: "secret"},
+			auth.ClientCredentials{ClientId: "id", ClientSecret: "secret"},
+			auth.ClientCredentials{ClientId: "id", ClientSecret: "secret"},
+		}, 1},
+		{"two children", []auth.Strategy{
+			auth.CSPCredentials{ClientId: "id", ClientSecret: "secret", ChildKey: "a", ChildSecret: "a"},
+			auth.CSPCredentials{ClientId: "id", ClientSecret: "secret", ChildKey: "b", ChildSecret: "b"},
+			auth.CSPCredentials{ClientId: "id", ClientSecret: "secret", ChildKey: "a", ChildSecret: "a"},
+		}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := fedextest.NewServer()
+			defer s.Close()
+			s.Latency(50 * time.Millisecond)
+			cache := auth.NewTokenCache(s.Auth("id", "secret"))
+
+			var wg sync.WaitGroup
+			tokens := make([]string, len(tt.strategies))
+			for i, strategy := range tt.strategies {
+				wg.Add(1)
+				go func(i int, strategy auth.Strategy) {
+					defer wg.Done()
+					response, err := cache.Token(strategy)
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					tokens[i] = response.AccessToken
+				}(i, strategy)
+			}
+			wg.Wait()
+
+			if n := tokenRequests(s); n != tt.want {
+				t.Errorf("%d token requests, want %d", n, tt.want)
+			}
+			if tokens[0] == "" || tokens[0] != tokens[2] {
+				t.Errorf("tokens = %v, want the first and last shared", tokens)
+			}
+		})
+	}
+}
+
+func TestAuthorizeContext(t *testing.T) {
+	s := fedextest.NewServer()
+	defer s.Close()
+	s.Latency(time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	a := s.Auth("id", "secret")
+	a.Context = ctx
+	start := time.Now()
+	if _, err := a.Authorization(); err == nil {
+		t.Fatal("Authorization() succeeded after its context was done")
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Errorf("Authorization() took %v to give up", d)
+	}
+}
+
+type recorder struct {
+	mu       sync.Mutex
+	spans    []string
+	counters map[string]int64
+}
+
+type span struct{}
+
+func (span) SetAttribute(key string, value interface{}) {}
+func (span) RecordError(err error)                      {}
+func (span) End()                                       {}
+
+func (r *recorder) StartSpan(ctx context.Context, operation string) (context.Context, common.Span) {
+	r.mu.Lock()
+	r.spans = append(r.spans, operation)
+	r.mu.Unlock()
+	return ctx, span{}
+}
+
+func (r *recorder) AddCounter(name string, value int64, attributes map[string]string) {
+	r.mu.Lock()
+	if r.counters == nil {
+		r.counters = map[string]int64{}
+	}
+	r.counters[name+" "+attributes["result"]] += value
+	r.mu.Unlock()
+}
+
+func (r *recorder) RecordHistogram(name string, value float64, attributes map[string]string) {}
+
+func TestAuthorizeTransactionID(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		id   string
+		want string
+	}{
+		{"from context", common.WithTransactionID(context.Background(), "order-1"), "", "order-1"},
+		{"explicit", context.Background(), "order-2", "order-2"},
+		{"generated", nil, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := fedextest.NewServer()
+			defer s.Close()
+			r := &recorder{}
+			a := s.Auth("id", "secret")
+			a.Context, a.CustomerTransactionID, a.Instrumentation = tt.ctx, tt.id, r
+
+			cache := auth.NewTokenCache(a)
+			for i := 0; i < 2; i++ {
+				if _, err := cache.Token(a.GrantStrategy()); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got := s.Requests()[0].Header.Get(common.HeaderCustomerTransactionID)
+			if got == "" || tt.want != "" && got != tt.want {
+				t.Errorf("transaction ID = %q, want %q", got, tt.want)
+			}
+			if len(r.spans) != 1 || r.spans[0] != common.OperationAuth {
+				t.Errorf("spans = %v, want one %s", r.spans, common.OperationAuth)
+			}
+			if r.counters[common.MetricTokenCache+" hit"] != 1 || r.counters[common.MetricTokenCache+" miss"] != 1 {
+				t.Errorf("counters = %v, want a miss and a hit", r.counters)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package auth
+
+import "net/url"
+
+const (
+	GrantClientCredentials   = "client_credentials"
+	GrantCSPCredentials      = "csp_credentials"
+	GrantClientPCCredentials = "client_pc_credentials"
+)
+
+// Strategy is one of the FedEx OAuth grant types. Form returns the values
+// posted to /oauth/token; CacheKey tells apart the tokens of different
+// customers, so each child account gets its own.
+type Strategy interface {
+	Form() url.Values
+	CacheKey() string
+}
+
+// ClientCredentials is the grant for customers and integrators using their
+// own project's API key.
+type ClientCredentials struct {
+	ClientId     string //
+	ClientSecret string //
+}
+
+func (c ClientCredentials) Form() url.Values {
+	return url.Values{
+		"grant_type":    {GrantClientCredentials},
+		"client_id":     {c.ClientId},
+		"client_secret": {c.ClientSecret},
+	}
+}
+
+func (c ClientCredentials) CacheKey() string {
+	return GrantClientCredentials + " " + c.ClientId
+}
+
+// CSPCredentials is the grant for compatible providers acting for one of
+// their customers, identified by the customer's child key and secret.
+type CSPCredentials struct {
+	ClientId     string //
+	ClientSecret string //
+	ChildKey     string //
+	ChildSecret  string //
+}
+
+func (c CSPCredentials) Form() url.Values {
+	return childForm(GrantCSPCredentials, c.ClientId, c.ClientSecret, c.ChildKey, c.ChildSecret)
+}
+
+func (c CSPCredentials) CacheKey() string {
+	return GrantCSPCredentials + " " + c.ClientId + " " + c.ChildKey
+}
+
+// ParentChildCredentials is the client_pc_credentials grant used by
+// proprietary parent/child integrations.
+type ParentChildCredentials struct {
+	ClientId     string //
+	ClientSecret string //
+	ChildKey     string //
+	ChildSecret  string //
+}
+
+func (c ParentChildCredentials) Form() url.Values {
+	return childForm(GrantClientPCCredentials, c.ClientId, c.ClientSecret, c.ChildKey, c.ChildSecret)
+}
+
+func (c ParentChildCredentials) CacheKey() string {
+	return GrantClientPCCredentials + " " + c.ClientId + " " + c.ChildKey
+}
+
+func childForm(grantType string, clientId string, clientSecret string, childKey string, childSecret string) url.Values {
+	return url.Values{
+		"grant_type":    {grantType},
+		"client_id":     {clientId},
+		"client_secret": {clientSecret},
+		"child_Key":     {childKey},
+		"child_secret":  {childSecret},
+	}
+}
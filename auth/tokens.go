@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"sync"
+	"time"
+
+	"github.com/tirpitz0509/go-fedex/common"
+)
+
+// TokenCache keeps one token per Strategy.CacheKey, so a provider serving
+// many customers reuses each child account's token until shortly before it
+// expires. Tokens are requested through Auth's environment and client; the
+// cache is not locked meanwhile, and callers wanting the same token share
+// one request.
+type TokenCache struct {
+	Auth FedEXAuth //
+
+	mu       sync.Mutex
+	tokens   map[string]cachedToken
+	fetching map[string]*fetch
+}
+
+type cachedToken struct {
+	response FedexAuthResponse
+	expires  time.Time
+}
+
+// fetch is a token request in flight; done is closed once response and err
+// are set.
+type fetch struct {
+	done     chan struct{}
+	response *FedexAuthResponse
+	err      error
+}
+
+func NewTokenCache(auth FedEXAuth) *TokenCache {
+	return &TokenCache{Auth: auth, tokens: map[string]cachedToken{}, fetching: map[string]*fetch{}}
+}
+
+// Token returns the cached token for strategy, requesting a new one when
+// there is none or it is about to expire.
+func (c *TokenCache) Token(strategy Strategy) (*FedexAuthResponse, error) {
+	key := strategy.CacheKey()
+
+	c.mu.Lock()
+	if c.tokens == nil {
+		c.tokens = map[string]cachedToken{}
+	}
+	if c.fetching == nil {
+		c.fetching = map[string]*fetch{}
+	}
+	if t, ok := c.tokens[key]; ok && time.Now().Before(t.expires) {
+		c.mu.Unlock()
+		common.Count(c.Auth.Instrumentation, common.MetricTokenCache, 1, map[string]string{"result": "hit"})
+		response := t.response
+		return &response, nil
+	}
+	if f, ok := c.fetching[key]; ok {
+		c.mu.Unlock()
+		common.Count(c.Auth.Instrumentation, common.MetricTokenCache, 1, map[string]string{"result": "shared"})
+		<-f.done
+		if f.err != nil {
+			return f.response, f.err
+		}
+		response := *f.response
+		return &response, nil
+	}
+	f := &fetch{done: make(chan struct{})}
+	c.fetching[key] = f
+	c.mu.Unlock()
+	common.Count(c.Auth.Instrumentation, common.MetricTokenCache, 1, map[string]string{"result": "miss"})
+
+	f.response, f.err = c.Auth.Authorize(strategy)
+	c.mu.Lock()
+	delete(c.fetching, key)
+	if f.err == nil {
+		c.tokens[key] = cachedToken{response: *f.response, expires: expiry(f.response.ExpiresIn)}
+	}
+	c.mu.Unlock()
+	close(f.done)
+
+	if f.err != nil {
+		return f.response, f.err
+	}
+	common.Count(c.Auth.Instrumentation, common.MetricTokenRefreshes, 1, map[string]string{"grant_type": strategy.Form().Get("grant_type")})
+	response := *f.response
+	return &response, nil
+}
+
+// expiry is when a token valid for expiresIn seconds is renewed: a minute
+// early, or halfway through for tokens valid less than two minutes.
+func expiry(expiresIn int) time.Time {
+	valid := time.Duration(expiresIn) * time.Second
+	margin := time.Minute
+	if valid < 2*margin {
+		margin = valid / 2
+	}
+	return time.Now().Add(valid - margin)
+}
+
+// Invalidate drops the token for strategy, e.g. after FedEx rejected it.
+func (c *TokenCache) Invalidate(strategy Strategy) {
+	c.mu.Lock()
+	delete(c.tokens, strategy.CacheKey())
+	c.mu.Unlock()
+}
+
+// TokenSource returns a function in the shape of rate.TokenSource for one
+// strategy.
+func (c *TokenCache) TokenSource(strategy Strategy) func() (string, string, error) {
+	return func() (string, string, error) {
+		response, err := c.Token(strategy)
+		if err != nil {
+			return "", "", err
+		}
+		return response.AccessToken, response.Url, nil
+	}
+}
@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpiry(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiresIn int
+		want      time.Duration
+	}{
+		{"hour", 3600, 59 * time.Minute},
+		{"two minutes", 120, time.Minute},
+		{"one minute", 60, 30 * time.Second},
+		{"ten seconds", 10, 5 * time.Second},
+		{"none", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := time.Until(expiry(tt.expiresIn))
+			if got > tt.want || got < tt.want-time.Second {
+				t.Errorf("expiry(%d) is in %v, want %v", tt.expiresIn, got, tt.want)
+			}
+		})
+	}
+}
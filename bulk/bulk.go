@@ -0,0 +1,257 @@
+// Package bulk rates shipments listed in CSV or JSONL files, e.g. to
+// reprice a catalog, and can pick up where an interrupted run stopped
+// without rating finished rows again.
+package bulk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/tirpitz0509/go-fedex/rate"
+)
+
+// Rater quotes one request; tenant.Tenant.Rate fits.
+type Rater func(request rate.RateRequest) (rate.RateResponse, error)
+
+// Result is the outcome of one input row.
+type Result struct {
+	Row    int          `json:"row"`              // 1-based, not counting a CSV header
+	ID     string       `json:"id,omitempty"`     //
+	Quotes []rate.Quote `json:"quotes,omitempty"` //
+	Error  string       `json:"error,omitempty"`  //
+}
+
+type Summary struct {
+	Rows    int // rows read
+	Skipped int // rated by an earlier run
+	Rated   int //
+	Failed  int //
+}
+
+// Job describes a bulk run. Results are written in the order they finish,
+// so use Result.Row to match them to the input.
+type Job struct {
+	Input         string          // CSV with a header row, or JSONL
+	Output        string          //
+	InputFormat   Format          // from the file name if empty
+	OutputFormat  Format          // from the file name if empty
+	Mapping       Mapping         //
+	Rate          Rater           //
+	AccountNumber string          // for the built requests; Rate may override it
+	Concurrency   int             // requests in flight, 4 if 0
+	Checkpoint    string          // Output + ".checkpoint" if empty
+	Resume        bool            // append to Output, skipping the rows Checkpoint lists as rated
+	Context       context.Context // stops reading new rows when done
+	Progress      func(Result)    // called after each result is written
+}
+
+// Run rates every row not rated before. A row that cannot be built or
+// rated is written with its error and counted as failed; only input, output
+// and checkpoint errors stop the run.
+//
+// The checkpoint lists the rows rated, each added after its result is
+// flushed to Output. Failed rows are left out, so a resumed run tries them
+// again and Output then holds both results; the later one counts. A crash
+// between flushing and checkpointing may repeat that one row too.
+func (j Job) Run() (Summary, error) {
+	var summary Summary
+	if j.Rate == nil {
+		return summary, errors.New("bulk: no Rater")
+	}
+	if err := j.Mapping.Validate(); err != nil {
+		return summary, err
+	}
+	ctx := j.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	concurrency := j.Concurrency
+	if concurrency < 1 {
+		concurrency = 4
+	}
+	inputFormat, outputFormat := j.InputFormat, j.OutputFormat
+	if inputFormat == "" {
+		inputFormat = FormatOf(j.Input)
+	}
+	if outputFormat == "" {
+		outputFormat = FormatOf(j.Output)
+	}
+	checkpointPath := j.Checkpoint
+	if checkpointPath == "" {
+		checkpointPath = j.Output + ".checkpoint"
+	}
+
+	done := map[int]bool{}
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if j.Resume {
+		var err error
+		if done, err = readCheckpoint(checkpointPath); err != nil {
+			return summary, err
+		}
+		flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	}
+
+	in, err := os.Open(j.Input)
+	if err != nil {
+		return summary, err
+	}
+	defer in.Close()
+	rows, err := newRowReader(in, inputFormat)
+	if err != nil {
+		return summary, err
+	}
+
+	out, err := os.OpenFile(j.Output, flags, 0644)
+	if err != nil {
+		return summary, err
+	}
+	defer out.Close()
+	info, err := out.Stat()
+	if err != nil {
+		return summary, err
+	}
+	writer := newResultWriter(out, outputFormat, info.Size() == 0)
+
+	checkpoint, err := os.OpenFile(checkpointPath, flags, 0644)
+	if err != nil {
+		return summary, err
+	}
+	defer checkpoint.Close()
+
+	type task struct {
+		row    int
+		values map[string]string
+		err    error
+	}
+	tasks := make(chan task)
+	results := make(chan Result)
+	stop := make(chan struct{})
+	var readErr error
+
+	go func() {
+		defer close(tasks)
+		for {
+			row, values, err := rows.next()
+			if err == io.EOF {
+				return
+			}
+			if err != nil && row == 0 {
+				readErr = err
+				return
+			}
+			summary.Rows++
+			if done[row] {
+				summary.Skipped++
+				continue
+			}
+			select {
+			case tasks <- task{row, values, err}:
+			case <-stop:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var workers sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for t := range tasks {
+				results <- j.rate(t.row, t.values, t.err)
+			}
+		}()
+	}
+	go func() {
+		workers.Wait()
+		close(results)
+	}()
+
+	var writeErr error
+	for result := range results {
+		if writeErr != nil {
+			continue
+		}
+		writeErr = writer.write(result)
+		if writeErr == nil {
+			writeErr = writer.flush()
+		}
+		if writeErr == nil && result.Error == "" {
+			_, writeErr = checkpoint.WriteString(strconv.Itoa(result.Row) + "\n")
+		}
+		if writeErr != nil {
+			close(stop)
+			continue
+		}
+		if result.Error != "" {
+			summary.Failed++
+		} else {
+			summary.Rated++
+		}
+		if j.Progress != nil {
+			j.Progress(result)
+		}
+	}
+
+	// the reader has finished once results is closed
+	if writeErr != nil {
+		return summary, writeErr
+	}
+	if readErr != nil {
+		return summary, readErr
+	}
+	return summary, ctx.Err()
+}
+
+func (j Job) rate(row int, values map[string]string, readErr error) Result {
+	result := Result{Row: row}
+	if readErr != nil {
+		result.Error = readErr.Error()
+		return result
+	}
+	id, request, err := j.Mapping.Request(values, j.AccountNumber)
+	result.ID = id
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	response, err := j.Rate(request)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Quotes = response.Quotes()
+	if len(result.Quotes) == 0 {
+		result.Error = "no rates returned"
+	}
+	return result
+}
+
+// readCheckpoint returns the rows listed in the checkpoint at path, none if
+// it does not exist. A last line without a newline was torn by a crash and
+// is ignored.
+func readCheckpoint(path string) (map[int]bool, error) {
+	done := map[int]bool{}
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return done, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(string(content), "\n")
+	for _, line := range lines[:len(lines)-1] {
+		if row, err := strconv.Atoi(strings.TrimSpace(line)); err == nil {
+			done[row] = true
+		}
+	}
+	return done, nil
+}
@@ -0,0 +1,86 @@
+package bulk_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tirpitz0509/go-fedex/bulk"
+	"github.com/tirpitz0509/go-fedex/fedextest"
+	"github.com/tirpitz0509/go-fedex/rate"
+)
+
+const catalog = `id,from.country,from.postal,to.country,to.postal,service,weight
+a,US,38017,US,90210,FEDEX_GROUND,5
+b,US,38017,US,10001,FEDEX_GROUND,2
+c,US,38017,US,60601,FEDEX_GROUND,12
+`
+
+func TestResumeRetriesFailedRows(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		first    bulk.Summary
+		resumed  bulk.Summary
+	}{
+		{"none failed", 0, bulk.Summary{Rows: 3, Rated: 3}, bulk.Summary{Rows: 3, Skipped: 3}},
+		{"first failed", 1, bulk.Summary{Rows: 3, Rated: 2, Failed: 1}, bulk.Summary{Rows: 3, Skipped: 2, Rated: 1}},
+		{"all failed", 3, bulk.Summary{Rows: 3, Failed: 3}, bulk.Summary{Rows: 3, Rated: 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := fedextest.NewServer()
+			defer s.Close()
+			s.Quotes(rate.Quote{NetCharge: 10, Currency: "USD"})
+			for i := 0; i < tt.failures; i++ {
+				s.Fail(503, "SERVICE.UNAVAILABLE.ERROR", "try again")
+			}
+			token, err := s.Auth("id", "secret").Authorization()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			dir := t.TempDir()
+			input := filepath.Join(dir, "catalog.csv")
+			if err := ioutil.WriteFile(input, []byte(catalog), 0644); err != nil {
+				t.Fatal(err)
+			}
+			job := bulk.Job{
+				Input:         input,
+				Output:        filepath.Join(dir, "rates.jsonl"),
+				AccountNumber: "123456789",
+				Concurrency:   1,
+				Rate: func(request rate.RateRequest) (rate.RateResponse, error) {
+					return request.RateWith(s.REST(), token.AccessToken)
+				},
+			}
+
+			summary, err := job.Run()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if summary != tt.first {
+				t.Errorf("first run = %+v, want %+v", summary, tt.first)
+			}
+
+			job.Resume = true
+			summary, err = job.Run()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if summary != tt.resumed {
+				t.Errorf("resumed run = %+v, want %+v", summary, tt.resumed)
+			}
+
+			content, err := ioutil.ReadFile(job.Output)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+			if want := 3 + tt.failures; len(lines) != want {
+				t.Errorf("output has %d results, want %d:\n%s", len(lines), want, content)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package bulk
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type Format string
+
+const (
+	CSV   Format = "csv"
+	JSONL Format = "jsonl"
+)
+
+// FormatOf guesses the format from a file name, CSV unless it ends in
+// .jsonl, .ndjson or .json.
+func FormatOf(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl", ".ndjson", ".json":
+		return JSONL
+	}
+	return CSV
+}
+
+// rowReader yields input rows as column -> value along with their 1-based
+// position, not counting a CSV header.
+type rowReader interface {
+	next() (int, map[string]string, error)
+}
+
+func newRowReader(r io.Reader, format Format) (rowReader, error) {
+	if format == JSONL {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		return &jsonlReader{scanner: scanner}, nil
+	}
+	c := csv.NewReader(r)
+	c.FieldsPerRecord = -1
+	header, err := c.Read()
+	if err == io.EOF {
+		return nil, errors.New("bulk: the input is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff"))
+	}
+	return &csvReader{reader: c, header: header}, nil
+}
+
+type csvReader struct {
+	reader *csv.Reader
+	header []string
+	row    int
+}
+
+func (r *csvReader) next() (int, map[string]string, error) {
+	record, err := r.reader.Read()
+	if err != nil {
+		return 0, nil, err
+	}
+	r.row++
+	values := make(map[string]string, len(r.header))
+	for i, column := range r.header {
+		if i < len(record) {
+			values[column] = record[i]
+		}
+	}
+	return r.row, values, nil
+}
+
+type jsonlReader struct {
+	scanner *bufio.Scanner
+	row     int
+}
+
+func (r *jsonlReader) next() (int, map[string]string, error) {
+	for r.scanner.Scan() {
+		r.row++
+		line := strings.TrimSpace(r.scanner.Text())
+		if line == "" {
+			continue
+		}
+		var object map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &object); err != nil {
+			return r.row, nil, err
+		}
+		values := make(map[string]string, len(object))
+		for k, v := range object {
+			switch v := v.(type) {
+			case nil:
+			case string:
+				values[k] = v
+			case float64:
+				values[k] = strconv.FormatFloat(v, 'f', -1, 64)
+			case bool:
+				values[k] = strconv.FormatBool(v)
+			default:
+				content, _ := json.Marshal(v)
+				values[k] = string(content)
+			}
+		}
+		return r.row, values, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return 0, nil, err
+	}
+	return 0, nil, io.EOF
+}
+
+// resultWriter writes results; header tells it whether to start the file
+// with a header, i.e. whether it is new.
+type resultWriter interface {
+	write(result Result) error
+	flush() error
+}
+
+func newResultWriter(w io.Writer, format Format, header bool) resultWriter {
+	if format == JSONL {
+		return &jsonlWriter{writer: bufio.NewWriter(w)}
+	}
+	return &csvWriter{writer: csv.NewWriter(w), header: header}
+}
+
+type jsonlWriter struct {
+	writer *bufio.Writer
+}
+
+func (w *jsonlWriter) write(result Result) error {
+	content, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	w.writer.Write(content)
+	return w.writer.WriteByte('\n')
+}
+
+func (w *jsonlWriter) flush() error {
+	return w.writer.Flush()
+}
+
+// CSVHeader is the header of CSV output, which has a line per quote, or a
+// single line carrying the error.
+var CSVHeader = []string{
+	"row", "id", "serviceType", "rateType", "currency", "baseCharge", "totalSurcharges",
+	"discounts", "netCharge", "billingWeight", "rateZone", "transitDays", "error",
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+	header bool
+}
+
+func (w *csvWriter) write(result Result) error {
+	if w.header {
+		w.header = false
+		if err := w.writer.Write(CSVHeader); err != nil {
+			return err
+		}
+	}
+	row := strconv.Itoa(result.Row)
+	if len(result.Quotes) == 0 {
+		return w.writer.Write([]string{row, result.ID, "", "", "", "", "", "", "", "", "", "", result.Error})
+	}
+	amount := func(f float64) string { return strconv.FormatFloat(f, 'f', 2, 64) }
+	for _, q := range result.Quotes {
+		var transitDays string
+		if q.TransitDays > 0 {
+			transitDays = strconv.Itoa(q.TransitDays)
+		}
+		var billingWeight string
+		if q.BillingWeight.Value > 0 {
+			billingWeight = strconv.FormatFloat(q.BillingWeight.Value, 'f', -1, 64) + " " + q.BillingWeight.Units
+		}
+		err := w.writer.Write([]string{
+			row, result.ID, string(q.ServiceType), string(q.RateType), q.Currency,
+			amount(q.BaseCharge), amount(q.TotalSurcharges), amount(q.Discounts), amount(q.NetCharge),
+			billingWeight, q.RateZone, transitDays, result.Error,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *csvWriter) flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
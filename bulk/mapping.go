@@ -0,0 +1,192 @@
+package bulk
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tirpitz0509/go-fedex/rate"
+	"gopkg.in/yaml.v3"
+)
+
+// Fields a Mapping can fill. Addresses are origin (from.*) and destination
+// (to.*); the package fields describe one line item, repeated packages times.
+var Fields = []string{
+	"id",
+	"from.country", "from.postal", "from.state", "from.city",
+	"to.country", "to.postal", "to.state", "to.city", "to.residential",
+	"service", "packaging", "pickup", "rateTypes", "shipDate", "currency",
+	"weight", "weight.units", "length", "width", "height", "dimensions.units",
+	"packages", "declaredValue",
+}
+
+// Mapping says which input column holds each field. A field without a
+// column is read from the column of the same name; Defaults fill fields
+// whose column is missing or empty.
+type Mapping struct {
+	Columns  map[string]string `json:"columns" yaml:"columns"`   // field -> column
+	Defaults map[string]string `json:"defaults" yaml:"defaults"` // field -> value
+}
+
+// LoadMapping reads a mapping from a YAML or JSON file.
+func LoadMapping(path string) (Mapping, error) {
+	var m Mapping
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return m, err
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(content, &m)
+	} else {
+		err = yaml.Unmarshal(content, &m)
+	}
+	if err != nil {
+		return m, errors.New("bulk: " + path + ": " + err.Error())
+	}
+	return m, m.Validate()
+}
+
+// Validate rejects unknown field names, which are usually typos.
+func (m Mapping) Validate() error {
+	known := map[string]bool{}
+	for _, f := range Fields {
+		known[f] = true
+	}
+	var unknown []string
+	for f := range m.Columns {
+		if !known[f] {
+			unknown = append(unknown, f)
+		}
+	}
+	for f := range m.Defaults {
+		if !known[f] {
+			unknown = append(unknown, f)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return errors.New("bulk: unknown mapping fields " + strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+func (m Mapping) value(row map[string]string, field string) string {
+	column := m.Columns[field]
+	if column == "" {
+		column = field
+	}
+	if v := strings.TrimSpace(row[column]); v != "" {
+		return v
+	}
+	return strings.TrimSpace(m.Defaults[field])
+}
+
+// Request builds the rate request for one row. It returns the row's id
+// together with any error, so failures can still be reported against it.
+func (m Mapping) Request(row map[string]string, accountNumber string) (string, rate.RateRequest, error) {
+	get := func(field string) string { return m.value(row, field) }
+	id := get("id")
+
+	var errs []string
+	number := func(field string) float64 {
+		v := get(field)
+		if v == "" {
+			return 0
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			errs = append(errs, field+": not a number: "+strconv.Quote(v))
+		}
+		return f
+	}
+	integer := func(field string) int {
+		v := get(field)
+		if v == "" {
+			return 0
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			errs = append(errs, field+": not a whole number: "+strconv.Quote(v))
+		}
+		return n
+	}
+	flag := func(field string) bool {
+		v := get(field)
+		if v == "" {
+			return false
+		}
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = append(errs, field+": not true or false: "+strconv.Quote(v))
+		}
+		return b
+	}
+
+	b := rate.NewShipment(accountNumber)
+	b.From(rate.Address{
+		CountryCode:         strings.ToUpper(get("from.country")),
+		PostalCode:          get("from.postal"),
+		StateOrProvinceCode: strings.ToUpper(get("from.state")),
+		City:                get("from.city"),
+	})
+	b.To(rate.Address{
+		CountryCode:         strings.ToUpper(get("to.country")),
+		PostalCode:          get("to.postal"),
+		StateOrProvinceCode: strings.ToUpper(get("to.state")),
+		City:                get("to.city"),
+		Residential:         flag("to.residential"),
+	})
+	if v := get("service"); v != "" {
+		b.Service(rate.ServiceType(strings.ToUpper(v)))
+	}
+	packaging := get("packaging")
+	if packaging == "" {
+		packaging = string(rate.YourPackaging)
+	}
+	b.Packaging(rate.PackagingType(strings.ToUpper(packaging)))
+	if v := get("pickup"); v != "" {
+		b.Pickup(rate.PickupType(strings.ToUpper(v)))
+	}
+	if v := get("rateTypes"); v != "" {
+		for _, t := range strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == '|' || r == ' ' }) {
+			b.RateTypes(rate.RateRequestType(strings.ToUpper(t)))
+		}
+	}
+	if v := get("shipDate"); v != "" {
+		b.ShipDate(v)
+	}
+	currency := strings.ToUpper(get("currency"))
+	if currency != "" {
+		b.PreferredCurrency(currency)
+	}
+
+	units := strings.ToUpper(get("weight.units"))
+	if units == "" {
+		units = "LB"
+	}
+	p := rate.NewPackage(number("weight"), units)
+	if length, width, height := integer("length"), integer("width"), integer("height"); length+width+height > 0 {
+		dimUnits := strings.ToUpper(get("dimensions.units"))
+		if dimUnits == "" {
+			dimUnits = "IN"
+		}
+		p = p.WithDimensions(length, width, height, dimUnits)
+	}
+	if value := number("declaredValue"); value > 0 {
+		p = p.WithDeclaredValue(value, currency)
+	}
+	if n := integer("packages"); n > 1 {
+		p = p.WithCount(n)
+	}
+	b.AddPackage(p)
+
+	if len(errs) > 0 {
+		return id, rate.RateRequest{}, errors.New(strings.Join(errs, "; "))
+	}
+	request, err := b.Build()
+	return id, request, err
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/tirpitz0509/go-fedex/rate"
+)
+
+// The gateway's JSON API is deliberately smaller than FedEx's and changes
+// only by adding fields, so callers need not follow FedEx releases.
+
+type Address struct {
+	Country     string `json:"country"`               // ISO 3166 alpha-2
+	Postal      string `json:"postal,omitempty"`      //
+	State       string `json:"state,omitempty"`       //
+	City        string `json:"city,omitempty"`        //
+	Residential bool   `json:"residential,omitempty"` //
+}
+
+type Package struct {
+	Weight        float64 `json:"weight"`                  //
+	WeightUnits   string  `json:"weightUnits,omitempty"`   // LB if empty
+	Length        int     `json:"length,omitempty"`        //
+	Width         int     `json:"width,omitempty"`         //
+	Height        int     `json:"height,omitempty"`        //
+	DimUnits      string  `json:"dimUnits,omitempty"`      // IN if empty
+	Count         int     `json:"count,omitempty"`         // identical packages, 1 if 0
+	DeclaredValue float64 `json:"declaredValue,omitempty"` // in the request's currency
+}
+
+type RateRequest struct {
+	From      Address   `json:"from"`                //
+	To        Address   `json:"to"`                  //
+	Packages  []Package `json:"packages"`            //
+	Service   string    `json:"service,omitempty"`   // all services if empty
+	Packaging string    `json:"packaging,omitempty"` // YOUR_PACKAGING if empty
+	Pickup    string    `json:"pickup,omitempty"`    //
+	RateTypes []string  `json:"rateTypes,omitempty"` // e.g. LIST, ACCOUNT
+	ShipDate  string    `json:"shipDate,omitempty"`  // YYYY-MM-DD
+	Currency  string    `json:"currency,omitempty"`  //
+}
+
+type RateResponse struct {
+	Quotes                []rate.Quote `json:"quotes"`                          //
+	TransactionID         string       `json:"transactionId,omitempty"`         // FedEx's
+	CustomerTransactionID string       `json:"customerTransactionId,omitempty"` //
+	Cached                bool         `json:"cached"`                          //
+}
+
+// toRateRequest builds and validates the FedEx request.
+func (r RateRequest) toRateRequest(accountNumber string) (rate.RateRequest, error) {
+	b := rate.NewShipment(accountNumber).
+		From(r.From.address()).
+		To(r.To.address())
+	if r.Service != "" {
+		b.Service(rate.ServiceType(strings.ToUpper(r.Service)))
+	}
+	packaging := r.Packaging
+	if packaging == "" {
+		packaging = string(rate.YourPackaging)
+	}
+	b.Packaging(rate.PackagingType(strings.ToUpper(packaging)))
+	if r.Pickup != "" {
+		b.Pickup(rate.PickupType(strings.ToUpper(r.Pickup)))
+	}
+	for _, t := range r.RateTypes {
+		b.RateTypes(rate.RateRequestType(strings.ToUpper(t)))
+	}
+	if r.ShipDate != "" {
+		b.ShipDate(r.ShipDate)
+	}
+	currency := strings.ToUpper(r.Currency)
+	if currency != "" {
+		b.PreferredCurrency(currency)
+	}
+	for _, p := range r.Packages {
+		units := strings.ToUpper(p.WeightUnits)
+		if units == "" {
+			units = "LB"
+		}
+		pkg := rate.NewPackage(p.Weight, units)
+		if p.Length+p.Width+p.Height > 0 {
+			dimUnits := strings.ToUpper(p.DimUnits)
+			if dimUnits == "" {
+				dimUnits = "IN"
+			}
+			pkg = pkg.WithDimensions(p.Length, p.Width, p.Height, dimUnits)
+		}
+		if p.DeclaredValue > 0 {
+			pkg = pkg.WithDeclaredValue(p.DeclaredValue, currency)
+		}
+		if p.Count > 1 {
+			pkg = pkg.WithCount(p.Count)
+		}
+		b.AddPackage(pkg)
+	}
+	return b.Build()
+}
+
+func (a Address) address() rate.Address {
+	return rate.Address{
+		CountryCode:         strings.ToUpper(a.Country),
+		PostalCode:          a.Postal,
+		StateOrProvinceCode: strings.ToUpper(a.State),
+		City:                a.City,
+		Residential:         a.Residential,
+	}
+}
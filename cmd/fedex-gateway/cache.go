@@ -0,0 +1,68 @@
+package main
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// quoteCache keeps successful responses for ttl, dropping the least
+// recently used beyond size entries.
+type quoteCache struct {
+	ttl  time.Duration
+	size int
+
+	mu      sync.Mutex
+	order   *list.List // front is most recently used
+	entries map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key      string
+	response RateResponse
+	expires  time.Time
+}
+
+func newQuoteCache(ttl time.Duration, size int) *quoteCache {
+	return &quoteCache{ttl: ttl, size: size, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+func (c *quoteCache) get(key string) (RateResponse, bool) {
+	if c.ttl <= 0 {
+		return RateResponse{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return RateResponse{}, false
+	}
+	entry := e.Value.(*cacheEntry)
+	if time.Now().After(entry.expires) {
+		c.order.Remove(e)
+		delete(c.entries, key)
+		return RateResponse{}, false
+	}
+	c.order.MoveToFront(e)
+	return entry.response, true
+}
+
+func (c *quoteCache) put(key string, response RateResponse) {
+	if c.ttl <= 0 || c.size <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := &cacheEntry{key: key, response: response, expires: time.Now().Add(c.ttl)}
+	if e, ok := c.entries[key]; ok {
+		e.Value = entry
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/tirpitz0509/go-fedex/common"
+	"github.com/tirpitz0509/go-fedex/rate"
+	"github.com/tirpitz0509/go-fedex/tenant"
+)
+
+// Error codes of the gateway API. Callers branch on these, never on
+// FedEx's codes, which are passed along in fedexCode for support only.
+const (
+	CodeInvalidRequest      = "invalid_request"      // malformed JSON or a shipment that fails validation
+	CodeInvalidShipment     = "invalid_shipment"     // FedEx rejected the shipment
+	CodeNoService           = "no_service"           // FedEx does not serve the lane or service
+	CodeRateLimited         = "rate_limited"         // retry later
+	CodeUpstreamAuth        = "upstream_auth"        // the gateway's FedEx credentials were refused
+	CodeUpstreamUnavailable = "upstream_unavailable" // FedEx is down or timed out; retry later
+	CodeUpstreamError       = "upstream_error"       // any other FedEx failure
+	CodeNotFound            = "not_found"            //
+	CodeMethodNotAllowed    = "method_not_allowed"   //
+)
+
+type ErrorBody struct {
+	Error APIError `json:"error"`
+}
+
+type APIError struct {
+	Status                int                   `json:"-"`                               //
+	Code                  string                `json:"code"`                            //
+	Message               string                `json:"message"`                         //
+	FedexCode             string                `json:"fedexCode,omitempty"`             //
+	TransactionID         string                `json:"transactionId,omitempty"`         //
+	CustomerTransactionID string                `json:"customerTransactionId,omitempty"` //
+	Details               rate.ValidationErrors `json:"details,omitempty"`               //
+}
+
+// fedexCodes maps FedEx error codes to the gateway's; codes not listed are
+// classified by classify.
+var fedexCodes = map[string]APIError{
+	"NOT.AUTHORIZED.ERROR":         {Status: http.StatusBadGateway, Code: CodeUpstreamAuth},
+	"LOGIN.REAUTHENTICATE.ERROR":   {Status: http.StatusBadGateway, Code: CodeUpstreamAuth},
+	"FORBIDDEN.ERROR":              {Status: http.StatusBadGateway, Code: CodeUpstreamAuth},
+	"ACCOUNT.NUMBER.MISMATCH":      {Status: http.StatusBadGateway, Code: CodeUpstreamAuth},
+	"TOO.MANY.REQUESTS.ERROR":      {Status: http.StatusTooManyRequests, Code: CodeRateLimited},
+	"SERVICE.UNAVAILABLE.ERROR":    {Status: http.StatusServiceUnavailable, Code: CodeUpstreamUnavailable},
+	"SYSTEM.UNAVAILABLE.EXCEPTION": {Status: http.StatusServiceUnavailable, Code: CodeUpstreamUnavailable},
+	"INTERNAL.SERVER.ERROR":        {Status: http.StatusServiceUnavailable, Code: CodeUpstreamUnavailable},
+	"RATE.LOCATION.NOSERVICE":      {Status: http.StatusUnprocessableEntity, Code: CodeNoService},
+	"SERVICE.UNAVAILABLE.LOCATION": {Status: http.StatusUnprocessableEntity, Code: CodeNoService},
+	"SERVICETYPE.NOT.ALLOWED":      {Status: http.StatusUnprocessableEntity, Code: CodeNoService},
+}
+
+// classify turns a failed rate call into the error returned to the caller.
+func classify(response rate.RateResponse, err error) APIError {
+	e := APIError{Status: http.StatusBadGateway, Code: CodeUpstreamError, Message: err.Error()}
+	var token *tenant.TokenError
+	if errors.As(err, &token) {
+		e.Code = CodeUpstreamAuth
+		return e
+	}
+	var transaction *common.TransactionError
+	if errors.As(err, &transaction) {
+		e.Message = transaction.Err.Error()
+		e.TransactionID = transaction.TransactionID
+		e.CustomerTransactionID = transaction.CustomerTransactionID
+	}
+
+	if len(response.Errors) > 0 {
+		fedex := response.Errors[0]
+		e.FedexCode = fedex.Code
+		if fedex.Message != "" {
+			e.Message = fedex.Message
+		}
+		if known, ok := fedexCodes[fedex.Code]; ok {
+			e.Status, e.Code = known.Status, known.Code
+		} else if strings.Contains(fedex.Code, "INVALID") || strings.Contains(fedex.Code, "MISSING") ||
+			strings.HasSuffix(fedex.Code, ".REQUIRED") {
+			e.Status, e.Code = http.StatusUnprocessableEntity, CodeInvalidShipment
+		}
+		return e
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) && netErr.Timeout() {
+		e.Status, e.Code = http.StatusGatewayTimeout, CodeUpstreamUnavailable
+	} else if transaction != nil && transaction.StatusCode >= 500 {
+		// a 5xx status without a FedEx error body
+		e.Status, e.Code = http.StatusServiceUnavailable, CodeUpstreamUnavailable
+	}
+	return e
+}
+
+func writeError(w http.ResponseWriter, e APIError) {
+	writeJSON(w, e.Status, ErrorBody{Error: e})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,73 @@
+// Command fedex-gateway serves FedEx quotes over a small JSON API for
+// services that do not use the Go library. It keeps one FedEx token, caches
+// quotes and limits the request rate for all of its callers.
+//
+//	POST /v1/rates   shipment in, quotes out; see RateRequest
+//	GET  /healthz    the process is up
+//	GET  /readyz     FedEx accepts the gateway's credentials
+//
+// Errors have the body {"error": {"code": ..., "message": ...}} with the
+// codes listed in errors.go. Settings come from package config.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/tirpitz0509/go-fedex/config"
+	"github.com/tirpitz0509/go-fedex/tenant"
+)
+
+func main() {
+	listen := flag.String("listen", ":8080", "address to serve on")
+	configFile := flag.String("config", "", "YAML or JSON config file (default $FEDEX_CONFIG)")
+	rateLimit := flag.Float64("rate-limit", 10, "FedEx requests per second, unlimited if 0")
+	maxPending := flag.Int("max-pending", 64, "requests waiting on FedEx before answering 429")
+	timeout := flag.Duration("timeout", 30*time.Second, "time allowed for each FedEx call")
+	cacheTTL := flag.Duration("cache-ttl", 10*time.Minute, "how long quotes are reused, 0 to disable")
+	cacheSize := flag.Int("cache-size", 10000, "quotes kept in the cache")
+	flag.Parse()
+
+	var opts []config.Option
+	if *configFile != "" {
+		opts = append(opts, config.WithFile(*configFile))
+	}
+	c, err := config.Load(opts...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := c.Validate(config.REST); err != nil {
+		log.Fatal(err)
+	}
+	registry := tenant.NewRegistry(nil)
+	registry.RateLimit = *rateLimit
+	registry.Client = c.Client
+
+	s := &server{
+		client:  registry.Register("gateway", c.Credentials()),
+		cache:   newQuoteCache(*cacheTTL, *cacheSize),
+		pending: make(chan struct{}, *maxPending),
+		timeout: *timeout,
+	}
+	httpServer := &http.Server{Addr: *listen, Handler: s.routes(), ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		stop := make(chan os.Signal, 1)
+		signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+		<-stop
+		ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+		defer cancel()
+		httpServer.Shutdown(ctx)
+	}()
+
+	log.Println("fedex-gateway listening on", *listen)
+	if err := httpServer.ListenAndServe(); err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
+}
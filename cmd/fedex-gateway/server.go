@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/tirpitz0509/go-fedex/common"
+	"github.com/tirpitz0509/go-fedex/rate"
+	"github.com/tirpitz0509/go-fedex/tenant"
+)
+
+const maxRequestBody = 1 << 20
+
+type server struct {
+	client  *tenant.Tenant
+	cache   *quoteCache
+	pending chan struct{} // one slot per request waiting on FedEx
+	timeout time.Duration
+}
+
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/rates", s.rates)
+	mux.HandleFunc("/healthz", s.healthz)
+	mux.HandleFunc("/readyz", s.readyz)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, APIError{Status: http.StatusNotFound, Code: CodeNotFound, Message: "no such endpoint " + r.URL.Path})
+	})
+	return mux
+}
+
+func (s *server) rates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, APIError{Status: http.StatusMethodNotAllowed, Code: CodeMethodNotAllowed, Message: "use POST"})
+		return
+	}
+	var body RateRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBody)).Decode(&body); err != nil {
+		writeError(w, APIError{Status: http.StatusBadRequest, Code: CodeInvalidRequest, Message: "invalid JSON: " + err.Error()})
+		return
+	}
+	request, err := body.toRateRequest(s.client.Credentials.AccountNumber)
+	if err != nil {
+		e := APIError{Status: http.StatusBadRequest, Code: CodeInvalidRequest, Message: err.Error()}
+		errors.As(err, &e.Details)
+		writeError(w, e)
+		return
+	}
+
+	key, _ := json.Marshal(request)
+	if cached, ok := s.cache.get(string(key)); ok {
+		cached.Cached = true
+		cached.TransactionID, cached.CustomerTransactionID = "", ""
+		writeJSON(w, http.StatusOK, cached)
+		return
+	}
+
+	select {
+	case s.pending <- struct{}{}:
+		defer func() { <-s.pending }()
+	default:
+		writeError(w, APIError{Status: http.StatusTooManyRequests, Code: CodeRateLimited, Message: "too many requests waiting on FedEx"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.timeout)
+	defer cancel()
+	if id := r.Header.Get(common.HeaderCustomerTransactionID); id != "" {
+		ctx = common.WithTransactionID(ctx, id)
+	}
+
+	response, e := s.rate(ctx, request)
+	if e != nil {
+		log.Println(e.Code, e.FedexCode, e.Message, e.CustomerTransactionID)
+		writeError(w, *e)
+		return
+	}
+	result := RateResponse{
+		Quotes:                response.Quotes(),
+		TransactionID:         response.TransactionID,
+		CustomerTransactionID: response.CustomerTransactionID,
+	}
+	if result.Quotes == nil {
+		result.Quotes = []rate.Quote{}
+	} else {
+		s.cache.put(string(key), result)
+	}
+	w.Header().Set(common.HeaderCustomerTransactionID, result.CustomerTransactionID)
+	writeJSON(w, http.StatusOK, result)
+}
+
+// rate calls FedEx; the tenant retries once with a new token if FedEx
+// refused the cached one.
+func (s *server) rate(ctx context.Context, request rate.RateRequest) (rate.RateResponse, *APIError) {
+	response, err := s.client.RateContext(ctx, request)
+	if err != nil {
+		e := classify(response, err)
+		return response, &e
+	}
+	return response, nil
+}
+
+func (s *server) healthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// readyz reports whether the gateway holds, or can get, a FedEx token.
+func (s *server) readyz(w http.ResponseWriter, r *http.Request) {
+	if _, _, err := s.client.TokenSource()(); err != nil {
+		writeError(w, APIError{Status: http.StatusServiceUnavailable, Code: CodeUpstreamAuth, Message: err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ready"})
+}
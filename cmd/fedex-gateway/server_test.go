@@ -0,0 +1,246 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tirpitz0509/go-fedex/common"
+	"github.com/tirpitz0509/go-fedex/fedextest"
+	"github.com/tirpitz0509/go-fedex/rate"
+	"github.com/tirpitz0509/go-fedex/tenant"
+)
+
+const groundBody = `{"from":{"country":"US","postal":"38017"},"to":{"country":"US","postal":"90210"},"service":"fedex_ground","packages":[{"weight":5}]}`
+
+// newServer returns a gateway on the fake FedEx f, caching quotes for a
+// minute and letting one request wait on FedEx.
+func newServer(f *fedextest.Server) *server {
+	registry := tenant.NewRegistry(nil)
+	registry.Client = f.Client()
+	return &server{
+		client:  registry.Register("gateway", tenant.Credentials{ClientId: "id", ClientSecret: "secret", AccountNumber: "123456789", BaseURL: f.URL}),
+		cache:   newQuoteCache(time.Minute, 10),
+		pending: make(chan struct{}, 1),
+		timeout: 5 * time.Second,
+	}
+}
+
+func post(s *server, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.routes().ServeHTTP(w, httptest.NewRequest("POST", "/v1/rates", strings.NewReader(body)))
+	return w
+}
+
+func decode(t *testing.T, w *httptest.ResponseRecorder, v interface{}) {
+	if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+		t.Fatalf("%v: %s", err, w.Body)
+	}
+}
+
+// rateRequests counts the rate calls f received.
+func rateRequests(f *fedextest.Server) int {
+	n := 0
+	for _, r := range f.Requests() {
+		if r.Path == fedextest.RatePath {
+			n++
+		}
+	}
+	return n
+}
+
+func TestRates(t *testing.T) {
+	f := fedextest.NewServer()
+	defer f.Close()
+	f.ServiceQuotes(rate.FedexGround, rate.Quote{NetCharge: 9.75, Currency: "USD"})
+	s := newServer(f)
+
+	w := post(s, groundBody)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var first RateResponse
+	decode(t, w, &first)
+	if len(first.Quotes) != 1 || first.Quotes[0].NetCharge != 9.75 || first.Quotes[0].ServiceType != rate.FedexGround {
+		t.Errorf("quotes = %+v, want one FEDEX_GROUND quote of 9.75", first.Quotes)
+	}
+	if first.Cached || first.TransactionID == "" || first.CustomerTransactionID == "" {
+		t.Errorf("response = %+v, want an uncached one with transaction IDs", first)
+	}
+	if got := w.Header().Get(common.HeaderCustomerTransactionID); got != first.CustomerTransactionID {
+		t.Errorf("header transaction ID = %q, want %q", got, first.CustomerTransactionID)
+	}
+
+	var second RateResponse
+	decode(t, post(s, groundBody), &second)
+	if !second.Cached || second.TransactionID != "" || second.CustomerTransactionID != "" {
+		t.Errorf("cached response = %+v, want Cached without transaction IDs", second)
+	}
+	if len(second.Quotes) != 1 || second.Quotes[0].NetCharge != 9.75 {
+		t.Errorf("cached quotes = %+v", second.Quotes)
+	}
+	if n := rateRequests(f); n != 1 {
+		t.Errorf("FedEx got %d rate requests, want 1", n)
+	}
+}
+
+func TestRatesValidation(t *testing.T) {
+	f := fedextest.NewServer()
+	defer f.Close()
+	s := newServer(f)
+
+	w := post(s, `{"from":{"country":"US","postal":"38017"},"to":{"postal":"90210"},"packages":[{"weight":0}]}`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+	var body ErrorBody
+	decode(t, w, &body)
+	if body.Error.Code != CodeInvalidRequest {
+		t.Errorf("code = %q, want %q", body.Error.Code, CodeInvalidRequest)
+	}
+	paths := map[string]bool{}
+	for _, d := range body.Error.Details {
+		paths[d.Path] = true
+	}
+	for _, want := range []string{
+		"requestedShipment.recipient.address.countryCode",
+		"requestedShipment.requestedPackageLineItems[0].weight.value",
+	} {
+		if !paths[want] {
+			t.Errorf("details = %+v, want one for %s", body.Error.Details, want)
+		}
+	}
+	if n := rateRequests(f); n != 0 {
+		t.Errorf("FedEx got %d rate requests for an invalid shipment", n)
+	}
+
+	if w := post(s, "{"); w.Code != http.StatusBadRequest {
+		t.Errorf("malformed JSON: status = %d, want 400", w.Code)
+	}
+}
+
+func TestRatesFedexErrors(t *testing.T) {
+	tests := []struct {
+		fedexCode string
+		status    int
+		code      string
+	}{
+		{"TOO.MANY.REQUESTS.ERROR", http.StatusTooManyRequests, CodeRateLimited},
+		{"SERVICE.UNAVAILABLE.ERROR", http.StatusServiceUnavailable, CodeUpstreamUnavailable},
+		{"FORBIDDEN.ERROR", http.StatusBadGateway, CodeUpstreamAuth},
+		{"RATE.LOCATION.NOSERVICE", http.StatusUnprocessableEntity, CodeNoService},
+		{"SHIPPER.POSTALSTATE.INVALID", http.StatusUnprocessableEntity, CodeInvalidShipment},
+		{"RECIPIENT.COUNTRY.REQUIRED", http.StatusUnprocessableEntity, CodeInvalidShipment},
+		{"SOMETHING.UNEXPECTED", http.StatusBadGateway, CodeUpstreamError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fedexCode, func(t *testing.T) {
+			f := fedextest.NewServer()
+			defer f.Close()
+			s := newServer(f)
+			f.Fail(http.StatusBadRequest, tt.fedexCode, "failed")
+
+			w := post(s, groundBody)
+			var body ErrorBody
+			decode(t, w, &body)
+			if w.Code != tt.status || body.Error.Code != tt.code {
+				t.Errorf("got %d %s, want %d %s", w.Code, body.Error.Code, tt.status, tt.code)
+			}
+			if body.Error.FedexCode != tt.fedexCode || body.Error.Message != "failed" || body.Error.CustomerTransactionID == "" {
+				t.Errorf("error = %+v, want FedEx's code, message and the transaction ID", body.Error)
+			}
+		})
+	}
+}
+
+func TestRatesRetriesRefusedToken(t *testing.T) {
+	f := fedextest.NewServer()
+	defer f.Close()
+	s := newServer(f)
+	if w := post(s, groundBody); w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+
+	f.ExpireTokens()
+	w := post(s, `{"from":{"country":"US","postal":"38017"},"to":{"country":"US","postal":"10001"},"packages":[{"weight":5}]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status after the token expired = %d: %s", w.Code, w.Body)
+	}
+	if n := rateRequests(f); n != 3 {
+		t.Errorf("FedEx got %d rate requests, want 3", n)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"5xx without a body", &common.TransactionError{Err: errors.New("502 Bad Gateway"), StatusCode: http.StatusBadGateway},
+			http.StatusServiceUnavailable, CodeUpstreamUnavailable},
+		{"4xx without a body", &common.TransactionError{Err: errors.New("404 Not Found"), StatusCode: http.StatusNotFound},
+			http.StatusBadGateway, CodeUpstreamError},
+		{"no reply", &common.TransactionError{Err: errors.New("connection refused")},
+			http.StatusBadGateway, CodeUpstreamError},
+		{"timeout", &common.TransactionError{Err: context.DeadlineExceeded},
+			http.StatusGatewayTimeout, CodeUpstreamUnavailable},
+		{"token", &tenant.TokenError{Err: errors.New("401 Unauthorized")},
+			http.StatusBadGateway, CodeUpstreamAuth},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := classify(rate.RateResponse{}, tt.err)
+			if e.Status != tt.status || e.Code != tt.code {
+				t.Errorf("classify() = %d %s, want %d %s", e.Status, e.Code, tt.status, tt.code)
+			}
+		})
+	}
+}
+
+func TestRatesTooManyPending(t *testing.T) {
+	f := fedextest.NewServer()
+	defer f.Close()
+	s := newServer(f)
+	s.pending <- struct{}{}
+
+	w := post(s, groundBody)
+	var body ErrorBody
+	decode(t, w, &body)
+	if w.Code != http.StatusTooManyRequests || body.Error.Code != CodeRateLimited {
+		t.Errorf("got %d %s, want 429 %s", w.Code, body.Error.Code, CodeRateLimited)
+	}
+	if n := rateRequests(f); n != 0 {
+		t.Errorf("FedEx got %d rate requests", n)
+	}
+}
+
+func TestReadyz(t *testing.T) {
+	f := fedextest.NewServer()
+	defer f.Close()
+	s := newServer(f)
+
+	get := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		s.routes().ServeHTTP(w, httptest.NewRequest("GET", "/readyz", nil))
+		return w
+	}
+	if w := get(); w.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200", w.Code)
+	}
+
+	s.client.InvalidateToken()
+	f.Unavailable(true)
+	w := get()
+	var body ErrorBody
+	decode(t, w, &body)
+	if w.Code != http.StatusServiceUnavailable || body.Error.Code != CodeUpstreamAuth {
+		t.Errorf("FedEx down: got %d %s, want 503 %s", w.Code, body.Error.Code, CodeUpstreamAuth)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/tirpitz0509/go-fedex/audit"
+)
+
+func auditCommand(args []string) error {
+	fs := flag.NewFlagSet("audit", flag.ExitOnError)
+	invoice := fs.String("invoice", "", "FedEx invoice CSV export")
+	records := fs.String("records", "", "JSONL file of shipments recorded with their quotes")
+	out := fs.String("out", "", "write disputes as CSV here (default stdout)")
+	tolerance := fs.Float64("tolerance", 0.01, "ignore money differences up to this amount")
+	weightTolerance := fs.Float64("weight-tolerance", 0.5, "ignore billed weight differences up to this many LB")
+	verbose := fs.Bool("v", false, "list every discrepancy found, not only disputes")
+	fs.Parse(args)
+
+	if *invoice == "" || *records == "" {
+		return errors.New("audit: -invoice and -records are required")
+	}
+	lines, err := audit.LoadInvoice(*invoice)
+	if err != nil {
+		return err
+	}
+	recorded, err := audit.LoadRecords(*records)
+	if err != nil {
+		return err
+	}
+
+	auditor := audit.Auditor{Tolerance: *tolerance, WeightTolerance: *weightTolerance}
+	report := auditor.Audit(lines, recorded)
+	if *verbose {
+		for _, f := range report.Findings {
+			for _, d := range f.Discrepancies {
+				fmt.Fprintf(os.Stderr, "line %d %s: %s\n", f.Line.Line, f.Line.TrackingNumber, d)
+			}
+		}
+	}
+
+	disputes := report.Disputes()
+	w := os.Stdout
+	if *out != "" {
+		if w, err = os.Create(*out); err != nil {
+			return err
+		}
+		defer w.Close()
+	}
+	if err := audit.WriteDisputes(w, disputes); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "%d lines: %d matched, %d unmatched, %d with discrepancies, %d to dispute\n",
+		report.Lines, report.Matched, report.Unmatched, len(report.Findings)-report.Unmatched, len(disputes))
+	for _, s := range audit.Summaries(disputes) {
+		fmt.Fprintf(os.Stderr, "invoice %s: %d disputes, %s %s\n",
+			s.InvoiceNumber, s.Disputes, strconv.FormatFloat(s.Amount, 'f', 2, 64), s.Currency)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+
+	"github.com/tirpitz0509/go-fedex/bulk"
+)
+
+func bulkCommand(args []string) error {
+	var t target
+	fs := flag.NewFlagSet("bulk", flag.ExitOnError)
+	t.flags(fs)
+	in := fs.String("in", "", "shipments as CSV with a header row, or JSONL")
+	out := fs.String("out", "", "results as CSV or JSONL, by extension")
+	mapping := fs.String("mapping", "", "YAML or JSON file mapping fields to input columns")
+	concurrency := fs.Int("concurrency", 4, "requests in flight")
+	checkpoint := fs.String("checkpoint", "", "finished rows (default <out>.checkpoint)")
+	resume := fs.Bool("resume", false, "append to -out, skipping rows rated before; failed rows are tried again")
+	quiet := fs.Bool("q", false, "do not report progress")
+	fs.Parse(args)
+
+	if *in == "" || *out == "" {
+		return errors.New("bulk: -in and -out are required")
+	}
+	var m bulk.Mapping
+	if *mapping != "" {
+		var err error
+		if m, err = bulk.LoadMapping(*mapping); err != nil {
+			return err
+		}
+	}
+
+	client, err := t.client()
+	if err != nil {
+		return err
+	}
+	defer t.close()
+
+	// the first interrupt lets in-flight rows finish and be checkpointed
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	job := bulk.Job{
+		Input:         *in,
+		Output:        *out,
+		Mapping:       m,
+		Rate:          client.Rate,
+		AccountNumber: client.Credentials.AccountNumber,
+		Concurrency:   *concurrency,
+		Checkpoint:    *checkpoint,
+		Resume:        *resume,
+		Context:       ctx,
+	}
+	if !*quiet {
+		var n int
+		job.Progress = func(result bulk.Result) {
+			n++
+			if result.Error != "" {
+				fmt.Fprintf(os.Stderr, "row %d %s: %s\n", result.Row, strconv.Quote(result.ID), result.Error)
+			} else if n%100 == 0 {
+				fmt.Fprintf(os.Stderr, "%d rows done\n", n)
+			}
+		}
+	}
+
+	summary, err := job.Run()
+	fmt.Fprintf(os.Stderr, "%d rows: %d rated, %d failed, %d skipped as done before\n",
+		summary.Rows, summary.Rated, summary.Failed, summary.Skipped)
+	if err == context.Canceled {
+		return errors.New("interrupted; rerun with -resume to continue")
+	}
+	return err
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/tirpitz0509/go-fedex/common"
+	"github.com/tirpitz0509/go-fedex/fedextest"
+)
+
+// dumpMiddleware prints each exchange with credentials, tokens and account
+// numbers redacted, so the output can go into a support ticket.
+func dumpMiddleware(w io.Writer) common.Middleware {
+	return func(next common.Handler) common.Handler {
+		return func(ex *common.Exchange) error {
+			err := next(ex)
+			if req := ex.HTTPRequest; req != nil {
+				fmt.Fprintf(w, "> %s %s\n", req.Method, req.URL)
+				dumpHeader(w, ">", req.Header)
+				dumpBody(w, ex.RequestBody, req.Header.Get("Content-Type"))
+			}
+			if resp := ex.HTTPResponse; resp != nil {
+				fmt.Fprintf(w, "< %s\n", resp.Status)
+				dumpHeader(w, "<", resp.Header)
+				dumpBody(w, ex.ResponseBody, resp.Header.Get("Content-Type"))
+			}
+			if err != nil {
+				fmt.Fprintf(w, "! %s: %s\n\n", ex.Operation, err)
+			}
+			return err
+		}
+	}
+}
+
+func dumpHeader(w io.Writer, prefix string, header http.Header) {
+	var names []string
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		if strings.EqualFold(name, "Authorization") {
+			value = "REDACTED"
+		}
+		fmt.Fprintf(w, "%s %s: %s\n", prefix, name, value)
+	}
+}
+
+func dumpBody(w io.Writer, body []byte, contentType string) {
+	redacted := fedextest.Redact(string(body), contentType)
+	if strings.Contains(contentType, "json") {
+		var indented bytes.Buffer
+		if json.Indent(&indented, []byte(redacted), "", "  ") == nil {
+			redacted = indented.String()
+		}
+	}
+	fmt.Fprintf(w, "\n%s\n\n", strings.TrimSpace(redacted))
+}
@@ -0,0 +1,160 @@
+// Command fedex obtains tokens and rates shipments against the FedEx
+// sandbox, production or a built-in mock, for reproducing quotes by hand.
+//
+//	fedex token -env sandbox
+//	fedex rate -from US,38017 -to US,90210 -weight 5 -dump
+//	fedex rate -file shipment.yaml -soap -env mock
+//	fedex bulk -in catalog.csv -mapping columns.yaml -out rates.csv -resume
+//	fedex audit -invoice invoice.csv -records shipments.jsonl -out disputes.csv
+//
+// Credentials come from the config file, FEDEX_* variables or flags; see
+// package config.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/tirpitz0509/go-fedex/auth"
+	"github.com/tirpitz0509/go-fedex/common"
+	"github.com/tirpitz0509/go-fedex/config"
+	"github.com/tirpitz0509/go-fedex/fedextest"
+	"github.com/tirpitz0509/go-fedex/tenant"
+)
+
+var commands = map[string]func(args []string) error{
+	"token": tokenCommand,
+	"rate":  rateCommand,
+	"bulk":  bulkCommand,
+	"audit": auditCommand,
+}
+
+func main() {
+	if len(os.Args) < 2 || commands[os.Args[1]] == nil {
+		usage()
+		os.Exit(2)
+	}
+	if err := commands[os.Args[1]](os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "fedex:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `usage: fedex <command> [flags]
+
+commands:
+  token   obtain an access token and show what it grants
+  rate    rate a shipment given by flags or a JSON/YAML file
+  bulk    rate every row of a CSV or JSONL file, resumably
+  audit   check a FedEx invoice against recorded quotes and list disputes
+
+Run "fedex <command> -h" for the flags of a command.`)
+}
+
+// target holds the flags every command shares: where to send requests and
+// with which credentials.
+type target struct {
+	configFile string
+	env        string
+	baseURL    string
+	soapURL    string
+	account    string
+	dump       bool
+	verbose    bool
+
+	mock *fedextest.Server
+}
+
+func (t *target) flags(fs *flag.FlagSet) {
+	fs.StringVar(&t.configFile, "config", "", "YAML or JSON config file (default $FEDEX_CONFIG)")
+	fs.StringVar(&t.env, "env", "", "sandbox, live or mock (default from config)")
+	fs.StringVar(&t.baseURL, "base-url", "", "REST API URL, overriding -env")
+	fs.StringVar(&t.soapURL, "soap-url", "", "Web Services URL, overriding -env")
+	fs.StringVar(&t.account, "account", "", "account number, overriding config")
+	fs.BoolVar(&t.dump, "dump", false, "print every request and reply, redacted, to stderr")
+	fs.BoolVar(&t.verbose, "v", false, "keep the library's log output")
+}
+
+// client builds the client for the flags; call close when done.
+func (t *target) client() (*tenant.Tenant, error) {
+	if !t.verbose {
+		log.SetOutput(ioutil.Discard)
+	}
+	if t.dump {
+		common.Use(dumpMiddleware(os.Stderr))
+	}
+
+	var opts []config.Option
+	if t.configFile != "" {
+		opts = append(opts, config.WithFile(t.configFile))
+	}
+	switch t.env {
+	case "":
+	case "sandbox":
+		opts = append(opts, config.WithTestMode(true))
+	case "live":
+		opts = append(opts, config.WithTestMode(false))
+	case "mock":
+		t.mock = fedextest.NewServer()
+		opts = append(opts,
+			config.WithURLs(t.mock.URL, t.mock.URL+"/web-services"),
+			config.WithHTTPClient(t.mock.Client()),
+		)
+	default:
+		return nil, errors.New("unknown -env " + t.env + ", want sandbox, live or mock")
+	}
+	if t.baseURL != "" || t.soapURL != "" {
+		opts = append(opts, config.WithURLs(t.baseURL, t.soapURL))
+	}
+	if t.account != "" {
+		opts = append(opts, config.WithAccountNumber(t.account))
+	}
+
+	c, err := config.Load(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if t.mock != nil {
+		mockCredentials(&c)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	registry := tenant.NewRegistry(nil)
+	registry.Client = c.Client
+	return registry.Register("cli", c.Credentials()), nil
+}
+
+func (t *target) close() {
+	if t.mock != nil {
+		t.mock.Close()
+	}
+}
+
+// mockCredentials fills whatever the mock needs but the user did not set.
+func mockCredentials(c *config.Config) {
+	defaults := []struct {
+		value *string
+		mock  string
+	}{
+		{&c.ClientId, "mock-client"},
+		{&c.ClientSecret, "mock-secret"},
+		{&c.AccountNumber, "000000000"},
+		{&c.Key, "mock-key"},
+		{&c.Password, "mock-password"},
+		{&c.MeterNumber, "000000"},
+	}
+	for _, d := range defaults {
+		if *d.value == "" {
+			*d.value = d.mock
+		}
+	}
+	if c.GrantType != "" && c.GrantType != auth.GrantClientCredentials && c.ChildKey == "" {
+		c.ChildKey, c.ChildSecret = "mock-child", "mock-child-secret"
+	}
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/tirpitz0509/go-fedex/currency"
+	"github.com/tirpitz0509/go-fedex/rate"
+	"gopkg.in/yaml.v3"
+)
+
+func rateCommand(args []string) error {
+	var t target
+	fs := flag.NewFlagSet("rate", flag.ExitOnError)
+	t.flags(fs)
+	file := fs.String("file", "", "REST rate request as JSON or YAML; the flags below amend it")
+	from := fs.String("from", "", "origin as COUNTRY,POSTAL[,STATE[,CITY]]")
+	to := fs.String("to", "", "destination as COUNTRY,POSTAL[,STATE[,CITY]]")
+	residential := fs.Bool("residential", false, "the destination is residential")
+	service := fs.String("service", "", "service type, e.g. FEDEX_GROUND (default all)")
+	packaging := fs.String("packaging", "", "packaging type (default YOUR_PACKAGING)")
+	weight := fs.Float64("weight", 0, "weight of each package")
+	units := fs.String("units", "LB", "weight units, LB or KG")
+	dims := fs.String("dims", "", "dimensions of each package as LxWxH")
+	dimUnits := fs.String("dim-units", "IN", "dimension units, IN or CM")
+	count := fs.Int("packages", 1, "number of identical packages")
+	rateTypes := fs.String("rate-types", "", "comma-separated rate request types, e.g. LIST,ACCOUNT")
+	shipDate := fs.String("ship-date", "", "ship date as YYYY-MM-DD")
+	currencyCode := fs.String("currency", "", "preferred currency; quotes in others are converted into it")
+	ratesFile := fs.String("rates", "", "exchange rates as CSV, YAML or JSON, for converting what FedEx did not")
+	soap := fs.Bool("soap", false, "use the SOAP Web Services API")
+	asJSON := fs.Bool("json", false, "print the quotes as JSON instead of a table")
+	fs.Parse(args)
+
+	client, err := t.client()
+	if err != nil {
+		return err
+	}
+	defer t.close()
+
+	var b *rate.ShipmentBuilder
+	if *file != "" {
+		request, err := readRequest(*file)
+		if err != nil {
+			return err
+		}
+		if request.AccountNumber.Value == "" {
+			request.AccountNumber.Value = client.Credentials.AccountNumber
+		}
+		b = rate.NewShipmentFrom(request)
+	} else {
+		b = rate.NewShipment(client.Credentials.AccountNumber)
+		if *packaging == "" {
+			*packaging = string(rate.YourPackaging)
+		}
+	}
+
+	if *from != "" {
+		address, err := parseAddress(*from)
+		if err != nil {
+			return err
+		}
+		b.From(address)
+	}
+	if *to != "" {
+		address, err := parseAddress(*to)
+		if err != nil {
+			return err
+		}
+		address.Residential = *residential
+		b.To(address)
+	}
+	if *service != "" {
+		b.Service(rate.ServiceType(strings.ToUpper(*service)))
+	}
+	if *packaging != "" {
+		b.Packaging(rate.PackagingType(strings.ToUpper(*packaging)))
+	}
+	if *weight > 0 {
+		p := rate.NewPackage(*weight, strings.ToUpper(*units))
+		if *dims != "" {
+			var l, w, h int
+			if _, err := fmt.Sscanf(strings.ToLower(*dims), "%dx%dx%d", &l, &w, &h); err != nil {
+				return errors.New("-dims: want LxWxH, e.g. 12x8x6")
+			}
+			p = p.WithDimensions(l, w, h, strings.ToUpper(*dimUnits))
+		}
+		if *count > 1 {
+			p = p.WithCount(*count)
+		}
+		b.AddPackage(p)
+	}
+	if *rateTypes != "" {
+		for _, rt := range strings.Split(*rateTypes, ",") {
+			b.RateTypes(rate.RateRequestType(strings.ToUpper(strings.TrimSpace(rt))))
+		}
+	}
+	if *shipDate != "" {
+		b.ShipDate(*shipDate)
+	}
+	if *currencyCode != "" {
+		b.PreferredCurrency(strings.ToUpper(*currencyCode))
+	}
+
+	var quotes []rate.Quote
+	if *soap {
+		c := client.Credentials
+		x, err := b.BuildXML(c.Key, c.Password, c.MeterNumber)
+		if err != nil {
+			return err
+		}
+		response, err := client.RateXML(x)
+		if err != nil {
+			return err
+		}
+		for _, n := range response.Body.RateReply.Notifications {
+			fmt.Fprintf(os.Stderr, "%s %s: %s\n", n.Severity, n.Code, n.Message)
+		}
+		if f := response.Body.Fault; f.Faultcode != "" {
+			return errors.New(f.Faultcode + ": " + f.Faultstring.Text)
+		}
+		quotes = response.Quotes()
+	} else {
+		request, err := b.Build()
+		if err != nil {
+			return err
+		}
+		response, err := client.Rate(request)
+		for _, e := range response.Errors {
+			fmt.Fprintf(os.Stderr, "ERROR %s: %s\n", e.Code, e.Message)
+		}
+		if err != nil {
+			return err
+		}
+		for _, a := range response.Output.Alerts {
+			fmt.Fprintf(os.Stderr, "%s %s: %s\n", a.AlertType, a.Code, a.Message)
+		}
+		quotes = response.Quotes()
+	}
+
+	if *currencyCode != "" {
+		providers := currency.Chain{currency.FromQuotes(quotes)}
+		if *ratesFile != "" {
+			table, err := currency.LoadTable(*ratesFile)
+			if err != nil {
+				return err
+			}
+			providers = append(providers, table)
+		}
+		converted, err := currency.Converter{Provider: providers}.Quotes(quotes, *currencyCode)
+		if err != nil {
+			return err
+		}
+		quotes = converted
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(quotes)
+	}
+	printQuotes(quotes)
+	return nil
+}
+
+// readRequest reads a RateRequest from JSON, or from YAML using the same
+// field names.
+func readRequest(path string) (rate.RateRequest, error) {
+	var request rate.RateRequest
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return request, err
+	}
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".yaml" || ext == ".yml" {
+		var v interface{}
+		if err := yaml.Unmarshal(content, &v); err != nil {
+			return request, errors.New(path + ": " + err.Error())
+		}
+		if content, err = json.Marshal(v); err != nil {
+			return request, errors.New(path + ": " + err.Error())
+		}
+	}
+	if err := json.Unmarshal(content, &request); err != nil {
+		return request, errors.New(path + ": " + err.Error())
+	}
+	return request, nil
+}
+
+func parseAddress(s string) (rate.Address, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) < 2 || len(parts) > 4 {
+		return rate.Address{}, errors.New("address " + strconv.Quote(s) + ": want COUNTRY,POSTAL[,STATE[,CITY]]")
+	}
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	address := rate.Address{CountryCode: strings.ToUpper(parts[0]), PostalCode: parts[1]}
+	if len(parts) > 2 {
+		address.StateOrProvinceCode = strings.ToUpper(parts[2])
+	}
+	if len(parts) > 3 {
+		address.City = parts[3]
+	}
+	return address, nil
+}
+
+func printQuotes(quotes []rate.Quote) {
+	if len(quotes) == 0 {
+		fmt.Println("no rates returned")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "SERVICE\tRATE\tBASE\tSURCHARGES\tDISCOUNTS\tNET\tCURRENCY\tTRANSIT\t")
+	for _, q := range quotes {
+		transit := q.TransitTime
+		if q.TransitDays > 0 {
+			transit = strconv.Itoa(q.TransitDays) + "d"
+		}
+		if q.CommitDate != "" {
+			transit += " " + q.CommitDate
+		}
+		fmt.Fprintf(w, "%s\t%s\t%.2f\t%.2f\t%.2f\t%.2f\t%s\t%s\t\n",
+			q.ServiceType, q.RateType, q.BaseCharge, q.TotalSurcharges, q.Discounts, q.NetCharge, q.Currency, transit)
+	}
+	w.Flush()
+
+	for _, q := range quotes {
+		for _, s := range q.Surcharges {
+			fmt.Printf("  %s %s: %s %.2f %s\n", q.ServiceType, q.RateType, s.Type, s.Amount, q.Currency)
+		}
+	}
+}
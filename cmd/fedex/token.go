@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+func tokenCommand(args []string) error {
+	var t target
+	fs := flag.NewFlagSet("token", flag.ExitOnError)
+	t.flags(fs)
+	show := fs.Bool("show", false, "print the token itself")
+	fs.Parse(args)
+
+	client, err := t.client()
+	if err != nil {
+		return err
+	}
+	defer t.close()
+
+	response, err := client.Auth().Authorization()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("url:       ", response.Url)
+	fmt.Println("type:      ", response.TokenType)
+	fmt.Println("scope:     ", response.Scope)
+	fmt.Println("expires:   ", time.Now().Add(time.Duration(response.ExpiresIn)*time.Second).Format(time.RFC3339))
+	if claims := jwtClaims(response.AccessToken); claims != "" {
+		fmt.Println("claims:    ", claims)
+	}
+	if *show {
+		fmt.Println("token:     ", response.AccessToken)
+	} else {
+		fmt.Fprintln(os.Stderr, "(token hidden, use -show to print it)")
+	}
+	return nil
+}
+
+// jwtClaims returns the unverified claims of a JWT access token, or "" if
+// the token is not one.
+func jwtClaims(token string) string {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+	var claims map[string]interface{}
+	if json.Unmarshal(payload, &claims) != nil {
+		return ""
+	}
+	content, _ := json.Marshal(claims)
+	return string(content)
+}
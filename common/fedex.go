@@ -1,6 +1,7 @@
 package common
 
 import (
+	"context"
 	"io/ioutil"
 	"log"
 	"net/http"
@@ -16,15 +17,55 @@ const (
 	ENC                = "http://schemas.xmlsoap.org/soap/encoding/"
 )
 
+// Fedex holds what every call to FedEx shares: the environment, an optional
+// base URL overriding it (for mocks and proxies), the HTTP client and the
+// caller's context and instrumentation.
 type Fedex struct {
-	TestMode bool //
+	TestMode        bool            //
+	BaseURL         string          //
+	Client          *http.Client    //
+	Context         context.Context // parent of the call's span, and cancels it
+	Instrumentation Instrumentation // the global one from Instrument if nil
+	Middleware      []Middleware    // runs inside the global middleware from Use
+
+	CustomerTransactionID string // see TransactionID
+
+	// RESTTokens, when set, sends SOAP rate requests to the REST API with
+	// the token and API URL it returns; see rate.EnableRESTCompatibility.
+	RESTTokens func() (token string, apiUrl string, err error)
+}
+
+func (c Fedex) HTTPClient() *http.Client {
+	if c.Client != nil {
+		return c.Client
+	}
+	return http.DefaultClient
+}
+
+func (c Fedex) Do(req *http.Request) (*http.Response, error) {
+	if c.Context != nil {
+		req = req.WithContext(c.Context)
+	}
+	return c.HTTPClient().Do(req)
+}
+
+func (c Fedex) StartCall(operation string) *Call {
+	return StartCall(c.Context, c.Instrumentation, operation)
 }
 
 func (c Fedex) PostRequest(xml string, path string) (content []byte, err error, statuCode int) {
+	return c.PostExchange(nil, xml, path)
+}
+
+// PostExchange is PostRequest recording the HTTP request and reply on ex.
+func (c Fedex) PostExchange(ex *Exchange, xml string, path string) (content []byte, err error, statuCode int) {
 	var url string
-	if c.TestMode {
+	switch {
+	case c.BaseURL != "":
+		url = c.BaseURL + path
+	case c.TestMode:
 		url = FEDEX_API_TEST_URL + path
-	} else {
+	default:
 		url = FEDEX_API_URL + path
 	}
 	xml = `<?xml version="1.0" encoding="UTF-8"?>` + xml
@@ -33,12 +74,20 @@ func (c Fedex) PostRequest(xml string, path string) (content []byte, err error,
 		log.Println(url)
 	}
 
-	resp, err := http.Post(url, "text/xml", strings.NewReader(xml))
+	req, err := http.NewRequest("POST", url, strings.NewReader(xml))
+	if err != nil {
+		return content, err, 0
+	}
+	req.Header.Set("Content-Type", "text/xml")
+	ex.Sent(req, []byte(xml))
+
+	resp, err := c.Do(req)
 	if err != nil {
-		return content, err, resp.StatusCode
+		return content, err, 0
 	}
 	defer resp.Body.Close()
 
 	result, err := ioutil.ReadAll(resp.Body)
+	ex.Received(resp, result)
 	return result, err, resp.StatusCode
 }
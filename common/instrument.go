@@ -0,0 +1,143 @@
+package common
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Span is the part of a tracing span the FedEx calls use. An OpenTelemetry
+// adapter is a thin wrapper around trace.Span.
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+// Instrumentation receives a span for every FedEx call plus counters and
+// histograms. Nothing in this module depends on a tracing or metrics
+// library; plug one in with Instrument or Fedex.Instrumentation.
+type Instrumentation interface {
+	StartSpan(ctx context.Context, operation string) (context.Context, Span)
+	AddCounter(name string, value int64, attributes map[string]string)
+	RecordHistogram(name string, value float64, attributes map[string]string)
+}
+
+const (
+	OperationAuth     = "fedex.auth"
+	OperationRate     = "fedex.rate"
+	OperationRateSOAP = "fedex.rate.soap"
+	OperationTrack    = "fedex.track"
+
+	AttributeOperation             = "fedex.operation"
+	AttributeStatusCode            = "http.status_code"
+	AttributeServiceType           = "fedex.service_type"
+	AttributeTransactionID         = "fedex.transaction_id"
+	AttributeCustomerTransactionID = "fedex.customer_transaction_id"
+	AttributeErrorCode             = "fedex.error_code"
+	AttributeGrantType             = "fedex.grant_type"
+
+	MetricRequests       = "fedex.requests"         // counter by operation and status
+	MetricDuration       = "fedex.request.duration" // histogram in seconds by operation and status
+	MetricErrors         = "fedex.errors"           // counter by operation and error code
+	MetricRetries        = "fedex.retries"          // counter by operation and reason, for retrying callers
+	MetricTokenCache     = "fedex.token.cache"      // counter by result, hit, miss or shared
+	MetricTokenRefreshes = "fedex.token.refreshes"  // counter by grant type
+)
+
+var (
+	instrumentMu    sync.RWMutex
+	instrumentation Instrumentation
+)
+
+// Instrument sets the instrumentation used by every call that does not
+// bring its own; nil turns it off.
+func Instrument(i Instrumentation) {
+	instrumentMu.Lock()
+	instrumentation = i
+	instrumentMu.Unlock()
+}
+
+func Instrumented() Instrumentation {
+	instrumentMu.RLock()
+	defer instrumentMu.RUnlock()
+	return instrumentation
+}
+
+// Count adds to a counter on i, or on the global instrumentation if i is nil.
+func Count(i Instrumentation, name string, value int64, attributes map[string]string) {
+	if i == nil {
+		i = Instrumented()
+	}
+	if i != nil {
+		i.AddCounter(name, value, attributes)
+	}
+}
+
+// Call is one instrumented FedEx operation. A Call without instrumentation
+// does nothing, so callers need not check.
+type Call struct {
+	instrumentation Instrumentation
+	operation       string
+	ctx             context.Context
+	span            Span
+	start           time.Time
+	errorCode       string
+}
+
+// StartCall starts a span for operation on i, or on the global
+// instrumentation if i is nil.
+func StartCall(ctx context.Context, i Instrumentation, operation string) *Call {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if i == nil {
+		i = Instrumented()
+	}
+	c := &Call{instrumentation: i, operation: operation, ctx: ctx, start: time.Now()}
+	if i != nil {
+		c.ctx, c.span = i.StartSpan(ctx, operation)
+		c.span.SetAttribute(AttributeOperation, operation)
+	}
+	return c
+}
+
+// Context carries the span to nested calls.
+func (c *Call) Context() context.Context {
+	return c.ctx
+}
+
+func (c *Call) SetAttribute(key string, value interface{}) {
+	if c.span != nil && value != "" {
+		c.span.SetAttribute(key, value)
+	}
+}
+
+// ErrorCode records the FedEx error code of a failed call.
+func (c *Call) ErrorCode(code string) {
+	c.errorCode = code
+	c.SetAttribute(AttributeErrorCode, code)
+}
+
+// End closes the span and records the request metrics. status is the HTTP
+// status, 0 if no response arrived.
+func (c *Call) End(status int, err error) {
+	if c.instrumentation == nil {
+		return
+	}
+	if status != 0 {
+		c.span.SetAttribute(AttributeStatusCode, status)
+	}
+	if err != nil {
+		c.span.RecordError(err)
+	}
+	c.span.End()
+
+	attributes := map[string]string{"operation": c.operation, "status": strconv.Itoa(status)}
+	c.instrumentation.AddCounter(MetricRequests, 1, attributes)
+	c.instrumentation.RecordHistogram(MetricDuration, time.Since(c.start).Seconds(), attributes)
+	if err != nil || c.errorCode != "" {
+		c.instrumentation.AddCounter(MetricErrors, 1, map[string]string{"operation": c.operation, "code": c.errorCode})
+	}
+}
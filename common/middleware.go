@@ -0,0 +1,87 @@
+package common
+
+import (
+	"net/http"
+	"sync"
+)
+
+// Exchange is one FedEx operation as middleware sees it. Before calling
+// next, middleware may change Request (a pointer to the typed request, e.g.
+// *rate.RateRequest) or add Header entries; once next returns, the HTTP
+// fields and Result (a pointer to the decoded reply) are filled in.
+type Exchange struct {
+	Operation    string         // OperationAuth, OperationRate, ...
+	Request      interface{}    //
+	Header       http.Header    // set on the outbound request, replacing defaults
+	HTTPRequest  *http.Request  //
+	RequestBody  []byte         //
+	HTTPResponse *http.Response // body already read into ResponseBody
+	ResponseBody []byte         //
+	Result       interface{}    //
+}
+
+type Handler func(ex *Exchange) error
+
+type Middleware func(next Handler) Handler
+
+var (
+	middlewareMu sync.RWMutex
+	middleware   []Middleware
+)
+
+// Use appends middleware applied to every FedEx operation, outside of any
+// Fedex.Middleware.
+func Use(m ...Middleware) {
+	middlewareMu.Lock()
+	middleware = append(middleware, m...)
+	middlewareMu.Unlock()
+}
+
+// ResetMiddleware removes everything added with Use.
+func ResetMiddleware() {
+	middlewareMu.Lock()
+	middleware = nil
+	middlewareMu.Unlock()
+}
+
+// Chain runs h behind the global middleware and then extra, the first one
+// outermost.
+func Chain(h Handler, extra ...Middleware) Handler {
+	middlewareMu.RLock()
+	all := append(append([]Middleware(nil), middleware...), extra...)
+	middlewareMu.RUnlock()
+	for i := len(all) - 1; i >= 0; i-- {
+		h = all[i](h)
+	}
+	return h
+}
+
+// Run performs an operation through the middleware chain of c.
+func (c Fedex) Run(ex *Exchange, h Handler) error {
+	if ex.Header == nil {
+		ex.Header = http.Header{}
+	}
+	return Chain(h, c.Middleware...)(ex)
+}
+
+// Sent applies the headers set by middleware to an outbound request and
+// records it.
+func (ex *Exchange) Sent(req *http.Request, body []byte) {
+	if ex == nil {
+		return
+	}
+	for k, v := range ex.Header {
+		req.Header[k] = v
+	}
+	ex.HTTPRequest = req
+	ex.RequestBody = body
+}
+
+// Received records the reply of an exchange.
+func (ex *Exchange) Received(resp *http.Response, body []byte) {
+	if ex == nil {
+		return
+	}
+	ex.HTTPResponse = resp
+	ex.ResponseBody = body
+}
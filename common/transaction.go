@@ -0,0 +1,68 @@
+package common
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+const HeaderCustomerTransactionID = "x-customer-transaction-id"
+
+type transactionKey struct{}
+
+// WithTransactionID attaches a customer transaction ID, e.g. an order ID, to
+// ctx; calls made with Fedex.Context set to ctx send it to FedEx.
+func WithTransactionID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, transactionKey{}, id)
+}
+
+func TransactionIDFrom(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(transactionKey{}).(string)
+	return id
+}
+
+// NewTransactionID returns a random UUID for calls the caller did not name.
+func NewTransactionID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	s := hex.EncodeToString(b)
+	return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
+
+// TransactionID returns the customer transaction ID for a call through c:
+// CustomerTransactionID if set, else the one on Context, else a new one.
+func (c Fedex) TransactionID() string {
+	if c.CustomerTransactionID != "" {
+		return c.CustomerTransactionID
+	}
+	if id := TransactionIDFrom(c.Context); id != "" {
+		return id
+	}
+	return NewTransactionID()
+}
+
+// TransactionError is a failed FedEx call annotated with the IDs FedEx
+// support asks for.
+type TransactionError struct {
+	Err                   error  //
+	CustomerTransactionID string //
+	TransactionID         string // assigned by FedEx, if the reply had one
+	StatusCode            int    // HTTP status of the reply, 0 if none arrived
+}
+
+func (e *TransactionError) Error() string {
+	msg := e.Err.Error() + " (customer transaction " + e.CustomerTransactionID
+	if e.TransactionID != "" {
+		msg += ", FedEx transaction " + e.TransactionID
+	}
+	return msg + ")"
+}
+
+func (e *TransactionError) Unwrap() error {
+	return e.Err
+}
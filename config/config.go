@@ -0,0 +1,246 @@
+// Package config gathers FedEx settings from a YAML or JSON file, the
+// environment and functional options, in that order of precedence (options
+// win), and turns them into a ready client.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/tirpitz0509/go-fedex/auth"
+	"github.com/tirpitz0509/go-fedex/tenant"
+	"gopkg.in/yaml.v3"
+)
+
+type API string
+
+const (
+	REST API = "REST"
+	SOAP API = "SOAP"
+)
+
+type Config struct {
+	GrantType         string `json:"grantType,omitempty" yaml:"grantType,omitempty"`                 //
+	ClientId          string `json:"clientId,omitempty" yaml:"clientId,omitempty"`                   //
+	ClientSecret      string `json:"clientSecret,omitempty" yaml:"clientSecret,omitempty"`           //
+	ChildKey          string `json:"childKey,omitempty" yaml:"childKey,omitempty"`                   //
+	ChildSecret       string `json:"childSecret,omitempty" yaml:"childSecret,omitempty"`             //
+	AccountNumber     string `json:"accountNumber,omitempty" yaml:"accountNumber,omitempty"`         //
+	MeterNumber       string `json:"meterNumber,omitempty" yaml:"meterNumber,omitempty"`             // SOAP only
+	Key               string `json:"key,omitempty" yaml:"key,omitempty"`                             // SOAP only
+	Password          string `json:"password,omitempty" yaml:"password,omitempty"`                   // SOAP only
+	TestMode          bool   `json:"testMode,omitempty" yaml:"testMode,omitempty"`                   //
+	Locale            string `json:"locale,omitempty" yaml:"locale,omitempty"`                       //
+	BaseURL           string `json:"baseUrl,omitempty" yaml:"baseUrl,omitempty"`                     //
+	SOAPURL           string `json:"soapUrl,omitempty" yaml:"soapUrl,omitempty"`                     //
+	RESTCompatibility bool   `json:"restCompatibility,omitempty" yaml:"restCompatibility,omitempty"` // SOAP rate requests go to the REST API
+
+	File   string       `json:"-" yaml:"-"` // read before the environment, FEDEX_CONFIG if empty
+	Client *http.Client `json:"-" yaml:"-"` //
+}
+
+type Option func(*Config)
+
+func WithFile(path string) Option {
+	return func(c *Config) { c.File = path }
+}
+
+func WithCredentials(clientId string, clientSecret string) Option {
+	return func(c *Config) { c.ClientId, c.ClientSecret = clientId, clientSecret }
+}
+
+// WithChildCredentials switches to grantType (csp_credentials or
+// client_pc_credentials) for the given customer.
+func WithChildCredentials(grantType string, childKey string, childSecret string) Option {
+	return func(c *Config) { c.GrantType, c.ChildKey, c.ChildSecret = grantType, childKey, childSecret }
+}
+
+func WithAccountNumber(accountNumber string) Option {
+	return func(c *Config) { c.AccountNumber = accountNumber }
+}
+
+// WithWebServices sets the SOAP credentials.
+func WithWebServices(key string, password string, meterNumber string) Option {
+	return func(c *Config) { c.Key, c.Password, c.MeterNumber = key, password, meterNumber }
+}
+
+func WithTestMode(testMode bool) Option {
+	return func(c *Config) { c.TestMode = testMode }
+}
+
+func WithLocale(locale string) Option {
+	return func(c *Config) { c.Locale = locale }
+}
+
+// WithURLs points the client at a mock or proxy; either may be empty.
+func WithURLs(baseURL string, soapURL string) Option {
+	return func(c *Config) { c.BaseURL, c.SOAPURL = baseURL, soapURL }
+}
+
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Config) { c.Client = client }
+}
+
+// environment maps variables to the settings they fill.
+var environment = []struct {
+	name string
+	set  func(c *Config, value string) error
+}{
+	{"FEDEX_GRANT_TYPE", func(c *Config, v string) error { c.GrantType = v; return nil }},
+	{"FEDEX_CLIENT_ID", func(c *Config, v string) error { c.ClientId = v; return nil }},
+	{"FEDEX_CLIENT_SECRET", func(c *Config, v string) error { c.ClientSecret = v; return nil }},
+	{"FEDEX_CHILD_KEY", func(c *Config, v string) error { c.ChildKey = v; return nil }},
+	{"FEDEX_CHILD_SECRET", func(c *Config, v string) error { c.ChildSecret = v; return nil }},
+	{"FEDEX_ACCOUNT_NUMBER", func(c *Config, v string) error { c.AccountNumber = v; return nil }},
+	{"FEDEX_METER_NUMBER", func(c *Config, v string) error { c.MeterNumber = v; return nil }},
+	{"FEDEX_KEY", func(c *Config, v string) error { c.Key = v; return nil }},
+	{"FEDEX_PASSWORD", func(c *Config, v string) error { c.Password = v; return nil }},
+	{"FEDEX_TEST_MODE", func(c *Config, v string) (err error) { c.TestMode, err = strconv.ParseBool(v); return }},
+	{"FEDEX_LOCALE", func(c *Config, v string) error { c.Locale = v; return nil }},
+	{"FEDEX_BASE_URL", func(c *Config, v string) error { c.BaseURL = v; return nil }},
+	{"FEDEX_SOAP_URL", func(c *Config, v string) error { c.SOAPURL = v; return nil }},
+	{"FEDEX_REST_COMPATIBILITY", func(c *Config, v string) (err error) { c.RESTCompatibility, err = strconv.ParseBool(v); return }},
+}
+
+// Load reads the file, then the environment, then applies opts. Options are
+// applied once first as well, so WithFile can choose the file.
+func Load(opts ...Option) (Config, error) {
+	var options Config
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	c := Config{Locale: "en_US"}
+	path := options.File
+	if path == "" {
+		path = os.Getenv("FEDEX_CONFIG")
+	}
+	if path != "" {
+		if err := c.loadFile(path); err != nil {
+			return c, err
+		}
+		c.File = path
+	}
+
+	for _, e := range environment {
+		if v, ok := os.LookupEnv(e.name); ok {
+			if err := e.set(&c, v); err != nil {
+				return c, errors.New("config: " + e.name + ": " + err.Error())
+			}
+		}
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c, nil
+}
+
+func (c *Config) loadFile(path string) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(content, c)
+	} else {
+		err = yaml.Unmarshal(content, c)
+	}
+	if err != nil {
+		return errors.New("config: " + path + ": " + err.Error())
+	}
+	return nil
+}
+
+// APIs returns the APIs with any settings, REST if there are none.
+func (c Config) APIs() []API {
+	var apis []API
+	if c.ClientId != "" || c.ClientSecret != "" {
+		apis = append(apis, REST)
+	}
+	if c.Key != "" || c.Password != "" || c.MeterNumber != "" {
+		apis = append(apis, SOAP)
+	}
+	if len(apis) == 0 {
+		apis = append(apis, REST)
+	}
+	return apis
+}
+
+// Validate reports the settings missing for the given APIs, or for APIs()
+// when none are given.
+func (c Config) Validate(apis ...API) error {
+	if len(apis) == 0 {
+		apis = c.APIs()
+	}
+	var missing []string
+	need := func(value string, name string) {
+		if value == "" {
+			missing = append(missing, name)
+		}
+	}
+	need(c.AccountNumber, "FEDEX_ACCOUNT_NUMBER")
+	for _, api := range apis {
+		switch api {
+		case REST:
+			need(c.ClientId, "FEDEX_CLIENT_ID")
+			need(c.ClientSecret, "FEDEX_CLIENT_SECRET")
+			switch c.GrantType {
+			case "", auth.GrantClientCredentials:
+			case auth.GrantCSPCredentials, auth.GrantClientPCCredentials:
+				need(c.ChildKey, "FEDEX_CHILD_KEY")
+				need(c.ChildSecret, "FEDEX_CHILD_SECRET")
+			default:
+				return errors.New("config: unknown grant type " + strconv.Quote(c.GrantType))
+			}
+		case SOAP:
+			need(c.Key, "FEDEX_KEY")
+			need(c.Password, "FEDEX_PASSWORD")
+			need(c.MeterNumber, "FEDEX_METER_NUMBER")
+		}
+	}
+	if len(missing) > 0 {
+		return errors.New("config: missing " + strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func (c Config) Credentials() tenant.Credentials {
+	return tenant.Credentials{
+		GrantType:         c.GrantType,
+		ClientId:          c.ClientId,
+		ClientSecret:      c.ClientSecret,
+		ChildKey:          c.ChildKey,
+		ChildSecret:       c.ChildSecret,
+		AccountNumber:     c.AccountNumber,
+		Key:               c.Key,
+		Password:          c.Password,
+		MeterNumber:       c.MeterNumber,
+		TestMode:          c.TestMode,
+		Locale:            c.Locale,
+		BaseURL:           c.BaseURL,
+		SOAPURL:           c.SOAPURL,
+		RESTCompatibility: c.RESTCompatibility,
+	}
+}
+
+// New loads and validates the configuration and returns a client for it,
+// with its own token cache.
+func New(opts ...Option) (*tenant.Tenant, error) {
+	c, err := Load(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	registry := tenant.NewRegistry(nil)
+	registry.Client = c.Client
+	return registry.Register("default", c.Credentials()), nil
+}
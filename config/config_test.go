@@ -0,0 +1,164 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tirpitz0509/go-fedex/auth"
+)
+
+// setenv replaces every FEDEX_ variable with vars for the test.
+func setenv(t *testing.T, vars map[string]string) {
+	names := []string{"FEDEX_CONFIG"}
+	for _, e := range environment {
+		names = append(names, e.name)
+	}
+	for _, name := range names {
+		name := name
+		if old, ok := os.LookupEnv(name); ok {
+			t.Cleanup(func() { os.Setenv(name, old) })
+		} else {
+			t.Cleanup(func() { os.Unsetenv(name) })
+		}
+		os.Unsetenv(name)
+	}
+	for name, value := range vars {
+		os.Setenv(name, value)
+	}
+}
+
+func writeFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	yamlFile := writeFile(t, "fedex.yaml", "clientId: file-id\nclientSecret: file-secret\naccountNumber: \"111111111\"\nlocale: fr_CA\n")
+	jsonFile := writeFile(t, "fedex.json", `{"clientId":"json-id","accountNumber":"222222222","testMode":true}`)
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		opts []Option
+		want Config
+	}{
+		{"defaults", nil, nil, Config{Locale: "en_US"}},
+		{"file", nil, []Option{WithFile(yamlFile)},
+			Config{ClientId: "file-id", ClientSecret: "file-secret", AccountNumber: "111111111", Locale: "fr_CA", File: yamlFile}},
+		{"file from the environment", map[string]string{"FEDEX_CONFIG": jsonFile}, nil,
+			Config{ClientId: "json-id", AccountNumber: "222222222", TestMode: true, Locale: "en_US", File: jsonFile}},
+		{"option chooses the file over the environment", map[string]string{"FEDEX_CONFIG": jsonFile}, []Option{WithFile(yamlFile)},
+			Config{ClientId: "file-id", ClientSecret: "file-secret", AccountNumber: "111111111", Locale: "fr_CA", File: yamlFile}},
+		{"environment over file", map[string]string{"FEDEX_CLIENT_ID": "env-id", "FEDEX_TEST_MODE": "true"}, []Option{WithFile(yamlFile)},
+			Config{ClientId: "env-id", ClientSecret: "file-secret", AccountNumber: "111111111", Locale: "fr_CA", TestMode: true, File: yamlFile}},
+		{"options over environment", map[string]string{"FEDEX_CLIENT_ID": "env-id", "FEDEX_ACCOUNT_NUMBER": "333333333"},
+			[]Option{WithFile(yamlFile), WithCredentials("opt-id", "opt-secret"), WithLocale("de_DE")},
+			Config{ClientId: "opt-id", ClientSecret: "opt-secret", AccountNumber: "333333333", Locale: "de_DE", File: yamlFile}},
+		{"options over environment for booleans", map[string]string{"FEDEX_TEST_MODE": "true"}, []Option{WithTestMode(false)},
+			Config{Locale: "en_US"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setenv(t, tt.env)
+			got, err := Load(tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Load() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		opts []Option
+		want string
+	}{
+		{"missing file", nil, []Option{WithFile(filepath.Join(t.TempDir(), "missing.yaml"))}, "missing.yaml"},
+		{"bad file", nil, []Option{WithFile(writeFile(t, "bad.json", "{"))}, "config: "},
+		{"bad boolean", map[string]string{"FEDEX_TEST_MODE": "sometimes"}, nil, "config: FEDEX_TEST_MODE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setenv(t, tt.env)
+			if _, err := Load(tt.opts...); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load() = %v, want an error about %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	rest := Config{ClientId: "id", ClientSecret: "secret", AccountNumber: "111111111"}
+	soap := Config{Key: "key", Password: "password", MeterNumber: "meter", AccountNumber: "111111111"}
+	both := rest
+	both.Key, both.Password, both.MeterNumber = soap.Key, soap.Password, soap.MeterNumber
+
+	tests := []struct {
+		name   string
+		config Config
+		apis   []API
+		want   string // "" for valid
+	}{
+		{"REST", rest, nil, ""},
+		{"SOAP", soap, nil, ""},
+		{"both", both, nil, ""},
+		{"nothing means REST", Config{}, nil, "config: missing FEDEX_ACCOUNT_NUMBER, FEDEX_CLIENT_ID, FEDEX_CLIENT_SECRET"},
+		{"REST without secret", Config{ClientId: "id", AccountNumber: "111111111"}, nil, "config: missing FEDEX_CLIENT_SECRET"},
+		{"SOAP without meter", Config{Key: "key", Password: "password", AccountNumber: "111111111"}, nil, "config: missing FEDEX_METER_NUMBER"},
+		{"SOAP asked of a REST config", rest, []API{SOAP}, "config: missing FEDEX_KEY, FEDEX_PASSWORD, FEDEX_METER_NUMBER"},
+		{"REST asked of a SOAP config", soap, []API{REST}, "config: missing FEDEX_CLIENT_ID, FEDEX_CLIENT_SECRET"},
+		{"child credentials", Config{ClientId: "id", ClientSecret: "secret", AccountNumber: "111111111", GrantType: auth.GrantCSPCredentials}, nil,
+			"config: missing FEDEX_CHILD_KEY, FEDEX_CHILD_SECRET"},
+		{"unknown grant type", Config{ClientId: "id", ClientSecret: "secret", AccountNumber: "111111111", GrantType: "password"}, nil,
+			`config: unknown grant type "password"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate(tt.apis...)
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Validate() = %v, want %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIs(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   string
+	}{
+		{"none", Config{}, "REST"},
+		{"REST", Config{ClientId: "id"}, "REST"},
+		{"SOAP", Config{MeterNumber: "meter"}, "SOAP"},
+		{"both", Config{ClientSecret: "secret", Key: "key"}, "REST,SOAP"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, api := range tt.config.APIs() {
+				got = append(got, string(api))
+			}
+			if strings.Join(got, ",") != tt.want {
+				t.Errorf("APIs() = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// Package currency converts quotes between currencies, so quotes returned
+// in mixed currencies can be compared in one, usually the preferred
+// currency of the request. Rates come from a Provider: a fixed Table, one
+// loaded from a file, or the exchange rates FedEx reported with quotes.
+package currency
+
+import (
+	"errors"
+	"math"
+	"strings"
+
+	"github.com/tirpitz0509/go-fedex/rate"
+)
+
+type Provider interface {
+	// Rate returns the units of to that one unit of from buys.
+	Rate(from string, to string) (float64, error)
+}
+
+// Chain is a Provider trying each provider in turn, e.g. the rates FedEx
+// reported before a file of fallback rates.
+type Chain []Provider
+
+func (c Chain) Rate(from string, to string) (float64, error) {
+	err := errors.New("currency: no providers")
+	for _, p := range c {
+		var r float64
+		if r, err = p.Rate(from, to); err == nil {
+			return r, nil
+		}
+	}
+	return 0, err
+}
+
+type Converter struct {
+	Provider Provider //
+}
+
+// Convert converts amount, unrounded.
+func (c Converter) Convert(amount float64, from string, to string) (float64, error) {
+	if strings.EqualFold(from, to) {
+		return amount, nil
+	}
+	if c.Provider == nil {
+		return 0, errors.New("currency: no rate provider")
+	}
+	r, err := c.Provider.Rate(strings.ToUpper(from), strings.ToUpper(to))
+	if err != nil {
+		return 0, err
+	}
+	return amount * r, nil
+}
+
+// Quote returns q with every amount converted into currency and rounded to
+// cents, recording the rate used in Conversion; ExchangeRate keeps the rate
+// FedEx reported. Converting a converted quote again chains the rates, so
+// Conversion always runs from the currency FedEx quoted in. A quote already
+// in currency is returned as is.
+func (c Converter) Quote(q rate.Quote, currency string) (rate.Quote, error) {
+	currency = strings.ToUpper(currency)
+	if q.Currency == "" {
+		return q, errors.New("currency: " + string(q.ServiceType) + " quote has no currency")
+	}
+	if strings.EqualFold(q.Currency, currency) {
+		return q, nil
+	}
+	r, err := c.Convert(1, q.Currency, currency)
+	if err != nil {
+		return q, err
+	}
+	convert := func(amount float64) float64 {
+		return math.Round(amount*r*100) / 100
+	}
+
+	conversion := &rate.CurrencyExchangeRate{FromCurrency: strings.ToUpper(q.Currency), IntoCurrency: currency, Rate: r}
+	if c := q.Conversion; c != nil {
+		conversion.FromCurrency, conversion.Rate = c.FromCurrency, c.Rate*r
+	}
+	q.Conversion = conversion
+	q.Currency = currency
+	q.BaseCharge = convert(q.BaseCharge)
+	q.Surcharges = convertCharges(q.Surcharges, convert)
+	q.TotalSurcharges = convert(q.TotalSurcharges)
+	q.Discounts = convert(q.Discounts)
+	q.Taxes = convert(q.Taxes)
+	q.DutiesAndTaxes = convert(q.DutiesAndTaxes)
+	q.NetCharge = convert(q.NetCharge)
+	packages := make([]rate.PackageQuote, len(q.Packages))
+	for i, p := range q.Packages {
+		p.BaseCharge = convert(p.BaseCharge)
+		p.Surcharges = convertCharges(p.Surcharges, convert)
+		p.TotalSurcharges = convert(p.TotalSurcharges)
+		p.NetCharge = convert(p.NetCharge)
+		packages[i] = p
+	}
+	if len(packages) > 0 {
+		q.Packages = packages
+	}
+	return q, nil
+}
+
+// Quotes converts every quote into currency.
+func (c Converter) Quotes(quotes []rate.Quote, currency string) ([]rate.Quote, error) {
+	converted := make([]rate.Quote, len(quotes))
+	for i, q := range quotes {
+		var err error
+		if converted[i], err = c.Quote(q, currency); err != nil {
+			return nil, err
+		}
+	}
+	return converted, nil
+}
+
+// Preferred converts quotes into the preferred currency of request, the
+// one FedEx was asked to quote in. Without one the quotes are returned as
+// is.
+func (c Converter) Preferred(request rate.RateRequest, quotes []rate.Quote) ([]rate.Quote, error) {
+	return c.preferred(request.RequestedShipment.PreferredCurrency, quotes)
+}
+
+// PreferredXML is Preferred for a SOAP request.
+func (c Converter) PreferredXML(request rate.RateXMLRequest, quotes []rate.Quote) ([]rate.Quote, error) {
+	return c.preferred(request.Body.RateRequest.RequestedShipment.PreferredCurrency, quotes)
+}
+
+func (c Converter) preferred(currency string, quotes []rate.Quote) ([]rate.Quote, error) {
+	if currency == "" {
+		return quotes, nil
+	}
+	return c.Quotes(quotes, currency)
+}
+
+func convertCharges(charges []rate.QuoteCharge, convert func(float64) float64) []rate.QuoteCharge {
+	if len(charges) == 0 {
+		return charges
+	}
+	converted := make([]rate.QuoteCharge, len(charges))
+	for i, c := range charges {
+		c.Amount = convert(c.Amount)
+		converted[i] = c
+	}
+	return converted
+}
@@ -0,0 +1,213 @@
+package currency
+
+import (
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tirpitz0509/go-fedex/rate"
+)
+
+func TestQuoteKeepsFedExRate(t *testing.T) {
+	table := NewTable()
+	table.Set("USD", "EUR", 0.9)
+	table.Set("USD", "GBP", 0.8)
+	fedex := &rate.CurrencyExchangeRate{FromCurrency: "CAD", IntoCurrency: "USD", Rate: 0.73}
+	q := rate.Quote{Currency: "USD", NetCharge: 100, ExchangeRate: fedex}
+	c := Converter{Provider: table}
+
+	tests := []struct {
+		name       string
+		currencies []string // converted into, in turn
+		net        float64
+		conversion *rate.CurrencyExchangeRate
+	}{
+		{"same currency", []string{"usd"}, 100, nil},
+		{"once", []string{"EUR"}, 90, &rate.CurrencyExchangeRate{FromCurrency: "USD", IntoCurrency: "EUR", Rate: 0.9}},
+		{"chained", []string{"EUR", "GBP"}, 80, &rate.CurrencyExchangeRate{FromCurrency: "USD", IntoCurrency: "GBP", Rate: 0.8}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converted := q
+			for _, currency := range tt.currencies {
+				var err error
+				if converted, err = c.Quote(converted, currency); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if converted.NetCharge != tt.net {
+				t.Errorf("NetCharge = %v, want %v", converted.NetCharge, tt.net)
+			}
+			if converted.ExchangeRate != fedex {
+				t.Errorf("ExchangeRate = %+v, want FedEx's %+v", converted.ExchangeRate, fedex)
+			}
+			got, want := converted.Conversion, tt.conversion
+			if (got == nil) != (want == nil) || got != nil && (got.FromCurrency != want.FromCurrency ||
+				got.IntoCurrency != want.IntoCurrency || got.Rate < want.Rate-1e-9 || got.Rate > want.Rate+1e-9) {
+				t.Errorf("Conversion = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestPreferred(t *testing.T) {
+	table := NewTable()
+	table.Set("USD", "EUR", 0.9)
+	c := Converter{Provider: table}
+	quotes := []rate.Quote{{Currency: "USD", NetCharge: 10}}
+
+	var rest rate.RateRequest
+	rest.RequestedShipment.PreferredCurrency = "EUR"
+	var soap rate.RateXMLRequest
+	soap.Body.RateRequest.RequestedShipment.PreferredCurrency = "EUR"
+
+	tests := []struct {
+		name    string
+		convert func() ([]rate.Quote, error)
+		net     float64
+	}{
+		{"REST request", func() ([]rate.Quote, error) { return c.Preferred(rest, quotes) }, 9},
+		{"SOAP request", func() ([]rate.Quote, error) { return c.PreferredXML(soap, quotes) }, 9},
+		{"none", func() ([]rate.Quote, error) { return c.Preferred(rate.RateRequest{}, quotes) }, 10},
+		{"none over SOAP", func() ([]rate.Quote, error) { return c.PreferredXML(rate.RateXMLRequest{}, quotes) }, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converted, err := tt.convert()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if converted[0].NetCharge != tt.net {
+				t.Errorf("NetCharge = %v, want %v", converted[0].NetCharge, tt.net)
+			}
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	fedex := NewTable()
+	fedex.Set("USD", "EUR", 0.9)
+	fallback := NewTable()
+	fallback.Set("USD", "EUR", 0.8)
+	fallback.Set("USD", "GBP", 0.75)
+	chain := Chain{fedex, fallback}
+
+	tests := []struct {
+		from string
+		to   string
+		want float64 // 0 for an error
+	}{
+		{"USD", "EUR", 0.9},
+		{"USD", "GBP", 0.75},
+		{"USD", "JPY", 0},
+	}
+	for _, tt := range tests {
+		got, err := chain.Rate(tt.from, tt.to)
+		if tt.want == 0 {
+			if err == nil || !strings.Contains(err.Error(), "JPY") {
+				t.Errorf("Rate(%s, %s) = %v, %v, want the last provider's error", tt.from, tt.to, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Rate(%s, %s) = %v, %v, want %v", tt.from, tt.to, got, err, tt.want)
+		}
+	}
+
+	if _, err := (Chain{}).Rate("USD", "EUR"); err == nil {
+		t.Error("empty chain: err = nil")
+	}
+}
+
+func TestFromQuotes(t *testing.T) {
+	table := FromQuotes([]rate.Quote{
+		{Currency: "USD", ExchangeRate: &rate.CurrencyExchangeRate{FromCurrency: "CAD", IntoCurrency: "USD", Rate: 0.75}},
+		{Currency: "EUR", ExchangeRate: &rate.CurrencyExchangeRate{FromCurrency: "usd", IntoCurrency: "eur", Rate: 0.9}},
+		{Currency: "GBP"},
+		{Currency: "JPY", ExchangeRate: &rate.CurrencyExchangeRate{FromCurrency: "USD", IntoCurrency: "JPY", Rate: 0}},
+	})
+
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want float64 // 0 for an error
+	}{
+		{"reported", "CAD", "USD", 0.75},
+		{"reported in lower case", "USD", "EUR", 0.9},
+		{"inverse", "EUR", "USD", 1 / 0.9},
+		{"cross", "CAD", "EUR", 0.75 * 0.9},
+		{"inverse cross", "EUR", "CAD", 1 / 0.9 / 0.75},
+		{"same", "gbp", "GBP", 1},
+		{"not reported", "USD", "GBP", 0},
+		{"zero rate ignored", "USD", "JPY", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := table.Rate(tt.from, tt.to)
+			if tt.want == 0 {
+				if err == nil {
+					t.Errorf("Rate() = %v, want an error", got)
+				}
+				return
+			}
+			if err != nil || math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Rate() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadTable(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr string // "" to succeed with USD->EUR 0.92 and USD->GBP 0.79
+	}{
+		{"CSV", write("rates.csv", "from,to,rate\nUSD,EUR,0.92\n usd , gbp ,0.79\n"), ""},
+		{"CSV without header", write("rates.txt", "USD,EUR,0.92\nUSD,GBP,0.79\n"), ""},
+		{"JSON", write("rates.json", `{"base":"USD","rates":{"EUR":0.92,"GBP":0.79}}`), ""},
+		{"YAML", write("rates.yaml", "base: USD\nrates: {EUR: 0.92, GBP: 0.79}\n"), ""},
+		{"YML", write("rates.yml", "base: usd\nrates:\n  eur: 0.92\n  gbp: 0.79\n"), ""},
+		{"missing", filepath.Join(dir, "missing.csv"), "missing.csv"},
+		{"short CSV line", write("short.csv", "USD,EUR,0.92\nUSD,GBP\n"), "short.csv: currency: line 2"},
+		{"bad CSV rate", write("bad.csv", "USD,EUR,0.92\nUSD,GBP,lots\n"), `bad.csv: currency: line 2: bad rate "lots"`},
+		{"negative CSV rate", write("negative.csv", "USD,EUR,-1\n"), "negative.csv: currency: line 1"},
+		{"bad JSON", write("bad.json", "{"), "currency: " + filepath.Join(dir, "bad.json")},
+		{"no base", write("nobase.yaml", "rates: {EUR: 0.92}\n"), "no base currency"},
+		{"zero rate", write("zero.json", `{"base":"USD","rates":{"EUR":0}}`), "rate for EUR must be positive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := LoadTable(tt.path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("LoadTable() = %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range []struct {
+				to   string
+				rate float64
+			}{{"EUR", 0.92}, {"GBP", 0.79}} {
+				if got, err := table.Rate("USD", want.to); err != nil || got != want.rate {
+					t.Errorf("Rate(USD, %s) = %v, %v, want %v", want.to, got, err, want.rate)
+				}
+			}
+		})
+	}
+}
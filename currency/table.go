@@ -0,0 +1,186 @@
+package currency
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/tirpitz0509/go-fedex/rate"
+	"gopkg.in/yaml.v3"
+)
+
+// Table is a Provider of fixed rates. Besides the rates set, it derives
+// their inverses and cross rates through one common currency. It is safe
+// for concurrent use.
+type Table struct {
+	mu    sync.RWMutex
+	rates map[string]map[string]float64 // from -> to -> rate
+}
+
+func NewTable() *Table {
+	return &Table{rates: map[string]map[string]float64{}}
+}
+
+// Set records that one from buys r to; rates that are not positive are
+// ignored.
+func (t *Table) Set(from string, to string, r float64) {
+	if r <= 0 {
+		return
+	}
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.rates[from] == nil {
+		t.rates[from] = map[string]float64{}
+	}
+	t.rates[from][to] = r
+}
+
+func (t *Table) Rate(from string, to string) (float64, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return 1, nil
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if r, ok := t.direct(from, to); ok {
+		return r, nil
+	}
+	for _, via := range t.currencies() {
+		if r1, ok := t.direct(from, via); ok {
+			if r2, ok := t.direct(via, to); ok {
+				return r1 * r2, nil
+			}
+		}
+	}
+	return 0, errors.New("currency: no rate from " + from + " to " + to)
+}
+
+// direct finds a rate set either way; callers hold mu.
+func (t *Table) direct(from string, to string) (float64, bool) {
+	if r, ok := t.rates[from][to]; ok {
+		return r, true
+	}
+	if r, ok := t.rates[to][from]; ok {
+		return 1 / r, true
+	}
+	return 0, false
+}
+
+// currencies lists every currency with a rate, sorted so cross rates do
+// not depend on map order; callers hold mu.
+func (t *Table) currencies() []string {
+	seen := map[string]bool{}
+	var all []string
+	for from, rates := range t.rates {
+		for to := range rates {
+			for _, c := range []string{from, to} {
+				if !seen[c] {
+					seen[c] = true
+					all = append(all, c)
+				}
+			}
+		}
+	}
+	sort.Strings(all)
+	return all
+}
+
+// FromQuotes collects the exchange rates FedEx reported with quotes.
+func FromQuotes(quotes []rate.Quote) *Table {
+	t := NewTable()
+	for _, q := range quotes {
+		if e := q.ExchangeRate; e != nil {
+			t.Set(e.FromCurrency, e.IntoCurrency, e.Rate)
+		}
+	}
+	return t
+}
+
+// file is the YAML or JSON layout of a rates file: the units of each
+// currency one unit of Base buys.
+type file struct {
+	Base  string             `json:"base" yaml:"base"`   //
+	Rates map[string]float64 `json:"rates" yaml:"rates"` //
+}
+
+// LoadTable reads rates from CSV rows of from, to and rate, with an
+// optional header, or from YAML or JSON such as
+//
+//	base: USD
+//	rates: {EUR: 0.92, GBP: 0.79}
+//
+// by the extension of path.
+func LoadTable(path string) (*Table, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != ".json" && ext != ".yaml" && ext != ".yml" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		t, err := ReadTable(f)
+		if err != nil {
+			return nil, errors.New(path + ": " + err.Error())
+		}
+		return t, nil
+	}
+
+	var rates file
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if ext == ".json" {
+		err = json.Unmarshal(content, &rates)
+	} else {
+		err = yaml.Unmarshal(content, &rates)
+	}
+	if err != nil {
+		return nil, errors.New("currency: " + path + ": " + err.Error())
+	}
+	if rates.Base == "" {
+		return nil, errors.New("currency: " + path + ": no base currency")
+	}
+	t := NewTable()
+	for to, r := range rates.Rates {
+		if r <= 0 {
+			return nil, errors.New("currency: " + path + ": rate for " + to + " must be positive")
+		}
+		t.Set(rates.Base, to, r)
+	}
+	return t, nil
+}
+
+// ReadTable reads CSV rows of from currency, to currency and rate.
+func ReadTable(r io.Reader) (*Table, error) {
+	c := csv.NewReader(r)
+	c.FieldsPerRecord = -1
+	records, err := c.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	t := NewTable()
+	for n, record := range records {
+		if len(record) < 3 {
+			return nil, errors.New("currency: line " + strconv.Itoa(n+1) + ": want from, to, rate")
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(record[2]), 64)
+		if err != nil && n == 0 {
+			continue // header
+		}
+		if err != nil || v <= 0 {
+			return nil, errors.New("currency: line " + strconv.Itoa(n+1) + ": bad rate " + strconv.Quote(record[2]))
+		}
+		t.Set(strings.TrimSpace(record[0]), strings.TrimSpace(record[1]), v)
+	}
+	return t, nil
+}
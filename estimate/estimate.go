@@ -0,0 +1,187 @@
+// Package estimate prices shipments offline from FedEx published rate
+// tables and zone charts, for checkout previews or when FedEx is down.
+// Estimates are list rates and ignore anything the tables do not cover,
+// such as account discounts and less common surcharges.
+package estimate
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/tirpitz0509/go-fedex/rate"
+)
+
+// Zoner finds the zone of a lane; ZoneChart is the simplest.
+type Zoner interface {
+	Zone(serviceType rate.ServiceType, origin string, destination string) (string, error)
+}
+
+const (
+	SurchargeFuel         = "FUEL"
+	SurchargeResidential  = "RESIDENTIAL_DELIVERY"
+	SurchargeDeliveryArea = "DELIVERY_AREA"
+)
+
+type Estimator struct {
+	Zones      Zoner                           //
+	Tables     map[rate.ServiceType]*RateTable //
+	Surcharges Surcharges                      //
+}
+
+func New(zones Zoner, surcharges Surcharges, tables ...*RateTable) *Estimator {
+	e := &Estimator{Zones: zones, Surcharges: surcharges, Tables: map[rate.ServiceType]*RateTable{}}
+	for _, t := range tables {
+		e.Tables[t.Service] = t
+	}
+	return e
+}
+
+// Estimate quotes request for its service, or for every service with a
+// table if it names none, cheapest first. Quotes have Source
+// rate.SourceEstimate and RateType LIST.
+func (e *Estimator) Estimate(request rate.RateRequest) ([]rate.Quote, error) {
+	shipment := request.RequestedShipment
+	if len(shipment.RequestedPackageLineItems) == 0 {
+		return nil, errors.New("estimate: no packages")
+	}
+	if shipment.ServiceType != "" {
+		q, err := e.estimate(shipment.ServiceType, request)
+		if err != nil {
+			return nil, err
+		}
+		return []rate.Quote{q}, nil
+	}
+
+	var quotes []rate.Quote
+	var errs []string
+	for serviceType := range e.Tables {
+		q, err := e.estimate(serviceType, request)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		quotes = append(quotes, q)
+	}
+	if len(quotes) == 0 {
+		if len(errs) == 0 {
+			return nil, errors.New("estimate: no rate tables")
+		}
+		sort.Strings(errs)
+		return nil, errors.New(strings.Join(errs, "; "))
+	}
+	sort.Slice(quotes, func(i, j int) bool {
+		if quotes[i].NetCharge != quotes[j].NetCharge {
+			return quotes[i].NetCharge < quotes[j].NetCharge
+		}
+		return quotes[i].ServiceType < quotes[j].ServiceType
+	})
+	return quotes, nil
+}
+
+func (e *Estimator) estimate(serviceType rate.ServiceType, request rate.RateRequest) (rate.Quote, error) {
+	shipment := request.RequestedShipment
+	table, ok := e.Tables[serviceType]
+	if !ok {
+		return rate.Quote{}, errors.New("estimate: no rate table for " + string(serviceType))
+	}
+	if e.Zones == nil {
+		return rate.Quote{}, errors.New("estimate: no zones")
+	}
+	origin := shipment.Shipper.Address
+	destination := shipment.Recipient.Address
+	zone, err := e.Zones.Zone(serviceType, origin.PostalCode, destination.PostalCode)
+	if err != nil {
+		return rate.Quote{}, err
+	}
+
+	divisor := e.Surcharges.DimDivisor
+	if divisor <= 0 {
+		divisor = 139
+	}
+	var base, billed, packages float64
+	for _, p := range shipment.RequestedPackageLineItems {
+		weight := BillableWeight(p, divisor)
+		charge, err := table.Charge(zone, weight)
+		if err != nil {
+			return rate.Quote{}, err
+		}
+		n := float64(p.GroupPackageCount)
+		if n < 1 {
+			n = 1
+		}
+		base += n * charge
+		billed += n * weight
+		packages += n
+	}
+
+	q := rate.Quote{
+		ServiceType:   serviceType,
+		ServiceName:   serviceType.DisplayName(),
+		PackagingType: string(shipment.PackagingType),
+		RateType:      rate.RateList,
+		Currency:      e.Surcharges.Currency,
+		BaseCharge:    round(base),
+		BillingWeight: rate.Weight{Units: "LB", Value: billed},
+		RateZone:      zone,
+		Source:        rate.SourceEstimate,
+	}
+	if q.Currency == "" {
+		q.Currency = "USD"
+	}
+	add := func(surchargeType string, description string, amount float64) {
+		if amount = round(amount); amount > 0 {
+			q.Surcharges = append(q.Surcharges, rate.QuoteCharge{Type: surchargeType, Description: description, Amount: amount})
+			q.TotalSurcharges += amount
+		}
+	}
+	// FedEx charges these per package
+	if destination.Residential {
+		add(SurchargeResidential, "Residential delivery", packages*forService(e.Surcharges.Residential, serviceType))
+	}
+	if e.deliveryArea(destination.PostalCode) {
+		add(SurchargeDeliveryArea, "Delivery area surcharge", packages*forService(e.Surcharges.DeliveryArea, serviceType))
+	}
+	add(SurchargeFuel, "Fuel surcharge", (q.BaseCharge+q.TotalSurcharges)*forService(e.Surcharges.Fuel, serviceType)/100)
+	q.TotalSurcharges = round(q.TotalSurcharges)
+	q.NetCharge = round(q.BaseCharge + q.TotalSurcharges)
+	return q, nil
+}
+
+func (e *Estimator) deliveryArea(postalCode string) bool {
+	if len(postalCode) > 5 {
+		postalCode = postalCode[:5]
+	}
+	for _, p := range e.Surcharges.DeliveryAreaPostalCodes {
+		if p == postalCode {
+			return true
+		}
+	}
+	return false
+}
+
+// BillableWeight is the greater of a package's actual and dimensional
+// weight in LB, rounded up to a whole pound; divisor is in cubic inches
+// per LB.
+func BillableWeight(p rate.Package, divisor float64) float64 {
+	weight := p.Weight.Value
+	if strings.EqualFold(p.Weight.Units, "KG") {
+		weight *= 2.20462
+	}
+	d := p.Dimensions
+	if d.Length > 0 && d.Width > 0 && d.Height > 0 && divisor > 0 {
+		cubic := float64(d.Length) * float64(d.Width) * float64(d.Height)
+		if strings.EqualFold(d.Units, "CM") {
+			cubic /= 2.54 * 2.54 * 2.54
+		}
+		if dim := math.Ceil(cubic / divisor); dim > weight {
+			weight = dim
+		}
+	}
+	return math.Ceil(weight)
+}
+
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,85 @@
+package estimate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tirpitz0509/go-fedex/rate"
+)
+
+const (
+	testZones = "origin,destination,zone\n380,900-961,8\n380,100-149,5\n"
+	testRates = "Weight,Zone 5,Zone 8\n1,10.00,15.00\n5,12.00,20.00\n10,16.00,28.00\n"
+)
+
+func testEstimator(t *testing.T) *Estimator {
+	zones, err := ReadZoneChart(strings.NewReader(testZones))
+	if err != nil {
+		t.Fatal(err)
+	}
+	table, err := ReadRateTable(rate.FedexGround, strings.NewReader(testRates))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return New(zones, Surcharges{
+		Residential:             map[string]float64{"default": 5},
+		DeliveryArea:            map[string]float64{"default": 3},
+		DeliveryAreaPostalCodes: []string{"90210"},
+	}, table)
+}
+
+func TestEstimatePerPackageSurcharges(t *testing.T) {
+	tests := []struct {
+		name           string
+		postal         string
+		residential    bool
+		packages       []rate.Package
+		base           float64
+		residentialFee float64
+		deliveryArea   float64
+	}{
+		{"one package", "90210", true, []rate.Package{rate.NewPackage(5, "LB")}, 20, 5, 3},
+		{"two packages", "90210", true, []rate.Package{rate.NewPackage(5, "LB"), rate.NewPackage(1, "LB")}, 35, 10, 6},
+		{"group of three", "90210", true, []rate.Package{rate.NewPackage(5, "LB").WithCount(3)}, 60, 15, 9},
+		{"group and single", "90210", false, []rate.Package{rate.NewPackage(5, "LB").WithCount(2), rate.NewPackage(10, "LB")}, 68, 0, 9},
+		{"outside delivery area", "10001", true, []rate.Package{rate.NewPackage(5, "LB").WithCount(2)}, 24, 10, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, _ := rate.NewShipment("123456789").
+				From(rate.Address{CountryCode: "US", PostalCode: "38017"}).
+				To(rate.Address{CountryCode: "US", PostalCode: tt.postal, Residential: tt.residential}).
+				Service(rate.FedexGround).
+				AddPackage(tt.packages...).
+				Build()
+			quotes, err := testEstimator(t).Estimate(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			q := quotes[0]
+			if q.BaseCharge != tt.base {
+				t.Errorf("BaseCharge = %v, want %v", q.BaseCharge, tt.base)
+			}
+			got := map[string]float64{}
+			for _, s := range q.Surcharges {
+				got[s.Type] = s.Amount
+			}
+			if got[SurchargeResidential] != tt.residentialFee || got[SurchargeDeliveryArea] != tt.deliveryArea {
+				t.Errorf("residential, delivery area = %v, %v, want %v, %v",
+					got[SurchargeResidential], got[SurchargeDeliveryArea], tt.residentialFee, tt.deliveryArea)
+			}
+		})
+	}
+}
+
+func TestZoneChartIgnoresService(t *testing.T) {
+	zones, err := ReadZoneChart(strings.NewReader(testZones))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, serviceType := range []rate.ServiceType{rate.FedexGround, rate.PriorityOvernight} {
+		if zone, err := zones.Zone(serviceType, "38017", "90210"); err != nil || zone != "8" {
+			t.Errorf("Zone(%s) = %q, %v, want 8", serviceType, zone, err)
+		}
+	}
+}
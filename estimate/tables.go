@@ -0,0 +1,244 @@
+package estimate
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tirpitz0509/go-fedex/rate"
+	"gopkg.in/yaml.v3"
+)
+
+// RateTable is a published rate chart for one service: the charge for each
+// weight break, in LB, by zone.
+type RateTable struct {
+	Service rate.ServiceType //
+
+	weights []float64            // ascending
+	charges map[string][]float64 // zone -> charge per weight break
+}
+
+// LoadRateTable reads a chart laid out as FedEx publishes them: a header of
+// zones ("Zone 2", "Zone 3", ... or just "2", "3", ...) and a row per weight
+// break whose first cell is the weight in LB. Rows not starting with a
+// number, such as envelope rates, are skipped.
+func LoadRateTable(service rate.ServiceType, path string) (*RateTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	t, err := ReadRateTable(service, f)
+	if err != nil {
+		return nil, errors.New(path + ": " + err.Error())
+	}
+	return t, nil
+}
+
+func ReadRateTable(service rate.ServiceType, r io.Reader) (*RateTable, error) {
+	c := csv.NewReader(r)
+	c.FieldsPerRecord = -1
+	records, err := c.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) < 2 {
+		return nil, errors.New("estimate: rate table has no rows")
+	}
+	header := records[0]
+	zones := make([]string, len(header))
+	for i := 1; i < len(header); i++ {
+		zones[i] = normalizeZone(header[i])
+	}
+
+	t := &RateTable{Service: service, charges: map[string][]float64{}}
+	for n, record := range records[1:] {
+		weight, ok := leadingNumber(record[0])
+		if !ok {
+			continue
+		}
+		if len(t.weights) > 0 && weight <= t.weights[len(t.weights)-1] {
+			return nil, errors.New("estimate: line " + strconv.Itoa(n+2) + ": weights must increase")
+		}
+		t.weights = append(t.weights, weight)
+		for i := 1; i < len(header); i++ {
+			if zones[i] == "" {
+				continue
+			}
+			charge := -1.0 // no rate for this zone and weight
+			if i < len(record) {
+				if v, ok := parseAmount(record[i]); ok {
+					charge = v
+				}
+			}
+			t.charges[zones[i]] = append(t.charges[zones[i]], charge)
+		}
+	}
+	if len(t.weights) == 0 {
+		return nil, errors.New("estimate: rate table has no weight rows")
+	}
+	return t, nil
+}
+
+// Charge returns the rate of the first weight break at or above weight.
+func (t *RateTable) Charge(zone string, weight float64) (float64, error) {
+	charges, ok := t.charges[normalizeZone(zone)]
+	if !ok {
+		return 0, errors.New("estimate: " + string(t.Service) + " has no rates for zone " + zone)
+	}
+	i := sort.SearchFloat64s(t.weights, weight)
+	if i == len(t.weights) {
+		return 0, errors.New("estimate: " + strconv.FormatFloat(weight, 'f', -1, 64) + " LB is beyond the " + string(t.Service) + " rate table")
+	}
+	if charges[i] < 0 {
+		return 0, errors.New("estimate: " + string(t.Service) + " has no rate for zone " + zone + " at " + strconv.FormatFloat(t.weights[i], 'f', -1, 64) + " LB")
+	}
+	return charges[i], nil
+}
+
+// ZoneChart gives the zone between an origin and a destination postal
+// prefix, the same for every service: Zone ignores serviceType. FedEx
+// publishes different zones for its ground and express networks on some
+// lanes; use a zone.Index, which keeps both, where that matters.
+type ZoneChart struct {
+	digits  int
+	origins map[string][]zoneRange
+}
+
+type zoneRange struct {
+	from string //
+	to   string // inclusive
+	zone string //
+}
+
+// LoadZoneChart reads CSV rows of origin prefix, destination prefix or
+// range (e.g. 900-961) and zone, with an optional header. All prefixes have
+// the same number of digits, usually three.
+func LoadZoneChart(path string) (*ZoneChart, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	c, err := ReadZoneChart(f)
+	if err != nil {
+		return nil, errors.New(path + ": " + err.Error())
+	}
+	return c, nil
+}
+
+func ReadZoneChart(r io.Reader) (*ZoneChart, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	c := &ZoneChart{origins: map[string][]zoneRange{}}
+	for n, record := range records {
+		origin := strings.TrimSpace(record[0])
+		if _, err := strconv.Atoi(origin); err != nil {
+			if n == 0 {
+				continue // header
+			}
+			return nil, errors.New("estimate: line " + strconv.Itoa(n+1) + ": bad origin " + strconv.Quote(origin))
+		}
+		if len(record) < 3 {
+			return nil, errors.New("estimate: line " + strconv.Itoa(n+1) + ": want origin, destination, zone")
+		}
+		if c.digits == 0 {
+			c.digits = len(origin)
+		}
+		from, to := splitRange(record[1])
+		if len(origin) != c.digits || len(from) != c.digits || len(to) != c.digits {
+			return nil, errors.New("estimate: line " + strconv.Itoa(n+1) + ": prefixes must have " + strconv.Itoa(c.digits) + " digits")
+		}
+		c.origins[origin] = append(c.origins[origin], zoneRange{from: from, to: to, zone: normalizeZone(record[2])})
+	}
+	return c, nil
+}
+
+func (c *ZoneChart) Zone(serviceType rate.ServiceType, origin string, destination string) (string, error) {
+	if len(origin) < c.digits || len(destination) < c.digits {
+		return "", errors.New("estimate: postal codes must have at least " + strconv.Itoa(c.digits) + " digits")
+	}
+	dest := destination[:c.digits]
+	for _, r := range c.origins[origin[:c.digits]] {
+		if dest >= r.from && dest <= r.to {
+			return r.zone, nil
+		}
+	}
+	return "", errors.New("estimate: no zone from " + origin + " to " + destination)
+}
+
+// Surcharges are the surcharges and factors applied on top of the table
+// rates. Maps are keyed by service type, with "default" for the rest; a
+// listed service with 0 gets none.
+type Surcharges struct {
+	Currency                string             `json:"currency" yaml:"currency"`                               // USD if empty
+	DimDivisor              float64            `json:"dimDivisor" yaml:"dimDivisor"`                           // cubic inches per LB, 139 if 0
+	Fuel                    map[string]float64 `json:"fuel" yaml:"fuel"`                                       // percent of the other charges
+	Residential             map[string]float64 `json:"residential" yaml:"residential"`                         // per package
+	DeliveryArea            map[string]float64 `json:"deliveryArea" yaml:"deliveryArea"`                       // per package
+	DeliveryAreaPostalCodes []string           `json:"deliveryAreaPostalCodes" yaml:"deliveryAreaPostalCodes"` // 5-digit ZIP codes
+}
+
+// LoadSurcharges reads Surcharges from a YAML or JSON file.
+func LoadSurcharges(path string) (Surcharges, error) {
+	var s Surcharges
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return s, err
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(content, &s)
+	} else {
+		err = yaml.Unmarshal(content, &s)
+	}
+	if err != nil {
+		return s, errors.New("estimate: " + path + ": " + err.Error())
+	}
+	return s, nil
+}
+
+func forService(values map[string]float64, serviceType rate.ServiceType) float64 {
+	if v, ok := values[string(serviceType)]; ok {
+		return v
+	}
+	return values["default"]
+}
+
+func normalizeZone(zone string) string {
+	zone = strings.ToUpper(strings.TrimSpace(zone))
+	zone = strings.TrimSpace(strings.TrimPrefix(zone, "ZONE"))
+	return strings.TrimLeft(zone, "0")
+}
+
+func splitRange(s string) (string, string) {
+	s = strings.TrimSpace(s)
+	if i := strings.Index(s, "-"); i > 0 {
+		return strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:])
+	}
+	return s, s
+}
+
+func leadingNumber(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	end := 0
+	for end < len(s) && (s[end] >= '0' && s[end] <= '9' || s[end] == '.') {
+		end++
+	}
+	v, err := strconv.ParseFloat(s[:end], 64)
+	return v, err == nil
+}
+
+func parseAmount(s string) (float64, bool) {
+	s = strings.NewReplacer("$", "", ",", "", " ", "").Replace(s)
+	v, err := strconv.ParseFloat(s, 64)
+	return v, err == nil
+}
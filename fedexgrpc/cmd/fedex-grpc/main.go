@@ -0,0 +1,65 @@
+// Command fedex-grpc serves fedex.v1.RatingService, fedex.v1.TrackingService
+// and the standard gRPC health service. Settings come from package config.
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/tirpitz0509/go-fedex/config"
+	"github.com/tirpitz0509/go-fedex/fedexgrpc"
+	"github.com/tirpitz0509/go-fedex/tenant"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func main() {
+	listen := flag.String("listen", ":9090", "address to serve on")
+	configFile := flag.String("config", "", "YAML or JSON config file (default $FEDEX_CONFIG)")
+	rateLimit := flag.Float64("rate-limit", 10, "FedEx requests per second, unlimited if 0")
+	concurrency := flag.Int("concurrency", 4, "shipments of a batch rated at once")
+	flag.Parse()
+
+	var opts []config.Option
+	if *configFile != "" {
+		opts = append(opts, config.WithFile(*configFile))
+	}
+	c, err := config.Load(opts...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := c.Validate(config.REST); err != nil {
+		log.Fatal(err)
+	}
+	registry := tenant.NewRegistry(nil)
+	registry.RateLimit = *rateLimit
+	registry.Client = c.Client
+
+	s := fedexgrpc.NewServer(registry.Register("grpc", c.Credentials()))
+	s.Concurrency = *concurrency
+
+	g := grpc.NewServer()
+	s.Register(g)
+	grpc_health_v1.RegisterHealthServer(g, health.NewServer())
+
+	listener, err := net.Listen("tcp", *listen)
+	if err != nil {
+		log.Fatal(err)
+	}
+	go func() {
+		stop := make(chan os.Signal, 1)
+		signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+		<-stop
+		g.GracefulStop()
+	}()
+
+	log.Println("fedex-grpc listening on", *listen)
+	if err := g.Serve(listener); err != nil {
+		log.Fatal(err)
+	}
+}
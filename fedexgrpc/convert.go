@@ -0,0 +1,161 @@
+package fedexgrpc
+
+import (
+	"strings"
+
+	"github.com/tirpitz0509/go-fedex/fedexgrpc/fedexpb"
+	"github.com/tirpitz0509/go-fedex/rate"
+	"github.com/tirpitz0509/go-fedex/track"
+)
+
+// toRateRequest builds and validates the library request for r.
+func toRateRequest(r *fedexpb.RateRequest, accountNumber string) (rate.RateRequest, error) {
+	b := rate.NewShipment(accountNumber).
+		From(toAddress(r.GetShipper())).
+		To(toAddress(r.GetRecipient()))
+	if r.GetServiceType() != "" {
+		b.Service(rate.ServiceType(strings.ToUpper(r.GetServiceType())))
+	}
+	packaging := r.GetPackagingType()
+	if packaging == "" {
+		packaging = string(rate.YourPackaging)
+	}
+	b.Packaging(rate.PackagingType(strings.ToUpper(packaging)))
+	if r.GetPickupType() != "" {
+		b.Pickup(rate.PickupType(strings.ToUpper(r.GetPickupType())))
+	}
+	for _, t := range r.GetRateRequestTypes() {
+		b.RateTypes(rate.RateRequestType(strings.ToUpper(t)))
+	}
+	if r.GetShipDate() != "" {
+		b.ShipDate(r.GetShipDate())
+	}
+	if r.GetPreferredCurrency() != "" {
+		b.PreferredCurrency(strings.ToUpper(r.GetPreferredCurrency()))
+	}
+	if r.GetReturnTransitTimes() {
+		b.ReturnTransitTimes()
+	}
+	for _, p := range r.GetPackages() {
+		pkg := rate.NewPackage(p.GetWeight().GetValue(), strings.ToUpper(p.GetWeight().GetUnits()))
+		if d := p.GetDimensions(); d != nil {
+			pkg = pkg.WithDimensions(int(d.GetLength()), int(d.GetWidth()), int(d.GetHeight()), strings.ToUpper(d.GetUnits()))
+		}
+		if v := p.GetDeclaredValue(); v != nil {
+			pkg = pkg.WithDeclaredValue(v.GetAmount(), strings.ToUpper(v.GetCurrency()))
+		}
+		if p.GetGroupPackageCount() > 1 {
+			pkg = pkg.WithCount(int(p.GetGroupPackageCount()))
+		}
+		b.AddPackage(pkg)
+	}
+	return b.Build()
+}
+
+func toAddress(a *fedexpb.Address) rate.Address {
+	return rate.Address{
+		StreetLines:         a.GetStreetLines(),
+		City:                a.GetCity(),
+		StateOrProvinceCode: strings.ToUpper(a.GetStateOrProvinceCode()),
+		PostalCode:          a.GetPostalCode(),
+		CountryCode:         strings.ToUpper(a.GetCountryCode()),
+		Residential:         a.GetResidential(),
+	}
+}
+
+func fromRateResponse(response rate.RateResponse) *fedexpb.RateResponse {
+	r := &fedexpb.RateResponse{
+		TransactionId:         response.TransactionID,
+		CustomerTransactionId: response.CustomerTransactionID,
+	}
+	for _, q := range response.Quotes() {
+		r.Quotes = append(r.Quotes, fromQuote(q))
+	}
+	for _, a := range response.Output.Alerts {
+		r.Alerts = append(r.Alerts, &fedexpb.Alert{Code: a.Code, Message: a.Message, AlertType: a.AlertType})
+	}
+	return r
+}
+
+func fromQuote(q rate.Quote) *fedexpb.Quote {
+	quote := &fedexpb.Quote{
+		ServiceType:     string(q.ServiceType),
+		ServiceName:     q.ServiceName,
+		PackagingType:   q.PackagingType,
+		RateType:        string(q.RateType),
+		Currency:        q.Currency,
+		BaseCharge:      q.BaseCharge,
+		TotalSurcharges: q.TotalSurcharges,
+		Discounts:       q.Discounts,
+		Taxes:           q.Taxes,
+		DutiesAndTaxes:  q.DutiesAndTaxes,
+		NetCharge:       q.NetCharge,
+		BillingWeight:   &fedexpb.Weight{Units: q.BillingWeight.Units, Value: q.BillingWeight.Value},
+		RateZone:        q.RateZone,
+		Transit: &fedexpb.Transit{
+			TransitTime: q.TransitTime,
+			TransitDays: int32(q.TransitDays),
+			CommitDate:  q.CommitDate,
+			DeliveryDay: q.DeliveryDay,
+		},
+		Source: q.Source,
+	}
+	for _, s := range q.Surcharges {
+		quote.Surcharges = append(quote.Surcharges, &fedexpb.Charge{Type: s.Type, Description: s.Description, Amount: s.Amount})
+	}
+	return quote
+}
+
+func fromTrackResponse(response track.TrackResponse) *fedexpb.TrackResponse {
+	r := &fedexpb.TrackResponse{
+		TransactionId:         response.TransactionID,
+		CustomerTransactionId: response.CustomerTransactionID,
+	}
+	for _, result := range response.Results() {
+		r.Results = append(r.Results, fromTrackResult(result))
+	}
+	return r
+}
+
+func fromTrackResult(t track.TrackResult) *fedexpb.TrackResult {
+	result := &fedexpb.TrackResult{
+		TrackingNumber: t.TrackingNumberInfo.TrackingNumber,
+		CarrierCode:    t.TrackingNumberInfo.CarrierCode,
+	}
+	if t.Error != nil {
+		result.Error = &fedexpb.Error{Code: int32(fedexStatus(t.Error.Code)), Message: t.Error.Message, FedexCode: t.Error.Code}
+		return result
+	}
+	result.StatusCode = t.LatestStatusDetail.Code
+	result.Status = t.LatestStatusDetail.Description
+	result.Location = fromLocation(t.LatestStatusDetail.ScanLocation)
+	result.ServiceType = t.ServiceDetail.Type
+	result.ActualPickup = t.DateAndTime("ACTUAL_PICKUP")
+	result.EstimatedDelivery = t.DateAndTime("ESTIMATED_DELIVERY")
+	result.EstimatedDeliveryBegins = t.EstimatedDeliveryTimeWindow.Window.Begins
+	result.EstimatedDeliveryEnds = t.EstimatedDeliveryTimeWindow.Window.Ends
+	result.ActualDelivery = t.DateAndTime("ACTUAL_DELIVERY")
+	for _, e := range t.ScanEvents {
+		result.ScanEvents = append(result.ScanEvents, &fedexpb.ScanEvent{
+			Date:                 e.Date,
+			EventType:            e.EventType,
+			Description:          e.EventDescription,
+			ExceptionCode:        e.ExceptionCode,
+			ExceptionDescription: e.ExceptionDescription,
+			Location:             fromLocation(e.ScanLocation),
+			DerivedStatusCode:    e.DerivedStatusCode,
+		})
+	}
+	return result
+}
+
+func fromLocation(l track.Location) *fedexpb.Address {
+	return &fedexpb.Address{
+		StreetLines:         l.StreetLines,
+		City:                l.City,
+		StateOrProvinceCode: l.StateOrProvinceCode,
+		PostalCode:          l.PostalCode,
+		CountryCode:         l.CountryCode,
+		Residential:         l.Residential,
+	}
+}
@@ -0,0 +1,1254 @@
+// Rating over gRPC. The messages mirror package rate: requests follow
+// rate.RateRequest and quotes follow rate.Quote. Enumerations such as
+// service and packaging types are the FedEx strings, e.g. FEDEX_GROUND,
+// so new FedEx values need no new release.
+// Tracking is the separate TrackingService in tracking.proto.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: fedexpb/rating.proto
+
+package fedexpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Address struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	StreetLines         []string               `protobuf:"bytes,1,rep,name=street_lines,json=streetLines,proto3" json:"street_lines,omitempty"`
+	City                string                 `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`
+	StateOrProvinceCode string                 `protobuf:"bytes,3,opt,name=state_or_province_code,json=stateOrProvinceCode,proto3" json:"state_or_province_code,omitempty"`
+	PostalCode          string                 `protobuf:"bytes,4,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	CountryCode         string                 `protobuf:"bytes,5,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	Residential         bool                   `protobuf:"varint,6,opt,name=residential,proto3" json:"residential,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_fedexpb_rating_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_fedexpb_rating_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_fedexpb_rating_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Address) GetStreetLines() []string {
+	if x != nil {
+		return x.StreetLines
+	}
+	return nil
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetStateOrProvinceCode() string {
+	if x != nil {
+		return x.StateOrProvinceCode
+	}
+	return ""
+}
+
+func (x *Address) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *Address) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *Address) GetResidential() bool {
+	if x != nil {
+		return x.Residential
+	}
+	return false
+}
+
+type Weight struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Units         string                 `protobuf:"bytes,1,opt,name=units,proto3" json:"units,omitempty"` // LB or KG
+	Value         float64                `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Weight) Reset() {
+	*x = Weight{}
+	mi := &file_fedexpb_rating_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Weight) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Weight) ProtoMessage() {}
+
+func (x *Weight) ProtoReflect() protoreflect.Message {
+	mi := &file_fedexpb_rating_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Weight.ProtoReflect.Descriptor instead.
+func (*Weight) Descriptor() ([]byte, []int) {
+	return file_fedexpb_rating_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Weight) GetUnits() string {
+	if x != nil {
+		return x.Units
+	}
+	return ""
+}
+
+func (x *Weight) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type Dimensions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Length        int32                  `protobuf:"varint,1,opt,name=length,proto3" json:"length,omitempty"`
+	Width         int32                  `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Units         string                 `protobuf:"bytes,4,opt,name=units,proto3" json:"units,omitempty"` // IN or CM
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Dimensions) Reset() {
+	*x = Dimensions{}
+	mi := &file_fedexpb_rating_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Dimensions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dimensions) ProtoMessage() {}
+
+func (x *Dimensions) ProtoReflect() protoreflect.Message {
+	mi := &file_fedexpb_rating_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dimensions.ProtoReflect.Descriptor instead.
+func (*Dimensions) Descriptor() ([]byte, []int) {
+	return file_fedexpb_rating_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Dimensions) GetLength() int32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *Dimensions) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Dimensions) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Dimensions) GetUnits() string {
+	if x != nil {
+		return x.Units
+	}
+	return ""
+}
+
+type Money struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        float64                `protobuf:"fixed64,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_fedexpb_rating_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_fedexpb_rating_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_fedexpb_rating_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Money) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type Package struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Weight            *Weight                `protobuf:"bytes,1,opt,name=weight,proto3" json:"weight,omitempty"`
+	Dimensions        *Dimensions            `protobuf:"bytes,2,opt,name=dimensions,proto3" json:"dimensions,omitempty"`
+	GroupPackageCount int32                  `protobuf:"varint,3,opt,name=group_package_count,json=groupPackageCount,proto3" json:"group_package_count,omitempty"` // identical packages, 1 if 0
+	DeclaredValue     *Money                 `protobuf:"bytes,4,opt,name=declared_value,json=declaredValue,proto3" json:"declared_value,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Package) Reset() {
+	*x = Package{}
+	mi := &file_fedexpb_rating_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Package) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Package) ProtoMessage() {}
+
+func (x *Package) ProtoReflect() protoreflect.Message {
+	mi := &file_fedexpb_rating_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Package.ProtoReflect.Descriptor instead.
+func (*Package) Descriptor() ([]byte, []int) {
+	return file_fedexpb_rating_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Package) GetWeight() *Weight {
+	if x != nil {
+		return x.Weight
+	}
+	return nil
+}
+
+func (x *Package) GetDimensions() *Dimensions {
+	if x != nil {
+		return x.Dimensions
+	}
+	return nil
+}
+
+func (x *Package) GetGroupPackageCount() int32 {
+	if x != nil {
+		return x.GroupPackageCount
+	}
+	return 0
+}
+
+func (x *Package) GetDeclaredValue() *Money {
+	if x != nil {
+		return x.DeclaredValue
+	}
+	return nil
+}
+
+type RateRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Shipper            *Address               `protobuf:"bytes,1,opt,name=shipper,proto3" json:"shipper,omitempty"`
+	Recipient          *Address               `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Packages           []*Package             `protobuf:"bytes,3,rep,name=packages,proto3" json:"packages,omitempty"`
+	ServiceType        string                 `protobuf:"bytes,4,opt,name=service_type,json=serviceType,proto3" json:"service_type,omitempty"`                  // all services if empty
+	PackagingType      string                 `protobuf:"bytes,5,opt,name=packaging_type,json=packagingType,proto3" json:"packaging_type,omitempty"`            // YOUR_PACKAGING if empty
+	PickupType         string                 `protobuf:"bytes,6,opt,name=pickup_type,json=pickupType,proto3" json:"pickup_type,omitempty"`                     // DROPOFF_AT_FEDEX_LOCATION if empty
+	RateRequestTypes   []string               `protobuf:"bytes,7,rep,name=rate_request_types,json=rateRequestTypes,proto3" json:"rate_request_types,omitempty"` // e.g. LIST, ACCOUNT
+	ShipDate           string                 `protobuf:"bytes,8,opt,name=ship_date,json=shipDate,proto3" json:"ship_date,omitempty"`                           // YYYY-MM-DD
+	PreferredCurrency  string                 `protobuf:"bytes,9,opt,name=preferred_currency,json=preferredCurrency,proto3" json:"preferred_currency,omitempty"`
+	ReturnTransitTimes bool                   `protobuf:"varint,10,opt,name=return_transit_times,json=returnTransitTimes,proto3" json:"return_transit_times,omitempty"`
+	// Sent to FedEx as x-customer-transaction-id; the
+	// x-customer-transaction-id metadata or a new ID if empty.
+	CustomerTransactionId string `protobuf:"bytes,11,opt,name=customer_transaction_id,json=customerTransactionId,proto3" json:"customer_transaction_id,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *RateRequest) Reset() {
+	*x = RateRequest{}
+	mi := &file_fedexpb_rating_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateRequest) ProtoMessage() {}
+
+func (x *RateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fedexpb_rating_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateRequest.ProtoReflect.Descriptor instead.
+func (*RateRequest) Descriptor() ([]byte, []int) {
+	return file_fedexpb_rating_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RateRequest) GetShipper() *Address {
+	if x != nil {
+		return x.Shipper
+	}
+	return nil
+}
+
+func (x *RateRequest) GetRecipient() *Address {
+	if x != nil {
+		return x.Recipient
+	}
+	return nil
+}
+
+func (x *RateRequest) GetPackages() []*Package {
+	if x != nil {
+		return x.Packages
+	}
+	return nil
+}
+
+func (x *RateRequest) GetServiceType() string {
+	if x != nil {
+		return x.ServiceType
+	}
+	return ""
+}
+
+func (x *RateRequest) GetPackagingType() string {
+	if x != nil {
+		return x.PackagingType
+	}
+	return ""
+}
+
+func (x *RateRequest) GetPickupType() string {
+	if x != nil {
+		return x.PickupType
+	}
+	return ""
+}
+
+func (x *RateRequest) GetRateRequestTypes() []string {
+	if x != nil {
+		return x.RateRequestTypes
+	}
+	return nil
+}
+
+func (x *RateRequest) GetShipDate() string {
+	if x != nil {
+		return x.ShipDate
+	}
+	return ""
+}
+
+func (x *RateRequest) GetPreferredCurrency() string {
+	if x != nil {
+		return x.PreferredCurrency
+	}
+	return ""
+}
+
+func (x *RateRequest) GetReturnTransitTimes() bool {
+	if x != nil {
+		return x.ReturnTransitTimes
+	}
+	return false
+}
+
+func (x *RateRequest) GetCustomerTransactionId() string {
+	if x != nil {
+		return x.CustomerTransactionId
+	}
+	return ""
+}
+
+type Charge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Charge) Reset() {
+	*x = Charge{}
+	mi := &file_fedexpb_rating_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Charge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Charge) ProtoMessage() {}
+
+func (x *Charge) ProtoReflect() protoreflect.Message {
+	mi := &file_fedexpb_rating_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Charge.ProtoReflect.Descriptor instead.
+func (*Charge) Descriptor() ([]byte, []int) {
+	return file_fedexpb_rating_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Charge) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Charge) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Charge) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type Transit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransitTime   string                 `protobuf:"bytes,1,opt,name=transit_time,json=transitTime,proto3" json:"transit_time,omitempty"` // e.g. THREE_DAYS
+	TransitDays   int32                  `protobuf:"varint,2,opt,name=transit_days,json=transitDays,proto3" json:"transit_days,omitempty"`
+	CommitDate    string                 `protobuf:"bytes,3,opt,name=commit_date,json=commitDate,proto3" json:"commit_date,omitempty"`
+	DeliveryDay   string                 `protobuf:"bytes,4,opt,name=delivery_day,json=deliveryDay,proto3" json:"delivery_day,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transit) Reset() {
+	*x = Transit{}
+	mi := &file_fedexpb_rating_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transit) ProtoMessage() {}
+
+func (x *Transit) ProtoReflect() protoreflect.Message {
+	mi := &file_fedexpb_rating_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transit.ProtoReflect.Descriptor instead.
+func (*Transit) Descriptor() ([]byte, []int) {
+	return file_fedexpb_rating_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Transit) GetTransitTime() string {
+	if x != nil {
+		return x.TransitTime
+	}
+	return ""
+}
+
+func (x *Transit) GetTransitDays() int32 {
+	if x != nil {
+		return x.TransitDays
+	}
+	return 0
+}
+
+func (x *Transit) GetCommitDate() string {
+	if x != nil {
+		return x.CommitDate
+	}
+	return ""
+}
+
+func (x *Transit) GetDeliveryDay() string {
+	if x != nil {
+		return x.DeliveryDay
+	}
+	return ""
+}
+
+type Quote struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ServiceType     string                 `protobuf:"bytes,1,opt,name=service_type,json=serviceType,proto3" json:"service_type,omitempty"`
+	ServiceName     string                 `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	PackagingType   string                 `protobuf:"bytes,3,opt,name=packaging_type,json=packagingType,proto3" json:"packaging_type,omitempty"`
+	RateType        string                 `protobuf:"bytes,4,opt,name=rate_type,json=rateType,proto3" json:"rate_type,omitempty"`
+	Currency        string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	BaseCharge      float64                `protobuf:"fixed64,6,opt,name=base_charge,json=baseCharge,proto3" json:"base_charge,omitempty"`
+	Surcharges      []*Charge              `protobuf:"bytes,7,rep,name=surcharges,proto3" json:"surcharges,omitempty"`
+	TotalSurcharges float64                `protobuf:"fixed64,8,opt,name=total_surcharges,json=totalSurcharges,proto3" json:"total_surcharges,omitempty"`
+	Discounts       float64                `protobuf:"fixed64,9,opt,name=discounts,proto3" json:"discounts,omitempty"`
+	Taxes           float64                `protobuf:"fixed64,10,opt,name=taxes,proto3" json:"taxes,omitempty"`
+	DutiesAndTaxes  float64                `protobuf:"fixed64,11,opt,name=duties_and_taxes,json=dutiesAndTaxes,proto3" json:"duties_and_taxes,omitempty"`
+	NetCharge       float64                `protobuf:"fixed64,12,opt,name=net_charge,json=netCharge,proto3" json:"net_charge,omitempty"`
+	BillingWeight   *Weight                `protobuf:"bytes,13,opt,name=billing_weight,json=billingWeight,proto3" json:"billing_weight,omitempty"`
+	RateZone        string                 `protobuf:"bytes,14,opt,name=rate_zone,json=rateZone,proto3" json:"rate_zone,omitempty"`
+	Transit         *Transit               `protobuf:"bytes,15,opt,name=transit,proto3" json:"transit,omitempty"`
+	Source          string                 `protobuf:"bytes,16,opt,name=source,proto3" json:"source,omitempty"` // REST or SOAP
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Quote) Reset() {
+	*x = Quote{}
+	mi := &file_fedexpb_rating_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Quote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quote) ProtoMessage() {}
+
+func (x *Quote) ProtoReflect() protoreflect.Message {
+	mi := &file_fedexpb_rating_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quote.ProtoReflect.Descriptor instead.
+func (*Quote) Descriptor() ([]byte, []int) {
+	return file_fedexpb_rating_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Quote) GetServiceType() string {
+	if x != nil {
+		return x.ServiceType
+	}
+	return ""
+}
+
+func (x *Quote) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *Quote) GetPackagingType() string {
+	if x != nil {
+		return x.PackagingType
+	}
+	return ""
+}
+
+func (x *Quote) GetRateType() string {
+	if x != nil {
+		return x.RateType
+	}
+	return ""
+}
+
+func (x *Quote) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Quote) GetBaseCharge() float64 {
+	if x != nil {
+		return x.BaseCharge
+	}
+	return 0
+}
+
+func (x *Quote) GetSurcharges() []*Charge {
+	if x != nil {
+		return x.Surcharges
+	}
+	return nil
+}
+
+func (x *Quote) GetTotalSurcharges() float64 {
+	if x != nil {
+		return x.TotalSurcharges
+	}
+	return 0
+}
+
+func (x *Quote) GetDiscounts() float64 {
+	if x != nil {
+		return x.Discounts
+	}
+	return 0
+}
+
+func (x *Quote) GetTaxes() float64 {
+	if x != nil {
+		return x.Taxes
+	}
+	return 0
+}
+
+func (x *Quote) GetDutiesAndTaxes() float64 {
+	if x != nil {
+		return x.DutiesAndTaxes
+	}
+	return 0
+}
+
+func (x *Quote) GetNetCharge() float64 {
+	if x != nil {
+		return x.NetCharge
+	}
+	return 0
+}
+
+func (x *Quote) GetBillingWeight() *Weight {
+	if x != nil {
+		return x.BillingWeight
+	}
+	return nil
+}
+
+func (x *Quote) GetRateZone() string {
+	if x != nil {
+		return x.RateZone
+	}
+	return ""
+}
+
+func (x *Quote) GetTransit() *Transit {
+	if x != nil {
+		return x.Transit
+	}
+	return nil
+}
+
+func (x *Quote) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type Alert struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	AlertType     string                 `protobuf:"bytes,3,opt,name=alert_type,json=alertType,proto3" json:"alert_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Alert) Reset() {
+	*x = Alert{}
+	mi := &file_fedexpb_rating_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Alert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alert) ProtoMessage() {}
+
+func (x *Alert) ProtoReflect() protoreflect.Message {
+	mi := &file_fedexpb_rating_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alert.ProtoReflect.Descriptor instead.
+func (*Alert) Descriptor() ([]byte, []int) {
+	return file_fedexpb_rating_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Alert) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Alert) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Alert) GetAlertType() string {
+	if x != nil {
+		return x.AlertType
+	}
+	return ""
+}
+
+type RateResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Quotes                []*Quote               `protobuf:"bytes,1,rep,name=quotes,proto3" json:"quotes,omitempty"`
+	Alerts                []*Alert               `protobuf:"bytes,2,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	TransactionId         string                 `protobuf:"bytes,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // assigned by FedEx
+	CustomerTransactionId string                 `protobuf:"bytes,4,opt,name=customer_transaction_id,json=customerTransactionId,proto3" json:"customer_transaction_id,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *RateResponse) Reset() {
+	*x = RateResponse{}
+	mi := &file_fedexpb_rating_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateResponse) ProtoMessage() {}
+
+func (x *RateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fedexpb_rating_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateResponse.ProtoReflect.Descriptor instead.
+func (*RateResponse) Descriptor() ([]byte, []int) {
+	return file_fedexpb_rating_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RateResponse) GetQuotes() []*Quote {
+	if x != nil {
+		return x.Quotes
+	}
+	return nil
+}
+
+func (x *RateResponse) GetAlerts() []*Alert {
+	if x != nil {
+		return x.Alerts
+	}
+	return nil
+}
+
+func (x *RateResponse) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *RateResponse) GetCustomerTransactionId() string {
+	if x != nil {
+		return x.CustomerTransactionId
+	}
+	return ""
+}
+
+type BatchRateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // echoed in the reply
+	Request       *RateRequest           `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchRateRequest) Reset() {
+	*x = BatchRateRequest{}
+	mi := &file_fedexpb_rating_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchRateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRateRequest) ProtoMessage() {}
+
+func (x *BatchRateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fedexpb_rating_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRateRequest.ProtoReflect.Descriptor instead.
+func (*BatchRateRequest) Descriptor() ([]byte, []int) {
+	return file_fedexpb_rating_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BatchRateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BatchRateRequest) GetRequest() *RateRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+type BatchRateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Response      *RateResponse          `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"` // set instead of response when rating failed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchRateResponse) Reset() {
+	*x = BatchRateResponse{}
+	mi := &file_fedexpb_rating_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchRateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRateResponse) ProtoMessage() {}
+
+func (x *BatchRateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fedexpb_rating_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRateResponse.ProtoReflect.Descriptor instead.
+func (*BatchRateResponse) Descriptor() ([]byte, []int) {
+	return file_fedexpb_rating_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BatchRateResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BatchRateResponse) GetResponse() *RateResponse {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *BatchRateResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// Error is what a failed Rate call reports in its status, for batch items.
+type Error struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"` // google.golang.org/grpc/codes
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	FedexCode     string                 `protobuf:"bytes,3,opt,name=fedex_code,json=fedexCode,proto3" json:"fedex_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_fedexpb_rating_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_fedexpb_rating_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_fedexpb_rating_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Error) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Error) GetFedexCode() string {
+	if x != nil {
+		return x.FedexCode
+	}
+	return ""
+}
+
+var File_fedexpb_rating_proto protoreflect.FileDescriptor
+
+const file_fedexpb_rating_proto_rawDesc = "" +
+	"\n" +
+	"\x14fedexpb/rating.proto\x12\bfedex.v1\"\xdb\x01\n" +
+	"\aAddress\x12!\n" +
+	"\fstreet_lines\x18\x01 \x03(\tR\vstreetLines\x12\x12\n" +
+	"\x04city\x18\x02 \x01(\tR\x04city\x123\n" +
+	"\x16state_or_province_code\x18\x03 \x01(\tR\x13stateOrProvinceCode\x12\x1f\n" +
+	"\vpostal_code\x18\x04 \x01(\tR\n" +
+	"postalCode\x12!\n" +
+	"\fcountry_code\x18\x05 \x01(\tR\vcountryCode\x12 \n" +
+	"\vresidential\x18\x06 \x01(\bR\vresidential\"4\n" +
+	"\x06Weight\x12\x14\n" +
+	"\x05units\x18\x01 \x01(\tR\x05units\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value\"h\n" +
+	"\n" +
+	"Dimensions\x12\x16\n" +
+	"\x06length\x18\x01 \x01(\x05R\x06length\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x05R\x06height\x12\x14\n" +
+	"\x05units\x18\x04 \x01(\tR\x05units\";\n" +
+	"\x05Money\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"\xd1\x01\n" +
+	"\aPackage\x12(\n" +
+	"\x06weight\x18\x01 \x01(\v2\x10.fedex.v1.WeightR\x06weight\x124\n" +
+	"\n" +
+	"dimensions\x18\x02 \x01(\v2\x14.fedex.v1.DimensionsR\n" +
+	"dimensions\x12.\n" +
+	"\x13group_package_count\x18\x03 \x01(\x05R\x11groupPackageCount\x126\n" +
+	"\x0edeclared_value\x18\x04 \x01(\v2\x0f.fedex.v1.MoneyR\rdeclaredValue\"\xe9\x03\n" +
+	"\vRateRequest\x12+\n" +
+	"\ashipper\x18\x01 \x01(\v2\x11.fedex.v1.AddressR\ashipper\x12/\n" +
+	"\trecipient\x18\x02 \x01(\v2\x11.fedex.v1.AddressR\trecipient\x12-\n" +
+	"\bpackages\x18\x03 \x03(\v2\x11.fedex.v1.PackageR\bpackages\x12!\n" +
+	"\fservice_type\x18\x04 \x01(\tR\vserviceType\x12%\n" +
+	"\x0epackaging_type\x18\x05 \x01(\tR\rpackagingType\x12\x1f\n" +
+	"\vpickup_type\x18\x06 \x01(\tR\n" +
+	"pickupType\x12,\n" +
+	"\x12rate_request_types\x18\a \x03(\tR\x10rateRequestTypes\x12\x1b\n" +
+	"\tship_date\x18\b \x01(\tR\bshipDate\x12-\n" +
+	"\x12preferred_currency\x18\t \x01(\tR\x11preferredCurrency\x120\n" +
+	"\x14return_transit_times\x18\n" +
+	" \x01(\bR\x12returnTransitTimes\x126\n" +
+	"\x17customer_transaction_id\x18\v \x01(\tR\x15customerTransactionId\"V\n" +
+	"\x06Charge\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\"\x93\x01\n" +
+	"\aTransit\x12!\n" +
+	"\ftransit_time\x18\x01 \x01(\tR\vtransitTime\x12!\n" +
+	"\ftransit_days\x18\x02 \x01(\x05R\vtransitDays\x12\x1f\n" +
+	"\vcommit_date\x18\x03 \x01(\tR\n" +
+	"commitDate\x12!\n" +
+	"\fdelivery_day\x18\x04 \x01(\tR\vdeliveryDay\"\xc3\x04\n" +
+	"\x05Quote\x12!\n" +
+	"\fservice_type\x18\x01 \x01(\tR\vserviceType\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12%\n" +
+	"\x0epackaging_type\x18\x03 \x01(\tR\rpackagingType\x12\x1b\n" +
+	"\trate_type\x18\x04 \x01(\tR\brateType\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12\x1f\n" +
+	"\vbase_charge\x18\x06 \x01(\x01R\n" +
+	"baseCharge\x120\n" +
+	"\n" +
+	"surcharges\x18\a \x03(\v2\x10.fedex.v1.ChargeR\n" +
+	"surcharges\x12)\n" +
+	"\x10total_surcharges\x18\b \x01(\x01R\x0ftotalSurcharges\x12\x1c\n" +
+	"\tdiscounts\x18\t \x01(\x01R\tdiscounts\x12\x14\n" +
+	"\x05taxes\x18\n" +
+	" \x01(\x01R\x05taxes\x12(\n" +
+	"\x10duties_and_taxes\x18\v \x01(\x01R\x0edutiesAndTaxes\x12\x1d\n" +
+	"\n" +
+	"net_charge\x18\f \x01(\x01R\tnetCharge\x127\n" +
+	"\x0ebilling_weight\x18\r \x01(\v2\x10.fedex.v1.WeightR\rbillingWeight\x12\x1b\n" +
+	"\trate_zone\x18\x0e \x01(\tR\brateZone\x12+\n" +
+	"\atransit\x18\x0f \x01(\v2\x11.fedex.v1.TransitR\atransit\x12\x16\n" +
+	"\x06source\x18\x10 \x01(\tR\x06source\"T\n" +
+	"\x05Alert\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"alert_type\x18\x03 \x01(\tR\talertType\"\xbf\x01\n" +
+	"\fRateResponse\x12'\n" +
+	"\x06quotes\x18\x01 \x03(\v2\x0f.fedex.v1.QuoteR\x06quotes\x12'\n" +
+	"\x06alerts\x18\x02 \x03(\v2\x0f.fedex.v1.AlertR\x06alerts\x12%\n" +
+	"\x0etransaction_id\x18\x03 \x01(\tR\rtransactionId\x126\n" +
+	"\x17customer_transaction_id\x18\x04 \x01(\tR\x15customerTransactionId\"S\n" +
+	"\x10BatchRateRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12/\n" +
+	"\arequest\x18\x02 \x01(\v2\x15.fedex.v1.RateRequestR\arequest\"~\n" +
+	"\x11BatchRateResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x122\n" +
+	"\bresponse\x18\x02 \x01(\v2\x16.fedex.v1.RateResponseR\bresponse\x12%\n" +
+	"\x05error\x18\x03 \x01(\v2\x0f.fedex.v1.ErrorR\x05error\"T\n" +
+	"\x05Error\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"fedex_code\x18\x03 \x01(\tR\tfedexCode2\x90\x01\n" +
+	"\rRatingService\x125\n" +
+	"\x04Rate\x12\x15.fedex.v1.RateRequest\x1a\x16.fedex.v1.RateResponse\x12H\n" +
+	"\tRateBatch\x12\x1a.fedex.v1.BatchRateRequest\x1a\x1b.fedex.v1.BatchRateResponse(\x010\x01B3Z1github.com/tirpitz0509/go-fedex/fedexgrpc/fedexpbb\x06proto3"
+
+var (
+	file_fedexpb_rating_proto_rawDescOnce sync.Once
+	file_fedexpb_rating_proto_rawDescData []byte
+)
+
+func file_fedexpb_rating_proto_rawDescGZIP() []byte {
+	file_fedexpb_rating_proto_rawDescOnce.Do(func() {
+		file_fedexpb_rating_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_fedexpb_rating_proto_rawDesc), len(file_fedexpb_rating_proto_rawDesc)))
+	})
+	return file_fedexpb_rating_proto_rawDescData
+}
+
+var file_fedexpb_rating_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_fedexpb_rating_proto_goTypes = []any{
+	(*Address)(nil),           // 0: fedex.v1.Address
+	(*Weight)(nil),            // 1: fedex.v1.Weight
+	(*Dimensions)(nil),        // 2: fedex.v1.Dimensions
+	(*Money)(nil),             // 3: fedex.v1.Money
+	(*Package)(nil),           // 4: fedex.v1.Package
+	(*RateRequest)(nil),       // 5: fedex.v1.RateRequest
+	(*Charge)(nil),            // 6: fedex.v1.Charge
+	(*Transit)(nil),           // 7: fedex.v1.Transit
+	(*Quote)(nil),             // 8: fedex.v1.Quote
+	(*Alert)(nil),             // 9: fedex.v1.Alert
+	(*RateResponse)(nil),      // 10: fedex.v1.RateResponse
+	(*BatchRateRequest)(nil),  // 11: fedex.v1.BatchRateRequest
+	(*BatchRateResponse)(nil), // 12: fedex.v1.BatchRateResponse
+	(*Error)(nil),             // 13: fedex.v1.Error
+}
+var file_fedexpb_rating_proto_depIdxs = []int32{
+	1,  // 0: fedex.v1.Package.weight:type_name -> fedex.v1.Weight
+	2,  // 1: fedex.v1.Package.dimensions:type_name -> fedex.v1.Dimensions
+	3,  // 2: fedex.v1.Package.declared_value:type_name -> fedex.v1.Money
+	0,  // 3: fedex.v1.RateRequest.shipper:type_name -> fedex.v1.Address
+	0,  // 4: fedex.v1.RateRequest.recipient:type_name -> fedex.v1.Address
+	4,  // 5: fedex.v1.RateRequest.packages:type_name -> fedex.v1.Package
+	6,  // 6: fedex.v1.Quote.surcharges:type_name -> fedex.v1.Charge
+	1,  // 7: fedex.v1.Quote.billing_weight:type_name -> fedex.v1.Weight
+	7,  // 8: fedex.v1.Quote.transit:type_name -> fedex.v1.Transit
+	8,  // 9: fedex.v1.RateResponse.quotes:type_name -> fedex.v1.Quote
+	9,  // 10: fedex.v1.RateResponse.alerts:type_name -> fedex.v1.Alert
+	5,  // 11: fedex.v1.BatchRateRequest.request:type_name -> fedex.v1.RateRequest
+	10, // 12: fedex.v1.BatchRateResponse.response:type_name -> fedex.v1.RateResponse
+	13, // 13: fedex.v1.BatchRateResponse.error:type_name -> fedex.v1.Error
+	5,  // 14: fedex.v1.RatingService.Rate:input_type -> fedex.v1.RateRequest
+	11, // 15: fedex.v1.RatingService.RateBatch:input_type -> fedex.v1.BatchRateRequest
+	10, // 16: fedex.v1.RatingService.Rate:output_type -> fedex.v1.RateResponse
+	12, // 17: fedex.v1.RatingService.RateBatch:output_type -> fedex.v1.BatchRateResponse
+	16, // [16:18] is the sub-list for method output_type
+	14, // [14:16] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_fedexpb_rating_proto_init() }
+func file_fedexpb_rating_proto_init() {
+	if File_fedexpb_rating_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_fedexpb_rating_proto_rawDesc), len(file_fedexpb_rating_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_fedexpb_rating_proto_goTypes,
+		DependencyIndexes: file_fedexpb_rating_proto_depIdxs,
+		MessageInfos:      file_fedexpb_rating_proto_msgTypes,
+	}.Build()
+	File_fedexpb_rating_proto = out.File
+	file_fedexpb_rating_proto_goTypes = nil
+	file_fedexpb_rating_proto_depIdxs = nil
+}
@@ -0,0 +1,501 @@
+// Tracking over gRPC. The messages follow package track, flattened to what
+// callers show: one result per tracking number with its latest status and
+// scan events. Status and event codes are FedEx's, e.g. DL for delivered.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: fedexpb/tracking.proto
+
+package fedexpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TrackRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	TrackingNumbers      []string               `protobuf:"bytes,1,rep,name=tracking_numbers,json=trackingNumbers,proto3" json:"tracking_numbers,omitempty"`
+	IncludeDetailedScans bool                   `protobuf:"varint,2,opt,name=include_detailed_scans,json=includeDetailedScans,proto3" json:"include_detailed_scans,omitempty"`
+	ShipDateBegin        string                 `protobuf:"bytes,3,opt,name=ship_date_begin,json=shipDateBegin,proto3" json:"ship_date_begin,omitempty"` // YYYY-MM-DD, tells reused numbers apart
+	ShipDateEnd          string                 `protobuf:"bytes,4,opt,name=ship_date_end,json=shipDateEnd,proto3" json:"ship_date_end,omitempty"`       // YYYY-MM-DD
+	// Sent to FedEx as x-customer-transaction-id; the
+	// x-customer-transaction-id metadata or a new ID if empty.
+	CustomerTransactionId string `protobuf:"bytes,5,opt,name=customer_transaction_id,json=customerTransactionId,proto3" json:"customer_transaction_id,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *TrackRequest) Reset() {
+	*x = TrackRequest{}
+	mi := &file_fedexpb_tracking_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackRequest) ProtoMessage() {}
+
+func (x *TrackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fedexpb_tracking_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackRequest.ProtoReflect.Descriptor instead.
+func (*TrackRequest) Descriptor() ([]byte, []int) {
+	return file_fedexpb_tracking_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TrackRequest) GetTrackingNumbers() []string {
+	if x != nil {
+		return x.TrackingNumbers
+	}
+	return nil
+}
+
+func (x *TrackRequest) GetIncludeDetailedScans() bool {
+	if x != nil {
+		return x.IncludeDetailedScans
+	}
+	return false
+}
+
+func (x *TrackRequest) GetShipDateBegin() string {
+	if x != nil {
+		return x.ShipDateBegin
+	}
+	return ""
+}
+
+func (x *TrackRequest) GetShipDateEnd() string {
+	if x != nil {
+		return x.ShipDateEnd
+	}
+	return ""
+}
+
+func (x *TrackRequest) GetCustomerTransactionId() string {
+	if x != nil {
+		return x.CustomerTransactionId
+	}
+	return ""
+}
+
+type ScanEvent struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Date                 string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`                            // RFC 3339 with the local offset
+	EventType            string                 `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"` // e.g. PU, AR, DL
+	Description          string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ExceptionCode        string                 `protobuf:"bytes,4,opt,name=exception_code,json=exceptionCode,proto3" json:"exception_code,omitempty"`
+	ExceptionDescription string                 `protobuf:"bytes,5,opt,name=exception_description,json=exceptionDescription,proto3" json:"exception_description,omitempty"`
+	Location             *Address               `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"`
+	DerivedStatusCode    string                 `protobuf:"bytes,7,opt,name=derived_status_code,json=derivedStatusCode,proto3" json:"derived_status_code,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ScanEvent) Reset() {
+	*x = ScanEvent{}
+	mi := &file_fedexpb_tracking_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanEvent) ProtoMessage() {}
+
+func (x *ScanEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_fedexpb_tracking_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanEvent.ProtoReflect.Descriptor instead.
+func (*ScanEvent) Descriptor() ([]byte, []int) {
+	return file_fedexpb_tracking_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ScanEvent) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *ScanEvent) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *ScanEvent) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ScanEvent) GetExceptionCode() string {
+	if x != nil {
+		return x.ExceptionCode
+	}
+	return ""
+}
+
+func (x *ScanEvent) GetExceptionDescription() string {
+	if x != nil {
+		return x.ExceptionDescription
+	}
+	return ""
+}
+
+func (x *ScanEvent) GetLocation() *Address {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *ScanEvent) GetDerivedStatusCode() string {
+	if x != nil {
+		return x.DerivedStatusCode
+	}
+	return ""
+}
+
+type TrackResult struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	TrackingNumber          string                 `protobuf:"bytes,1,opt,name=tracking_number,json=trackingNumber,proto3" json:"tracking_number,omitempty"`
+	CarrierCode             string                 `protobuf:"bytes,2,opt,name=carrier_code,json=carrierCode,proto3" json:"carrier_code,omitempty"` // FDXE, FDXG, ...
+	StatusCode              string                 `protobuf:"bytes,3,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`    // of the latest status, e.g. DL
+	Status                  string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Location                *Address               `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`                          // of the latest status
+	ServiceType             string                 `protobuf:"bytes,6,opt,name=service_type,json=serviceType,proto3" json:"service_type,omitempty"` // e.g. FEDEX_GROUND
+	ActualPickup            string                 `protobuf:"bytes,7,opt,name=actual_pickup,json=actualPickup,proto3" json:"actual_pickup,omitempty"`
+	EstimatedDelivery       string                 `protobuf:"bytes,8,opt,name=estimated_delivery,json=estimatedDelivery,proto3" json:"estimated_delivery,omitempty"`
+	EstimatedDeliveryBegins string                 `protobuf:"bytes,9,opt,name=estimated_delivery_begins,json=estimatedDeliveryBegins,proto3" json:"estimated_delivery_begins,omitempty"` // window, when FedEx gives one
+	EstimatedDeliveryEnds   string                 `protobuf:"bytes,10,opt,name=estimated_delivery_ends,json=estimatedDeliveryEnds,proto3" json:"estimated_delivery_ends,omitempty"`
+	ActualDelivery          string                 `protobuf:"bytes,11,opt,name=actual_delivery,json=actualDelivery,proto3" json:"actual_delivery,omitempty"`
+	ScanEvents              []*ScanEvent           `protobuf:"bytes,12,rep,name=scan_events,json=scanEvents,proto3" json:"scan_events,omitempty"` // most recent first
+	Error                   *Error                 `protobuf:"bytes,13,opt,name=error,proto3" json:"error,omitempty"`                             // set instead of the rest when FedEx has no result
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *TrackResult) Reset() {
+	*x = TrackResult{}
+	mi := &file_fedexpb_tracking_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrackResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackResult) ProtoMessage() {}
+
+func (x *TrackResult) ProtoReflect() protoreflect.Message {
+	mi := &file_fedexpb_tracking_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackResult.ProtoReflect.Descriptor instead.
+func (*TrackResult) Descriptor() ([]byte, []int) {
+	return file_fedexpb_tracking_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TrackResult) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+func (x *TrackResult) GetCarrierCode() string {
+	if x != nil {
+		return x.CarrierCode
+	}
+	return ""
+}
+
+func (x *TrackResult) GetStatusCode() string {
+	if x != nil {
+		return x.StatusCode
+	}
+	return ""
+}
+
+func (x *TrackResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TrackResult) GetLocation() *Address {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *TrackResult) GetServiceType() string {
+	if x != nil {
+		return x.ServiceType
+	}
+	return ""
+}
+
+func (x *TrackResult) GetActualPickup() string {
+	if x != nil {
+		return x.ActualPickup
+	}
+	return ""
+}
+
+func (x *TrackResult) GetEstimatedDelivery() string {
+	if x != nil {
+		return x.EstimatedDelivery
+	}
+	return ""
+}
+
+func (x *TrackResult) GetEstimatedDeliveryBegins() string {
+	if x != nil {
+		return x.EstimatedDeliveryBegins
+	}
+	return ""
+}
+
+func (x *TrackResult) GetEstimatedDeliveryEnds() string {
+	if x != nil {
+		return x.EstimatedDeliveryEnds
+	}
+	return ""
+}
+
+func (x *TrackResult) GetActualDelivery() string {
+	if x != nil {
+		return x.ActualDelivery
+	}
+	return ""
+}
+
+func (x *TrackResult) GetScanEvents() []*ScanEvent {
+	if x != nil {
+		return x.ScanEvents
+	}
+	return nil
+}
+
+func (x *TrackResult) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type TrackResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Results               []*TrackResult         `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`                                  // in request order
+	TransactionId         string                 `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // assigned by FedEx
+	CustomerTransactionId string                 `protobuf:"bytes,3,opt,name=customer_transaction_id,json=customerTransactionId,proto3" json:"customer_transaction_id,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *TrackResponse) Reset() {
+	*x = TrackResponse{}
+	mi := &file_fedexpb_tracking_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackResponse) ProtoMessage() {}
+
+func (x *TrackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fedexpb_tracking_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackResponse.ProtoReflect.Descriptor instead.
+func (*TrackResponse) Descriptor() ([]byte, []int) {
+	return file_fedexpb_tracking_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TrackResponse) GetResults() []*TrackResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *TrackResponse) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *TrackResponse) GetCustomerTransactionId() string {
+	if x != nil {
+		return x.CustomerTransactionId
+	}
+	return ""
+}
+
+var File_fedexpb_tracking_proto protoreflect.FileDescriptor
+
+const file_fedexpb_tracking_proto_rawDesc = "" +
+	"\n" +
+	"\x16fedexpb/tracking.proto\x12\bfedex.v1\x1a\x14fedexpb/rating.proto\"\xf3\x01\n" +
+	"\fTrackRequest\x12)\n" +
+	"\x10tracking_numbers\x18\x01 \x03(\tR\x0ftrackingNumbers\x124\n" +
+	"\x16include_detailed_scans\x18\x02 \x01(\bR\x14includeDetailedScans\x12&\n" +
+	"\x0fship_date_begin\x18\x03 \x01(\tR\rshipDateBegin\x12\"\n" +
+	"\rship_date_end\x18\x04 \x01(\tR\vshipDateEnd\x126\n" +
+	"\x17customer_transaction_id\x18\x05 \x01(\tR\x15customerTransactionId\"\x9b\x02\n" +
+	"\tScanEvent\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\tR\teventType\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12%\n" +
+	"\x0eexception_code\x18\x04 \x01(\tR\rexceptionCode\x123\n" +
+	"\x15exception_description\x18\x05 \x01(\tR\x14exceptionDescription\x12-\n" +
+	"\blocation\x18\x06 \x01(\v2\x11.fedex.v1.AddressR\blocation\x12.\n" +
+	"\x13derived_status_code\x18\a \x01(\tR\x11derivedStatusCode\"\xb2\x04\n" +
+	"\vTrackResult\x12'\n" +
+	"\x0ftracking_number\x18\x01 \x01(\tR\x0etrackingNumber\x12!\n" +
+	"\fcarrier_code\x18\x02 \x01(\tR\vcarrierCode\x12\x1f\n" +
+	"\vstatus_code\x18\x03 \x01(\tR\n" +
+	"statusCode\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12-\n" +
+	"\blocation\x18\x05 \x01(\v2\x11.fedex.v1.AddressR\blocation\x12!\n" +
+	"\fservice_type\x18\x06 \x01(\tR\vserviceType\x12#\n" +
+	"\ractual_pickup\x18\a \x01(\tR\factualPickup\x12-\n" +
+	"\x12estimated_delivery\x18\b \x01(\tR\x11estimatedDelivery\x12:\n" +
+	"\x19estimated_delivery_begins\x18\t \x01(\tR\x17estimatedDeliveryBegins\x126\n" +
+	"\x17estimated_delivery_ends\x18\n" +
+	" \x01(\tR\x15estimatedDeliveryEnds\x12'\n" +
+	"\x0factual_delivery\x18\v \x01(\tR\x0eactualDelivery\x124\n" +
+	"\vscan_events\x18\f \x03(\v2\x13.fedex.v1.ScanEventR\n" +
+	"scanEvents\x12%\n" +
+	"\x05error\x18\r \x01(\v2\x0f.fedex.v1.ErrorR\x05error\"\x9f\x01\n" +
+	"\rTrackResponse\x12/\n" +
+	"\aresults\x18\x01 \x03(\v2\x15.fedex.v1.TrackResultR\aresults\x12%\n" +
+	"\x0etransaction_id\x18\x02 \x01(\tR\rtransactionId\x126\n" +
+	"\x17customer_transaction_id\x18\x03 \x01(\tR\x15customerTransactionId2K\n" +
+	"\x0fTrackingService\x128\n" +
+	"\x05Track\x12\x16.fedex.v1.TrackRequest\x1a\x17.fedex.v1.TrackResponseB3Z1github.com/tirpitz0509/go-fedex/fedexgrpc/fedexpbb\x06proto3"
+
+var (
+	file_fedexpb_tracking_proto_rawDescOnce sync.Once
+	file_fedexpb_tracking_proto_rawDescData []byte
+)
+
+func file_fedexpb_tracking_proto_rawDescGZIP() []byte {
+	file_fedexpb_tracking_proto_rawDescOnce.Do(func() {
+		file_fedexpb_tracking_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_fedexpb_tracking_proto_rawDesc), len(file_fedexpb_tracking_proto_rawDesc)))
+	})
+	return file_fedexpb_tracking_proto_rawDescData
+}
+
+var file_fedexpb_tracking_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_fedexpb_tracking_proto_goTypes = []any{
+	(*TrackRequest)(nil),  // 0: fedex.v1.TrackRequest
+	(*ScanEvent)(nil),     // 1: fedex.v1.ScanEvent
+	(*TrackResult)(nil),   // 2: fedex.v1.TrackResult
+	(*TrackResponse)(nil), // 3: fedex.v1.TrackResponse
+	(*Address)(nil),       // 4: fedex.v1.Address
+	(*Error)(nil),         // 5: fedex.v1.Error
+}
+var file_fedexpb_tracking_proto_depIdxs = []int32{
+	4, // 0: fedex.v1.ScanEvent.location:type_name -> fedex.v1.Address
+	4, // 1: fedex.v1.TrackResult.location:type_name -> fedex.v1.Address
+	1, // 2: fedex.v1.TrackResult.scan_events:type_name -> fedex.v1.ScanEvent
+	5, // 3: fedex.v1.TrackResult.error:type_name -> fedex.v1.Error
+	2, // 4: fedex.v1.TrackResponse.results:type_name -> fedex.v1.TrackResult
+	0, // 5: fedex.v1.TrackingService.Track:input_type -> fedex.v1.TrackRequest
+	3, // 6: fedex.v1.TrackingService.Track:output_type -> fedex.v1.TrackResponse
+	6, // [6:7] is the sub-list for method output_type
+	5, // [5:6] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_fedexpb_tracking_proto_init() }
+func file_fedexpb_tracking_proto_init() {
+	if File_fedexpb_tracking_proto != nil {
+		return
+	}
+	file_fedexpb_rating_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_fedexpb_tracking_proto_rawDesc), len(file_fedexpb_tracking_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_fedexpb_tracking_proto_goTypes,
+		DependencyIndexes: file_fedexpb_tracking_proto_depIdxs,
+		MessageInfos:      file_fedexpb_tracking_proto_msgTypes,
+	}.Build()
+	File_fedexpb_tracking_proto = out.File
+	file_fedexpb_tracking_proto_goTypes = nil
+	file_fedexpb_tracking_proto_depIdxs = nil
+}
@@ -0,0 +1,21 @@
+module github.com/tirpitz0509/go-fedex/fedexgrpc
+
+go 1.25.0
+
+require (
+	github.com/tirpitz0509/go-fedex v0.0.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260414002931-afd174a4e478
+	google.golang.org/grpc v1.82.1
+	google.golang.org/protobuf v1.36.11
+)
+
+require (
+	github.com/cstockton/go-conv v1.0.0 // indirect
+	golang.org/x/net v0.53.0 // indirect
+	golang.org/x/sys v0.43.0 // indirect
+	golang.org/x/text v0.36.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// the library is developed alongside
+replace github.com/tirpitz0509/go-fedex => ../
@@ -0,0 +1,264 @@
+// Package fedexgrpc serves rating and tracking over gRPC; the services are
+// defined in fedexpb/rating.proto and fedexpb/tracking.proto. It lives in
+// its own module so the library itself does not depend on gRPC.
+package fedexgrpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative fedexpb/rating.proto fedexpb/tracking.proto
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/tirpitz0509/go-fedex/common"
+	"github.com/tirpitz0509/go-fedex/fedexgrpc/fedexpb"
+	"github.com/tirpitz0509/go-fedex/rate"
+	"github.com/tirpitz0509/go-fedex/tenant"
+	"github.com/tirpitz0509/go-fedex/track"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is the domain of the ErrorInfo detail carrying FedEx's error
+// code on failed calls.
+const ErrorDomain = "fedex.com"
+
+// Server implements fedexpb.RatingServiceServer and
+// fedexpb.TrackingServiceServer on the REST API of one tenant, whose token
+// cache and rate limit all callers share.
+type Server struct {
+	fedexpb.UnimplementedRatingServiceServer
+	fedexpb.UnimplementedTrackingServiceServer
+
+	Client      *tenant.Tenant //
+	Concurrency int            // shipments of a batch rated at once, 4 if 0
+}
+
+func NewServer(client *tenant.Tenant) *Server {
+	return &Server{Client: client}
+}
+
+// Register adds both services to g.
+func (s *Server) Register(g *grpc.Server) {
+	fedexpb.RegisterRatingServiceServer(g, s)
+	fedexpb.RegisterTrackingServiceServer(g, s)
+}
+
+func (s *Server) Rate(ctx context.Context, request *fedexpb.RateRequest) (*fedexpb.RateResponse, error) {
+	response, err := s.rate(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(common.HeaderCustomerTransactionID, response.CustomerTransactionId))
+	return response, nil
+}
+
+func (s *Server) RateBatch(stream fedexpb.RatingService_RateBatchServer) error {
+	concurrency := s.Concurrency
+	if concurrency < 1 {
+		concurrency = 4
+	}
+	ctx := stream.Context()
+	slots := make(chan struct{}, concurrency)
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex // serializes Send
+		sendErr error
+	)
+	send := func(reply *fedexpb.BatchRateResponse) {
+		mu.Lock()
+		defer mu.Unlock()
+		if sendErr == nil {
+			sendErr = stream.Send(reply)
+		}
+	}
+
+	var recvErr error
+	for {
+		item, err := stream.Recv()
+		if err != nil {
+			if err != io.EOF {
+				recvErr = err
+			}
+			break
+		}
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() { <-slots; wg.Done() }()
+			reply := &fedexpb.BatchRateResponse{Id: item.GetId()}
+			response, err := s.rate(ctx, item.GetRequest())
+			if err != nil {
+				reply.Error = toError(err)
+			} else {
+				reply.Response = response
+			}
+			send(reply)
+		}()
+	}
+	wg.Wait()
+
+	if recvErr != nil {
+		return recvErr
+	}
+	return sendErr
+}
+
+// rate quotes one shipment; the tenant retries once with a new token if
+// FedEx refused the cached one. Errors are gRPC statuses.
+func (s *Server) rate(ctx context.Context, request *fedexpb.RateRequest) (*fedexpb.RateResponse, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "no request")
+	}
+	r, err := toRateRequest(request, s.Client.Credentials.AccountNumber)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	response, err := s.Client.RateContext(withTransactionID(ctx, request.GetCustomerTransactionId()), r)
+	if err != nil {
+		var fedex rate.Message
+		if len(response.Errors) > 0 {
+			fedex = response.Errors[0]
+		}
+		return nil, toStatus(fedex.Code, fedex.Message, err)
+	}
+	return fromRateResponse(response), nil
+}
+
+func (s *Server) Track(ctx context.Context, request *fedexpb.TrackRequest) (*fedexpb.TrackResponse, error) {
+	numbers := request.GetTrackingNumbers()
+	if len(numbers) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no tracking numbers")
+	}
+	if len(numbers) > track.MaxTrackingNumbers {
+		return nil, status.Error(codes.InvalidArgument, "at most "+strconv.Itoa(track.MaxTrackingNumbers)+" tracking numbers per request")
+	}
+	r := track.NewRequest(numbers...)
+	r.IncludeDetailedScans = request.GetIncludeDetailedScans()
+	for i := range r.TrackingInfo {
+		r.TrackingInfo[i].ShipDateBegin = request.GetShipDateBegin()
+		r.TrackingInfo[i].ShipDateEnd = request.GetShipDateEnd()
+	}
+
+	response, err := s.Client.TrackContext(withTransactionID(ctx, request.GetCustomerTransactionId()), r)
+	if err != nil {
+		var fedex track.Message
+		if len(response.Errors) > 0 {
+			fedex = response.Errors[0]
+		}
+		return nil, toStatus(fedex.Code, fedex.Message, err)
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(common.HeaderCustomerTransactionID, response.CustomerTransactionID))
+	return fromTrackResponse(response), nil
+}
+
+// withTransactionID attaches the request's customer transaction ID, or
+// else the one in the call's metadata, to ctx.
+func withTransactionID(ctx context.Context, id string) context.Context {
+	if md, ok := metadata.FromIncomingContext(ctx); ok && id == "" {
+		if values := md.Get(common.HeaderCustomerTransactionID); len(values) > 0 {
+			id = values[0]
+		}
+	}
+	if id != "" {
+		ctx = common.WithTransactionID(ctx, id)
+	}
+	return ctx
+}
+
+func invalidArgument(err error) error {
+	st := status.New(codes.InvalidArgument, err.Error())
+	var validation rate.ValidationErrors
+	if !errors.As(err, &validation) {
+		return st.Err()
+	}
+	detail := &errdetails.BadRequest{}
+	for _, v := range validation {
+		detail.FieldViolations = append(detail.FieldViolations, &errdetails.BadRequest_FieldViolation{Field: v.Path, Description: v.Message})
+	}
+	if with, err := st.WithDetails(detail); err == nil {
+		st = with
+	}
+	return st.Err()
+}
+
+// toStatus picks the code for a failed FedEx call and attaches FedEx's
+// error code and the transaction IDs as ErrorInfo.
+func toStatus(fedexCode string, fedexMessage string, err error) error {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+	var token *tenant.TokenError
+	if errors.As(err, &token) {
+		return status.Error(codes.Internal, "fedex auth: "+token.Err.Error())
+	}
+
+	code := codes.Unknown
+	message := err.Error()
+	info := &errdetails.ErrorInfo{Domain: ErrorDomain, Metadata: map[string]string{}}
+	var transaction *common.TransactionError
+	if errors.As(err, &transaction) {
+		info.Metadata["customerTransactionId"] = transaction.CustomerTransactionID
+		if transaction.TransactionID != "" {
+			info.Metadata["transactionId"] = transaction.TransactionID
+		}
+	}
+	if fedexCode != "" {
+		info.Reason = fedexCode
+		if fedexMessage != "" {
+			message = fedexMessage
+		}
+		code = fedexStatus(fedexCode)
+	} else if transaction != nil && transaction.StatusCode >= 500 {
+		// a 5xx status without a FedEx error body
+		code = codes.Unavailable
+	}
+
+	st := status.New(code, message)
+	if with, err := st.WithDetails(info); err == nil {
+		st = with
+	}
+	return st.Err()
+}
+
+func fedexStatus(fedexCode string) codes.Code {
+	switch fedexCode {
+	case "NOT.AUTHORIZED.ERROR", "LOGIN.REAUTHENTICATE.ERROR", "FORBIDDEN.ERROR", "ACCOUNT.NUMBER.MISMATCH":
+		return codes.Internal
+	case "TOO.MANY.REQUESTS.ERROR":
+		return codes.ResourceExhausted
+	case "SERVICE.UNAVAILABLE.ERROR", "SYSTEM.UNAVAILABLE.EXCEPTION", "INTERNAL.SERVER.ERROR":
+		return codes.Unavailable
+	case "RATE.LOCATION.NOSERVICE", "SERVICE.UNAVAILABLE.LOCATION", "SERVICETYPE.NOT.ALLOWED":
+		return codes.FailedPrecondition
+	}
+	if strings.HasSuffix(fedexCode, ".NOTFOUND") {
+		return codes.NotFound
+	}
+	if strings.Contains(fedexCode, "INVALID") || strings.Contains(fedexCode, "MISSING") || strings.HasSuffix(fedexCode, ".REQUIRED") {
+		return codes.InvalidArgument
+	}
+	return codes.Unknown
+}
+
+// toError flattens a status for a batch reply.
+func toError(err error) *fedexpb.Error {
+	st := status.Convert(err)
+	e := &fedexpb.Error{Code: int32(st.Code()), Message: st.Message()}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			e.FedexCode = info.Reason
+		}
+	}
+	return e
+}
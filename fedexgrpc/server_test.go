@@ -0,0 +1,221 @@
+package fedexgrpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"sort"
+	"testing"
+
+	"github.com/tirpitz0509/go-fedex/fedexgrpc/fedexpb"
+	"github.com/tirpitz0509/go-fedex/fedextest"
+	"github.com/tirpitz0509/go-fedex/rate"
+	"github.com/tirpitz0509/go-fedex/tenant"
+	"github.com/tirpitz0509/go-fedex/track"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dial serves the rating and tracking services for a tenant of the mock
+// FedEx server over an in-memory connection.
+func dial(t *testing.T, s *fedextest.Server) *grpc.ClientConn {
+	client := tenant.NewRegistry(nil).Register("test", tenant.Credentials{
+		ClientId:      "id",
+		ClientSecret:  "secret",
+		AccountNumber: "123456789",
+		BaseURL:       s.URL,
+	})
+	listener := bufconn.Listen(1 << 20)
+	g := grpc.NewServer()
+	NewServer(client).Register(g)
+	go g.Serve(listener)
+	t.Cleanup(g.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func shipment(postal string) *fedexpb.RateRequest {
+	return &fedexpb.RateRequest{
+		Shipper:     &fedexpb.Address{CountryCode: "US", PostalCode: "38017"},
+		Recipient:   &fedexpb.Address{CountryCode: "US", PostalCode: postal},
+		Packages:    []*fedexpb.Package{{Weight: &fedexpb.Weight{Units: "LB", Value: 5}}},
+		ServiceType: "FEDEX_GROUND",
+	}
+}
+
+func TestRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		request *fedexpb.RateRequest
+		prepare func(s *fedextest.Server)
+		code    codes.Code
+		net     float64
+	}{
+		{"quoted", shipment("90210"), func(s *fedextest.Server) {}, codes.OK, 12.5},
+		{"expired token retried", shipment("90210"), func(s *fedextest.Server) { s.ExpireTokens() }, codes.OK, 12.5},
+		{"FedEx error", shipment("90210"), func(s *fedextest.Server) {
+			s.Fail(400, "RATE.LOCATION.NOSERVICE", "no service")
+		}, codes.FailedPrecondition, 0},
+		{"FedEx down", shipment("90210"), func(s *fedextest.Server) {
+			s.Fail(503, "SERVICE.UNAVAILABLE.ERROR", "down")
+		}, codes.Unavailable, 0},
+		{"invalid", &fedexpb.RateRequest{ServiceType: "FEDEX_GROUND"}, func(s *fedextest.Server) {}, codes.InvalidArgument, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := fedextest.NewServer()
+			defer s.Close()
+			s.Quotes(rate.Quote{NetCharge: 12.5, Currency: "USD"})
+			client := fedexpb.NewRatingServiceClient(dial(t, s))
+
+			// fill the tenant's token cache before the scenario
+			if _, err := client.Rate(context.Background(), shipment("10001")); err != nil {
+				t.Fatal(err)
+			}
+			tt.prepare(s)
+
+			response, err := client.Rate(context.Background(), tt.request)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("code = %v (%v), want %v", code, err, tt.code)
+			}
+			if tt.code != codes.OK {
+				return
+			}
+			if len(response.GetQuotes()) != 1 || response.GetQuotes()[0].GetNetCharge() != tt.net {
+				t.Errorf("quotes = %v, want one of %v", response.GetQuotes(), tt.net)
+			}
+			if response.GetCustomerTransactionId() == "" {
+				t.Error("no customer transaction ID")
+			}
+		})
+	}
+}
+
+func TestRateBatch(t *testing.T) {
+	s := fedextest.NewServer()
+	defer s.Close()
+	s.Quotes(rate.Quote{NetCharge: 12.5, Currency: "USD"})
+	client := fedexpb.NewRatingServiceClient(dial(t, s))
+
+	stream, err := client.RateBatch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := []*fedexpb.BatchRateRequest{
+		{Id: "a", Request: shipment("90210")},
+		{Id: "b", Request: &fedexpb.RateRequest{}},
+		{Id: "c", Request: shipment("10001")},
+	}
+	for _, item := range items {
+		if err := stream.Send(item); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stream.CloseSend()
+
+	var got []string
+	for {
+		reply, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch {
+		case reply.GetError() != nil:
+			got = append(got, reply.GetId()+" "+codes.Code(reply.GetError().GetCode()).String())
+		case len(reply.GetResponse().GetQuotes()) == 1:
+			got = append(got, reply.GetId()+" OK")
+		default:
+			got = append(got, reply.GetId()+" empty")
+		}
+	}
+	sort.Strings(got)
+	want := []string{"a OK", "b InvalidArgument", "c OK"}
+	if len(got) != len(want) {
+		t.Fatalf("replies = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("replies = %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestTrack(t *testing.T) {
+	delivered := track.TrackResult{
+		TrackingNumberInfo: track.TrackingNumberInfo{TrackingNumber: "794843185271", CarrierCode: "FDXG"},
+		LatestStatusDetail: track.StatusDetail{Code: "DL", Description: "Delivered", ScanLocation: track.Location{City: "BEVERLY HILLS", CountryCode: "US"}},
+		DateAndTimes:       []track.DateAndTime{{Type: "ACTUAL_DELIVERY", DateTime: "2026-10-14T10:42:00-07:00"}},
+		ScanEvents:         []track.ScanEvent{{Date: "2026-10-14T10:42:00-07:00", EventType: "DL", EventDescription: "Delivered"}},
+		ServiceDetail:      track.ServiceDetail{Type: "FEDEX_GROUND"},
+	}
+	tooMany := make([]string, track.MaxTrackingNumbers+1)
+	for i := range tooMany {
+		tooMany[i] = "794843185271"
+	}
+
+	tests := []struct {
+		name    string
+		numbers []string
+		prepare func(s *fedextest.Server)
+		code    codes.Code
+	}{
+		{"tracked", []string{"794843185271", "000000000000"}, func(s *fedextest.Server) {}, codes.OK},
+		{"expired token retried", []string{"794843185271", "000000000000"}, func(s *fedextest.Server) { s.ExpireTokens() }, codes.OK},
+		{"FedEx down", []string{"794843185271"}, func(s *fedextest.Server) {
+			s.Fail(503, "SERVICE.UNAVAILABLE.ERROR", "down")
+		}, codes.Unavailable},
+		{"no numbers", nil, func(s *fedextest.Server) {}, codes.InvalidArgument},
+		{"too many numbers", tooMany, func(s *fedextest.Server) {}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := fedextest.NewServer()
+			defer s.Close()
+			s.Tracking(delivered)
+			conn := dial(t, s)
+			client := fedexpb.NewTrackingServiceClient(conn)
+
+			// fill the tenant's token cache before the scenario
+			if _, err := fedexpb.NewRatingServiceClient(conn).Rate(context.Background(), shipment("10001")); err != nil {
+				t.Fatal(err)
+			}
+			tt.prepare(s)
+
+			response, err := client.Track(context.Background(), &fedexpb.TrackRequest{TrackingNumbers: tt.numbers, IncludeDetailedScans: true})
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("code = %v (%v), want %v", code, err, tt.code)
+			}
+			if tt.code != codes.OK {
+				return
+			}
+			results := response.GetResults()
+			if len(results) != 2 {
+				t.Fatalf("results = %v, want 2", results)
+			}
+			if r := results[0]; r.GetStatusCode() != "DL" || r.GetActualDelivery() != "2026-10-14T10:42:00-07:00" ||
+				r.GetLocation().GetCity() != "BEVERLY HILLS" || len(r.GetScanEvents()) != 1 || r.GetError() != nil {
+				t.Errorf("first result = %v, want the delivered shipment", r)
+			}
+			if e := results[1].GetError(); codes.Code(e.GetCode()) != codes.NotFound || e.GetFedexCode() != "TRACKING.TRACKINGNUMBER.NOTFOUND" {
+				t.Errorf("second result error = %v, want NotFound", e)
+			}
+			if response.GetCustomerTransactionId() == "" {
+				t.Error("no customer transaction ID")
+			}
+		})
+	}
+}
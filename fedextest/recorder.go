@@ -0,0 +1,237 @@
+package fedextest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type Mode int
+
+const (
+	// Record sends requests to FedEx and keeps the scrubbed interactions.
+	Record Mode = iota
+	// Replay answers requests from the golden file without network access.
+	Replay
+)
+
+const redacted = "REDACTED"
+
+// Recorder is an http.RoundTripper for common.Fedex.Client and
+// auth.FedEXAuth.Client that records FedEx interactions to a golden file or
+// replays them from one. Replayed requests are matched on method, path and
+// the normalized, scrubbed body, so host names and formatting may differ.
+//
+//	r, _ := fedextest.NewRecorder("testdata/ground.json", fedextest.Replay)
+//	reply, err := request.RateWith(common.Fedex{BaseURL: url, Client: r.Client()}, token)
+type Recorder struct {
+	Mode      Mode              //
+	Path      string            //
+	Transport http.RoundTripper // used when recording, http.DefaultTransport if nil
+
+	mu           sync.Mutex
+	interactions []Interaction
+	replayed     []bool
+}
+
+// Interaction is one recorded request and its answer.
+type Interaction struct {
+	Method       string `json:"method"`       //
+	Path         string `json:"path"`         //
+	RequestBody  string `json:"requestBody"`  //
+	Status       int    `json:"status"`       //
+	ContentType  string `json:"contentType"`  //
+	ResponseBody string `json:"responseBody"` //
+}
+
+type golden struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// NewRecorder loads path when replaying; a recorder in Record mode starts
+// empty and writes path on Save.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{Mode: mode, Path: path}
+	if mode != Replay {
+		return r, nil
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var g golden
+	if err := json.Unmarshal(content, &g); err != nil {
+		return nil, err
+	}
+	r.interactions = g.Interactions
+	r.replayed = make([]bool, len(g.Interactions))
+	return r, nil
+}
+
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Interactions returns what has been recorded or loaded so far.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.interactions...)
+}
+
+// Save writes the recorded interactions to Path.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	content, err := json.MarshalIndent(golden{Interactions: r.interactions}, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.Path, append(content, '\n'), 0644)
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if r.Mode == Replay {
+		return r.replay(req, body)
+	}
+	return r.record(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	out := req.Clone(req.Context())
+	out.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	resp, err := transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	content, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(content))
+
+	contentType := req.Header.Get("Content-Type")
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{
+		Method:       req.Method,
+		Path:         req.URL.Path,
+		RequestBody:  Redact(string(body), contentType),
+		Status:       resp.StatusCode,
+		ContentType:  resp.Header.Get("Content-Type"),
+		ResponseBody: Redact(string(content), resp.Header.Get("Content-Type")),
+	})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// replay answers with the first unused matching interaction, falling back
+// to a used one so a test may repeat a call.
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	contentType := req.Header.Get("Content-Type")
+	want := normalize(Redact(string(body), contentType), contentType)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	match := -1
+	for i, in := range r.interactions {
+		if in.Method != req.Method || in.Path != req.URL.Path || normalize(in.RequestBody, contentType) != want {
+			continue
+		}
+		if !r.replayed[i] {
+			match = i
+			break
+		}
+		if match < 0 {
+			match = i
+		}
+	}
+	if match < 0 {
+		return nil, errors.New("fedextest: no recorded interaction for " + req.Method + " " + req.URL.Path)
+	}
+	r.replayed[match] = true
+
+	in := r.interactions[match]
+	header := http.Header{}
+	header.Set("Content-Type", in.ContentType)
+	return &http.Response{
+		Status:        strconv.Itoa(in.Status) + " " + http.StatusText(in.Status),
+		StatusCode:    in.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(in.ResponseBody)),
+		ContentLength: int64(len(in.ResponseBody)),
+		Request:       req,
+	}, nil
+}
+
+var (
+	scrubbedFormKeys = []string{"client_id", "client_secret", "child_Key", "child_secret"}
+	scrubXML         = regexp.MustCompile(`<((?:\w+:)?(?:Key|Password|MeterNumber|AccountNumber))>[^<]*<`)
+	scrubJSON        = regexp.MustCompile(`"(access_token|client_id|client_secret|child_Key|child_secret)"(\s*):(\s*)"[^"]*"`)
+	scrubAccount     = regexp.MustCompile(`"(accountNumber)"(\s*):(\s*)\{(\s*)"value"(\s*):(\s*)"[^"]*"`)
+	betweenTags      = regexp.MustCompile(`>\s+<`)
+	transactionId    = regexp.MustCompile(`<((?:\w+:)?CustomerTransactionId)>[^<]*<`)
+)
+
+// Redact removes credentials, account numbers and tokens from a request or
+// reply body, for golden files and support tickets.
+func Redact(body string, contentType string) string {
+	if strings.Contains(contentType, "x-www-form-urlencoded") {
+		values, err := url.ParseQuery(body)
+		if err != nil {
+			return body
+		}
+		for _, key := range scrubbedFormKeys {
+			if values.Get(key) != "" {
+				values.Set(key, redacted)
+			}
+		}
+		return values.Encode()
+	}
+	body = scrubXML.ReplaceAllString(body, "<$1>"+redacted+"<")
+	body = scrubJSON.ReplaceAllString(body, `"$1"$2:$3"`+redacted+`"`)
+	return scrubAccount.ReplaceAllString(body, `"$1"$2:$3{$4"value"$5:$6"`+redacted+`"`)
+}
+
+// normalize makes bodies comparable regardless of key order, whitespace and
+// generated customer transaction IDs.
+func normalize(body string, contentType string) string {
+	switch {
+	case strings.Contains(contentType, "x-www-form-urlencoded"):
+		if values, err := url.ParseQuery(body); err == nil {
+			return values.Encode()
+		}
+	case strings.Contains(contentType, "json"):
+		var v interface{}
+		if err := json.Unmarshal([]byte(body), &v); err == nil {
+			content, _ := json.Marshal(v)
+			return string(content)
+		}
+	}
+	body = transactionId.ReplaceAllString(body, "<$1><")
+	return betweenTags.ReplaceAllString(strings.TrimSpace(body), "><")
+}
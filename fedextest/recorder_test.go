@@ -0,0 +1,116 @@
+package fedextest_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tirpitz0509/go-fedex/auth"
+	"github.com/tirpitz0509/go-fedex/common"
+	"github.com/tirpitz0509/go-fedex/fedextest"
+	"github.com/tirpitz0509/go-fedex/rate"
+)
+
+// rateAll authorizes and rates one shipment over REST and SOAP, returning
+// the token and the net charges quoted.
+func rateAll(t *testing.T, baseURL string, credentials auth.FedEXAuth, fedex common.Fedex) (string, []float64) {
+	token, err := credentials.Authorize(credentials.GrantStrategy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	shipment := rate.NewShipment("123456789").
+		From(rate.Address{CountryCode: "US", PostalCode: "38017"}).
+		To(rate.Address{CountryCode: "US", PostalCode: "90210"}).
+		Service(rate.FedexGround).
+		Packaging(rate.YourPackaging).
+		AddPackage(rate.NewPackage(5, "LB"))
+	request, err := shipment.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fedex.BaseURL = baseURL
+	response, err := request.RateWith(fedex, token.AccessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, err := shipment.BuildXML("k3y-value", "p4ss-value", "m3ter-value")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fedex.BaseURL = baseURL + "/web-services"
+	xmlResponse, err := x.RateWith(fedex, "/rate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var charges []float64
+	for _, q := range append(response.Quotes(), xmlResponse.Quotes()...) {
+		charges = append(charges, q.NetCharge)
+	}
+	return token.AccessToken, charges
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	s := fedextest.NewServer()
+	s.Quotes(rate.Quote{NetCharge: 12.5, Currency: "USD"})
+	path := filepath.Join(t.TempDir(), "ground.json")
+
+	recorder, err := fedextest.NewRecorder(path, fedextest.Record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder.Transport = s.Client().Transport
+	credentials := s.Auth("id-value", "s3cr3t-value")
+	credentials.Client = recorder.Client()
+	token, recorded := rateAll(t, s.URL, credentials, common.Fedex{Client: recorder.Client()})
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"id-value", "s3cr3t-value", "123456789", "k3y-value", "p4ss-value", "m3ter-value", token} {
+		if strings.Contains(string(content), secret) {
+			t.Errorf("golden file contains %q", secret)
+		}
+	}
+
+	// the server is closed: everything comes from the golden file
+	replayer, err := fedextest.NewRecorder(path, fedextest.Replay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	credentials.Client = replayer.Client()
+	_, replayed := rateAll(t, s.URL, credentials, common.Fedex{Client: replayer.Client()})
+	if len(recorded) != 2 || len(replayed) != len(recorded) || replayed[0] != recorded[0] || replayed[1] != recorded[1] {
+		t.Errorf("replayed %v, recorded %v, want 12.5 twice", replayed, recorded)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		want        string
+	}{
+		{"form", "client_id=id&client_secret=secret&grant_type=client_credentials", "application/x-www-form-urlencoded",
+			"client_id=REDACTED&client_secret=REDACTED&grant_type=client_credentials"},
+		{"token", `{"access_token": "abc", "token_type":"bearer"}`, "application/json",
+			`{"access_token": "REDACTED", "token_type":"bearer"}`},
+		{"account", `{"accountNumber":{"value":"123456789"}}`, "application/json",
+			`{"accountNumber":{"value":"REDACTED"}}`},
+		{"SOAP", "<v28:Key>key</v28:Key><AccountNumber>123456789</AccountNumber>", "text/xml",
+			"<v28:Key>REDACTED</v28:Key><AccountNumber>REDACTED</AccountNumber>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fedextest.Redact(tt.body, tt.contentType); got != tt.want {
+				t.Errorf("Redact() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,522 @@
+// Package fedextest provides a local stand-in for the FedEx APIs, so code
+// using the auth, rate and track packages can be tested without the sandbox.
+//
+//	s := fedextest.NewServer()
+//	defer s.Close()
+//	s.Quotes(rate.Quote{ServiceType: rate.FedexGround, NetCharge: 12.5})
+//	token, _ := s.Auth("id", "secret").Authorization()
+//	reply, err := request.RateWith(s.REST(), token.AccessToken)
+package fedextest
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tirpitz0509/go-fedex/auth"
+	"github.com/tirpitz0509/go-fedex/common"
+	"github.com/tirpitz0509/go-fedex/rate"
+	"github.com/tirpitz0509/go-fedex/track"
+)
+
+const (
+	TokenPath = "/oauth/token"
+	RatePath  = "/rate/v1/rates/quotes"
+	SOAPPath  = "/web-services/rate"
+	TrackPath = "/track/v1/trackingnumbers"
+)
+
+// Server serves /oauth/token, the REST rate and track endpoints and the
+// SOAP rate endpoint. Scenario methods may be called at any time, also while requests
+// are in flight.
+type Server struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	clientId     string
+	clientSecret string
+	tokens       map[string]bool
+	issued       int
+	quotes       []rate.Quote
+	byService    map[rate.ServiceType][]rate.Quote
+	tracking     map[string]track.TrackResult
+	failures     []Failure
+	unavailable  bool
+	latency      time.Duration
+	requests     []Request
+}
+
+// Failure is a scripted error answer to the next rate or track request.
+type Failure struct {
+	Status  int    //
+	Code    string //
+	Message string //
+}
+
+// Request is a request received by the server.
+type Request struct {
+	Method string      //
+	Path   string      //
+	Header http.Header //
+	Body   []byte      //
+}
+
+func NewServer() *Server {
+	s := &Server{
+		tokens:    map[string]bool{},
+		byService: map[rate.ServiceType][]rate.Quote{},
+		tracking:  map[string]track.TrackResult{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Auth returns credentials pointing at the server.
+func (s *Server) Auth(clientId string, clientSecret string) auth.FedEXAuth {
+	return auth.FedEXAuth{
+		GrantType:    "client_credentials",
+		ClientId:     clientId,
+		ClientSecret: clientSecret,
+		BaseURL:      s.URL,
+		Client:       s.Client(),
+	}
+}
+
+// REST returns a transport for RateRequest.RateWith.
+func (s *Server) REST() common.Fedex {
+	return common.Fedex{BaseURL: s.URL, Client: s.Client()}
+}
+
+// SOAP returns a transport for RateXMLRequest.RateWith with the path "/rate".
+func (s *Server) SOAP() common.Fedex {
+	return common.Fedex{BaseURL: s.URL + "/web-services", Client: s.Client()}
+}
+
+// Credentials makes /oauth/token reject any other client id and secret.
+func (s *Server) Credentials(clientId string, clientSecret string) {
+	s.mu.Lock()
+	s.clientId, s.clientSecret = clientId, clientSecret
+	s.mu.Unlock()
+}
+
+// Quotes sets the rates returned for every request. A quote without a
+// service type answers whichever service was requested.
+func (s *Server) Quotes(quotes ...rate.Quote) {
+	s.mu.Lock()
+	s.quotes = quotes
+	s.mu.Unlock()
+}
+
+// ServiceQuotes sets the rates returned when serviceType is requested,
+// taking precedence over Quotes.
+func (s *Server) ServiceQuotes(serviceType rate.ServiceType, quotes ...rate.Quote) {
+	s.mu.Lock()
+	for i := range quotes {
+		quotes[i].ServiceType = serviceType
+	}
+	s.byService[serviceType] = quotes
+	s.mu.Unlock()
+}
+
+// Fail queues an error answer for the next rate request, REST or SOAP, or
+// track request.
+func (s *Server) Fail(status int, code string, message string) {
+	s.mu.Lock()
+	s.failures = append(s.failures, Failure{Status: status, Code: code, Message: message})
+	s.mu.Unlock()
+}
+
+// Tracking sets the results returned for their tracking numbers; other
+// numbers are answered with TRACKING.TRACKINGNUMBER.NOTFOUND.
+func (s *Server) Tracking(results ...track.TrackResult) {
+	s.mu.Lock()
+	for _, r := range results {
+		s.tracking[r.TrackingNumberInfo.TrackingNumber] = r
+	}
+	s.mu.Unlock()
+}
+
+// ExpireTokens invalidates every token issued so far; REST requests using
+// them get a 401.
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	s.tokens = map[string]bool{}
+	s.mu.Unlock()
+}
+
+// Unavailable makes every endpoint answer 503 until called with false.
+func (s *Server) Unavailable(unavailable bool) {
+	s.mu.Lock()
+	s.unavailable = unavailable
+	s.mu.Unlock()
+}
+
+// Latency delays every answer by d.
+func (s *Server) Latency(d time.Duration) {
+	s.mu.Lock()
+	s.latency = d
+	s.mu.Unlock()
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Header: r.Header.Clone(), Body: body})
+	latency, unavailable := s.latency, s.unavailable
+	s.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if unavailable {
+		http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != "POST" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	switch r.URL.Path {
+	case TokenPath:
+		s.token(w, r, body)
+	case RatePath:
+		s.rateREST(w, r, body)
+	case SOAPPath:
+		s.rateSOAP(w, body)
+	case TrackPath:
+		s.track(w, r, body)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request, body []byte) {
+	r.Body = ioutil.NopCloser(strings.NewReader(string(body)))
+	r.ParseForm()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.PostForm.Get("client_id") == "" ||
+		(s.clientId != "" && (r.PostForm.Get("client_id") != s.clientId || r.PostForm.Get("client_secret") != s.clientSecret)) {
+		writeJSON(w, http.StatusUnauthorized, restError("", "NOT.AUTHORIZED.ERROR", "The given client credentials were not valid. Please modify your request and try again."))
+		return
+	}
+
+	s.issued++
+	token := "fedextest-" + strconv.Itoa(s.issued)
+	s.tokens[token] = true
+	writeJSON(w, http.StatusOK, auth.FedexAuthResponse{
+		AccessToken: token,
+		TokenType:   "bearer",
+		ExpiresIn:   3599,
+		Scope:       "CXS",
+	})
+}
+
+func (s *Server) rateREST(w http.ResponseWriter, r *http.Request, body []byte) {
+	transactionId := r.Header.Get(common.HeaderCustomerTransactionID)
+	if !s.authorized(w, r, transactionId) {
+		return
+	}
+
+	var request rate.RateRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		writeJSON(w, http.StatusBadRequest, restError(transactionId, "INVALID.INPUT.EXCEPTION", "Invalid field value in the input"))
+		return
+	}
+	writeJSON(w, http.StatusOK, s.respond(request, transactionId))
+}
+
+func (s *Server) track(w http.ResponseWriter, r *http.Request, body []byte) {
+	transactionId := r.Header.Get(common.HeaderCustomerTransactionID)
+	if !s.authorized(w, r, transactionId) {
+		return
+	}
+
+	var request track.TrackRequest
+	if err := json.Unmarshal(body, &request); err != nil || len(request.TrackingInfo) == 0 {
+		writeJSON(w, http.StatusBadRequest, restError(transactionId, "INVALID.INPUT.EXCEPTION", "Invalid field value in the input"))
+		return
+	}
+	response := track.TrackResponse{TransactionID: newTransactionID(), CustomerTransactionID: transactionId}
+	s.mu.Lock()
+	for _, info := range request.TrackingInfo {
+		number := info.TrackingNumberInfo.TrackingNumber
+		result, ok := s.tracking[number]
+		if !ok {
+			result = track.TrackResult{
+				TrackingNumberInfo: info.TrackingNumberInfo,
+				Error:              &track.Message{Code: "TRACKING.TRACKINGNUMBER.NOTFOUND", Message: "Tracking number cannot be found. Please correct the tracking number and try again."},
+			}
+		}
+		if !request.IncludeDetailedScans {
+			result.ScanEvents = nil
+		}
+		response.Output.CompleteTrackResults = append(response.Output.CompleteTrackResults, track.CompleteTrackResult{TrackingNumber: number, TrackResults: []track.TrackResult{result}})
+	}
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, response)
+}
+
+// authorized answers REST requests with an unknown token or a scripted
+// failure, reporting whether the request may go on.
+func (s *Server) authorized(w http.ResponseWriter, r *http.Request, transactionId string) bool {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	s.mu.Lock()
+	valid := s.tokens[token]
+	s.mu.Unlock()
+	if !valid {
+		writeJSON(w, http.StatusUnauthorized, restError(transactionId, "NOT.AUTHORIZED.ERROR", "Access token expired. Please modify your request and try again."))
+		return false
+	}
+	if f, ok := s.nextFailure(); ok {
+		writeJSON(w, f.Status, restError(transactionId, f.Code, f.Message))
+		return false
+	}
+	return true
+}
+
+func (s *Server) rateSOAP(w http.ResponseWriter, body []byte) {
+	if f, ok := s.nextFailure(); ok {
+		writeSOAP(w, f.Status, fault(f))
+		return
+	}
+
+	request, err := decodeSOAP(body)
+	if err != nil {
+		writeSOAP(w, http.StatusInternalServerError, fault(Failure{Code: "INVALID.INPUT.EXCEPTION", Message: err.Error()}))
+		return
+	}
+	transactionId := request.Body.RateRequest.TransactionDetail.CustomerTransactionId
+	reply := s.respond(request.ToRateRequest(), transactionId).ToRateXMLResponse()
+	writeSOAP(w, http.StatusOK, soapBody{RateReply: reply.Body.RateReply})
+}
+
+func (s *Server) nextFailure() (Failure, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.failures) == 0 {
+		return Failure{}, false
+	}
+	f := s.failures[0]
+	s.failures = s.failures[1:]
+	if f.Status == 0 {
+		f.Status = http.StatusBadRequest
+	}
+	return f, true
+}
+
+// respond builds the reply from the configured quotes for the requested
+// service, or for every configured service when none was requested.
+func (s *Server) respond(request rate.RateRequest, transactionId string) rate.RateResponse {
+	requested := request.RequestedShipment.ServiceType
+
+	s.mu.Lock()
+	var quotes []rate.Quote
+	if requested != "" && len(s.byService[requested]) > 0 {
+		quotes = append(quotes, s.byService[requested]...)
+	} else {
+		for _, q := range s.quotes {
+			if q.ServiceType == "" {
+				q.ServiceType = requested
+			}
+			if requested == "" || q.ServiceType == requested {
+				quotes = append(quotes, q)
+			}
+		}
+		if requested == "" {
+			for _, q := range s.byService {
+				quotes = append(quotes, q...)
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	if len(quotes) == 0 {
+		quotes = defaultQuotes(request)
+	}
+
+	var response rate.RateResponse
+	response.TransactionID = newTransactionID()
+	response.CustomerTransactionID = transactionId
+	response.Output.QuoteDate = time.Now().Format("2006-01-02")
+	for _, q := range quotes {
+		response.Output.RateReplyDetails = append(response.Output.RateReplyDetails, replyDetail(q))
+	}
+	return response
+}
+
+func defaultQuotes(request rate.RateRequest) []rate.Quote {
+	serviceType := request.RequestedShipment.ServiceType
+	if serviceType == "" {
+		serviceType = rate.FedexGround
+	}
+	weight := request.RequestedShipment.TotalWeight
+	units := "LB"
+	if items := request.RequestedShipment.RequestedPackageLineItems; len(items) > 0 {
+		units = items[0].Weight.Units
+		if weight == 0 {
+			for _, p := range items {
+				weight += p.Weight.Value
+			}
+		}
+	}
+	base := 10 + weight
+	return []rate.Quote{{
+		ServiceType:     serviceType,
+		RateType:        rate.RateAccount,
+		Currency:        "USD",
+		BaseCharge:      base,
+		Surcharges:      []rate.QuoteCharge{{Type: "FUEL", Description: "Fuel", Amount: 1.5}},
+		TotalSurcharges: 1.5,
+		NetCharge:       base + 1.5,
+		BillingWeight:   rate.Weight{Units: units, Value: weight},
+		TransitTime:     "THREE_DAYS",
+		TransitDays:     3,
+	}}
+}
+
+func replyDetail(q rate.Quote) rate.RateReplyDetail {
+	rateType := q.RateType
+	if rateType == "" {
+		rateType = rate.RateAccount
+	}
+	rated := rate.RatedShipmentDetail{
+		RateType:            string(rateType),
+		RatedWeightMethod:   "ACTUAL",
+		TotalDiscounts:      q.Discounts,
+		TotalBaseCharge:     q.BaseCharge,
+		TotalNetCharge:      q.NetCharge,
+		TotalVatCharge:      q.Taxes,
+		TotalNetFedExCharge: q.NetCharge,
+		TotalDutiesAndTaxes: q.DutiesAndTaxes,
+		Currency:            q.Currency,
+	}
+	rated.TotalNetChargeWithDutiesAndTaxes = q.NetCharge + q.DutiesAndTaxes
+	rated.ShipmentRateDetail = rate.ShipmentRateDetail{
+		RateZone:           q.RateZone,
+		TotalSurcharges:    q.TotalSurcharges,
+		TotalBillingWeight: q.BillingWeight,
+		Currency:           q.Currency,
+	}
+	for _, c := range q.Surcharges {
+		rated.ShipmentRateDetail.SurCharges = append(rated.ShipmentRateDetail.SurCharges, rate.Surcharge{
+			Type: c.Type, Description: c.Description, Amount: c.Amount,
+		})
+	}
+
+	detail := rate.RateReplyDetail{
+		ServiceType:          string(q.ServiceType),
+		ServiceName:          q.ServiceName,
+		PackagingType:        q.PackagingType,
+		RatedShipmentDetails: []rate.RatedShipmentDetail{rated},
+	}
+	if detail.ServiceName == "" {
+		detail.ServiceName = q.ServiceType.DisplayName()
+	}
+	detail.OperationalDetail.TransitTime = q.TransitTime
+	detail.OperationalDetail.CommitDate = q.CommitDate
+	detail.OperationalDetail.DeliveryDay = q.DeliveryDay
+	return detail
+}
+
+// decodeSOAP reads the parts of a SOAP rate request that ToRateRequest uses.
+func decodeSOAP(body []byte) (rate.RateXMLRequest, error) {
+	var request rate.RateXMLRequest
+	decoder := xml.NewDecoder(strings.NewReader(string(body)))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return request, nil
+		}
+		if err != nil {
+			return request, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		rr := &request.Body.RateRequest
+		switch start.Name.Local {
+		case "ClientDetail":
+			err = decoder.DecodeElement(&rr.ClientDetail, &start)
+		case "TransactionDetail":
+			err = decoder.DecodeElement(&rr.TransactionDetail, &start)
+		case "RequestedShipment":
+			err = decoder.DecodeElement(&rr.RequestedShipment, &start)
+		}
+		if err != nil {
+			return request, err
+		}
+	}
+}
+
+func restError(transactionId string, code string, message string) rate.RateResponse {
+	return rate.RateResponse{
+		TransactionID:         newTransactionID(),
+		CustomerTransactionID: transactionId,
+		Errors:                []rate.Message{{Code: code, Message: message}},
+	}
+}
+
+func newTransactionID() string {
+	return "fedextest-" + common.NewTransactionID()
+}
+
+type soapFault struct {
+	Faultcode   string `xml:"faultcode"`   //
+	Faultstring string `xml:"faultstring"` //
+	Detail      struct {
+		Code string `xml:"code"`
+		Desc string `xml:"desc"`
+	} `xml:"detail"` //
+}
+
+type soapBody struct {
+	Fault     *soapFault  `xml:"SOAP-ENV:Fault,omitempty"` //
+	RateReply interface{} `xml:"RateReply,omitempty"`      //
+}
+
+func fault(f Failure) soapBody {
+	x := &soapFault{Faultcode: "SOAP-ENV:Server", Faultstring: f.Message}
+	x.Detail.Code = f.Code
+	x.Detail.Desc = f.Message
+	return soapBody{Fault: x}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeSOAP(w http.ResponseWriter, status int, body soapBody) {
+	content, _ := xml.Marshal(struct {
+		XMLName xml.Name `xml:"SOAP-ENV:Envelope"`
+		Xmlns   string   `xml:"xmlns:SOAP-ENV,attr"`
+		Body    soapBody `xml:"SOAP-ENV:Body"`
+	}{Xmlns: common.ENV, Body: body})
+	w.Header().Set("Content-Type", "text/xml")
+	w.WriteHeader(status)
+	w.Write([]byte(xml.Header))
+	w.Write(content)
+}
@@ -0,0 +1,271 @@
+package fedextest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tirpitz0509/go-fedex/common"
+	"github.com/tirpitz0509/go-fedex/fedextest"
+	"github.com/tirpitz0509/go-fedex/rate"
+)
+
+func groundShipment() *rate.ShipmentBuilder {
+	return rate.NewShipment("123456789").
+		From(rate.Address{CountryCode: "US", PostalCode: "38017"}).
+		To(rate.Address{CountryCode: "US", PostalCode: "90210"}).
+		Service(rate.FedexGround).
+		Packaging(rate.YourPackaging).
+		AddPackage(rate.NewPackage(5, "LB"))
+}
+
+// token fetches a token from s the way FedEx clients do.
+func token(t *testing.T, s *fedextest.Server, clientId string, clientSecret string) (int, string) {
+	form := url.Values{"grant_type": {"client_credentials"}, "client_id": {clientId}, "client_secret": {clientSecret}}
+	response, err := s.Client().PostForm(s.URL+fedextest.TokenPath, form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	var body struct {
+		AccessToken string `json:"access_token"`
+	}
+	json.NewDecoder(response.Body).Decode(&body)
+	return response.StatusCode, body.AccessToken
+}
+
+// postREST sends the ground shipment to the REST endpoint of s.
+func postREST(t *testing.T, s *fedextest.Server, token string) (int, rate.RateResponse) {
+	request, err := groundShipment().Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, _ := json.Marshal(request)
+	r, _ := http.NewRequest("POST", s.URL+fedextest.RatePath, bytes.NewReader(content))
+	r.Header.Set("Authorization", "Bearer "+token)
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set(common.HeaderCustomerTransactionID, "txn-1")
+	response, err := s.Client().Do(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	var reply rate.RateResponse
+	json.NewDecoder(response.Body).Decode(&reply)
+	return response.StatusCode, reply
+}
+
+// postSOAP sends body to the SOAP endpoint of s.
+func postSOAP(t *testing.T, s *fedextest.Server, body []byte) (int, rate.RateXMLResponse) {
+	response, err := s.Client().Post(s.URL+fedextest.SOAPPath, "text/xml", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	content, _ := ioutil.ReadAll(response.Body)
+	var reply rate.RateXMLResponse
+	if err := xml.Unmarshal(content, &reply); err != nil {
+		t.Fatalf("%v: %s", err, content)
+	}
+	return response.StatusCode, reply
+}
+
+func charges(quotes []rate.Quote) []float64 {
+	var c []float64
+	for _, q := range quotes {
+		c = append(c, q.NetCharge)
+	}
+	return c
+}
+
+func TestServerREST(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(s *fedextest.Server)
+		status  int
+		code    string
+		charges []float64
+		once    bool // the scenario answers one request only
+	}{
+		{"default quote", func(s *fedextest.Server) {}, http.StatusOK, "", nil, false},
+		{"quotes", func(s *fedextest.Server) {
+			s.Quotes(rate.Quote{NetCharge: 12.5, Currency: "USD"})
+		}, http.StatusOK, "", []float64{12.5}, false},
+		{"per-service quotes", func(s *fedextest.Server) {
+			s.Quotes(rate.Quote{ServiceType: rate.PriorityOvernight, NetCharge: 40, Currency: "USD"})
+			s.ServiceQuotes(rate.FedexGround, rate.Quote{NetCharge: 9.75, Currency: "USD"})
+		}, http.StatusOK, "", []float64{9.75}, false},
+		{"fail", func(s *fedextest.Server) {
+			s.Fail(http.StatusServiceUnavailable, "SERVICE.UNAVAILABLE.ERROR", "The service is currently unavailable")
+		}, http.StatusServiceUnavailable, "SERVICE.UNAVAILABLE.ERROR", nil, true},
+		{"fail without status", func(s *fedextest.Server) {
+			s.Fail(0, "SHIPMENT.USER.UNAUTHORIZED", "unauthorized")
+		}, http.StatusBadRequest, "SHIPMENT.USER.UNAUTHORIZED", nil, true},
+		{"expired tokens", func(s *fedextest.Server) { s.ExpireTokens() }, http.StatusUnauthorized, "NOT.AUTHORIZED.ERROR", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := fedextest.NewServer()
+			defer s.Close()
+			status, accessToken := token(t, s, "id", "secret")
+			if status != http.StatusOK || accessToken == "" {
+				t.Fatalf("token = %d %q", status, accessToken)
+			}
+			tt.prepare(s)
+
+			status, reply := postREST(t, s, accessToken)
+			if status != tt.status {
+				t.Fatalf("status = %d, want %d", status, tt.status)
+			}
+			if tt.code != "" {
+				if len(reply.Errors) != 1 || reply.Errors[0].Code != tt.code {
+					t.Errorf("errors = %+v, want %s", reply.Errors, tt.code)
+				}
+				if reply.CustomerTransactionID != "txn-1" {
+					t.Errorf("CustomerTransactionID = %q, want txn-1", reply.CustomerTransactionID)
+				}
+			} else {
+				quotes := reply.Quotes()
+				if len(quotes) == 0 || quotes[0].ServiceType != rate.FedexGround {
+					t.Fatalf("quotes = %+v, want FEDEX_GROUND", quotes)
+				}
+				if tt.charges != nil && !equal(charges(quotes), tt.charges) {
+					t.Errorf("charges = %v, want %v", charges(quotes), tt.charges)
+				}
+			}
+
+			if tt.once {
+				if status, _ := postREST(t, s, accessToken); status != http.StatusOK {
+					t.Errorf("status after the failure = %d, want 200", status)
+				}
+			}
+		})
+	}
+}
+
+func TestServerTokens(t *testing.T) {
+	s := fedextest.NewServer()
+	defer s.Close()
+	s.Credentials("id", "secret")
+	if status, _ := token(t, s, "id", "wrong"); status != http.StatusUnauthorized {
+		t.Errorf("wrong secret: status = %d, want 401", status)
+	}
+	status, first := token(t, s, "id", "secret")
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, want 200", status)
+	}
+
+	s.ExpireTokens()
+	if status, _ := postREST(t, s, first); status != http.StatusUnauthorized {
+		t.Errorf("expired token: status = %d, want 401", status)
+	}
+	_, second := token(t, s, "id", "secret")
+	if second == first {
+		t.Errorf("token after expiry = %q, want a new one", second)
+	}
+	if status, _ := postREST(t, s, second); status != http.StatusOK {
+		t.Errorf("new token: status = %d, want 200", status)
+	}
+}
+
+func TestServerLatencyAndAvailability(t *testing.T) {
+	s := fedextest.NewServer()
+	defer s.Close()
+	_, accessToken := token(t, s, "id", "secret")
+
+	s.Latency(50 * time.Millisecond)
+	start := time.Now()
+	if status, _ := postREST(t, s, accessToken); status != http.StatusOK {
+		t.Errorf("status = %d, want 200", status)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("answered after %v, want at least 50ms", elapsed)
+	}
+
+	client := s.Client()
+	client.Timeout = 10 * time.Millisecond
+	if _, err := client.Post(s.URL+fedextest.SOAPPath, "text/xml", strings.NewReader("")); err == nil {
+		t.Error("client with a 10ms timeout got an answer, want a timeout")
+	}
+
+	s.Latency(0)
+	s.Unavailable(true)
+	if status, _ := token(t, s, "id", "secret"); status != http.StatusServiceUnavailable {
+		t.Errorf("unavailable: status = %d, want 503", status)
+	}
+	s.Unavailable(false)
+	if status, _ := postREST(t, s, accessToken); status != http.StatusOK {
+		t.Errorf("available again: status = %d, want 200", status)
+	}
+}
+
+func TestServerSOAP(t *testing.T) {
+	s := fedextest.NewServer()
+	defer s.Close()
+	s.ServiceQuotes(rate.FedexGround, rate.Quote{NetCharge: 9.75, Currency: "USD"})
+
+	x, err := groundShipment().BuildXML("key", "password", "meter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	x.Body.RateRequest.TransactionDetail.CustomerTransactionId = "txn-2"
+	body, err := xml.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status, reply := postSOAP(t, s, body)
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, want 200", status)
+	}
+	if got := reply.Body.RateReply.TransactionDetail.CustomerTransactionId; got != "txn-2" {
+		t.Errorf("CustomerTransactionId = %q, want txn-2", got)
+	}
+	if got := charges(reply.Quotes()); !equal(got, []float64{9.75}) {
+		t.Errorf("charges = %v, want [9.75]", got)
+	}
+
+	s.Fail(http.StatusInternalServerError, "RATE.SERVICE.ERROR", "rating failed")
+	status, reply = postSOAP(t, s, body)
+	if status != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", status)
+	}
+	if f := reply.Body.Fault; f.Detail.Code != "RATE.SERVICE.ERROR" || f.Detail.Desc != "rating failed" {
+		t.Errorf("fault = %+v, want RATE.SERVICE.ERROR", f)
+	}
+
+	status, reply = postSOAP(t, s, []byte("<Envelope><Body><RateRequest><RequestedShipment><Oops>"))
+	if status != http.StatusInternalServerError || reply.Body.Fault.Detail.Code != "INVALID.INPUT.EXCEPTION" {
+		t.Errorf("malformed request: status = %d, fault = %+v", status, reply.Body.Fault)
+	}
+
+	var paths []string
+	for _, r := range s.Requests() {
+		paths = append(paths, r.Path)
+	}
+	if want := []string{fedextest.SOAPPath, fedextest.SOAPPath, fedextest.SOAPPath}; !equalStrings(paths, want) {
+		t.Errorf("requests = %v, want %v", paths, want)
+	}
+}
+
+func equal(a []float64, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalStrings(a []string, b []string) bool {
+	return strings.Join(a, ",") == strings.Join(b, ",")
+}
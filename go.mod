@@ -2,5 +2,7 @@ module github.com/tirpitz0509/go-fedex
 
 go 1.16
 
-
-
+require (
+	github.com/cstockton/go-conv v1.0.0
+	gopkg.in/yaml.v3 v3.0.1
+)
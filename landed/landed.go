@@ -0,0 +1,362 @@
+// Package landed estimates the landed cost of international shipments:
+// shipping plus the duties, taxes and fees of each commodity, from FedEx's
+// duty and tax estimate or, when FedEx gives none, a local tariff table.
+package landed
+
+import (
+	"errors"
+	"math"
+	"strings"
+
+	"github.com/tirpitz0509/go-fedex/currency"
+	"github.com/tirpitz0509/go-fedex/rate"
+)
+
+// Incoterm says who pays duties and taxes.
+type Incoterm string
+
+const (
+	DDP Incoterm = "DDP" // delivered duty paid: the shipper
+	DDU Incoterm = "DDU" // delivered duty unpaid: the recipient
+)
+
+// Line types.
+const (
+	Duty = "DUTY"
+	Tax  = "TAX"
+	Fee  = "FEE"
+)
+
+const (
+	SourceFedEx  = "FEDEX"  // FedEx estimated the duties and taxes
+	SourceTariff = "TARIFF" // computed from Calculator.Tariff
+)
+
+type Line struct {
+	Commodity      string  `json:"commodity,omitempty"`      // description; empty for shipment level fees
+	HarmonizedCode string  `json:"harmonizedCode,omitempty"` //
+	Type           string  `json:"type"`                     // DUTY, TAX or FEE
+	Description    string  `json:"description,omitempty"`    //
+	Amount         float64 `json:"amount"`                   //
+}
+
+// LandedCost is what a shipment costs delivered. Amounts are in Currency:
+// the preferred currency of the request when FedEx quoted in it or
+// Calculator.Currency converts into it, otherwise the currency FedEx quoted
+// in.
+type LandedCost struct {
+	ServiceType   rate.ServiceType            `json:"serviceType"`           //
+	Incoterm      Incoterm                    `json:"incoterm"`              //
+	Currency      string                      `json:"currency"`              //
+	Conversions   []rate.CurrencyExchangeRate `json:"conversions,omitempty"` // the rates amounts in other currencies were converted at
+	GoodsValue    float64                     `json:"goodsValue"`            // customs value of the commodities
+	Shipping      float64                     `json:"shipping"`              // net charge of the quote
+	Lines         []Line                      `json:"lines"`                 //
+	Duties        float64                     `json:"duties"`                //
+	Taxes         float64                     `json:"taxes"`                 //
+	Fees          float64                     `json:"fees"`                  //
+	Total         float64                     `json:"total"`                 // goods, shipping, duties, taxes and fees
+	ShipperPays   float64                     `json:"shipperPays"`           // shipping, and duties, taxes and fees under DDP
+	RecipientPays float64                     `json:"recipientPays"`         // duties, taxes and fees under DDU
+	Source        string                      `json:"source"`                //
+}
+
+type Calculator struct {
+	Rate     func(rate.RateRequest) (rate.RateResponse, error) // e.g. (*tenant.Tenant).Rate
+	Tariff   Tariff                                            // used when FedEx estimates nothing; may be nil
+	Currency currency.Provider                                 // converts amounts in other currencies; may be nil
+}
+
+// Estimate rates request with duties and taxes estimated and paid per
+// incoterm. The request needs its service type and, when international,
+// CustomsClearanceDetail.Commodities. Commodity values, tariff fees and
+// FedEx's estimates in another currency than the landed cost are converted
+// with Calculator.Currency; without it they are an error.
+func (c Calculator) Estimate(request rate.RateRequest, incoterm Incoterm) (LandedCost, error) {
+	if c.Rate == nil {
+		return LandedCost{}, errors.New("landed: no Rate function")
+	}
+	shipment := &request.RequestedShipment
+	if shipment.ServiceType == "" {
+		return LandedCost{}, errors.New("landed: the request has no service type")
+	}
+	international := shipment.Shipper.Address.CountryCode != shipment.Recipient.Address.CountryCode
+	customs := &shipment.CustomsClearanceDetail
+	if international && len(customs.Commodities) == 0 && !shipment.DocumentShipment {
+		return LandedCost{}, errors.New("landed: international shipments need commodities")
+	}
+
+	switch incoterm {
+	case DDP:
+		customs.DutiesPayment.PaymentType = "SENDER"
+		if customs.DutiesPayment.Payor.AccountNumber() == "" {
+			customs.DutiesPayment.Payor.ResponsibleParty = &rate.Party{AccountNumber: request.AccountNumber}
+		}
+	case DDU:
+		customs.DutiesPayment.PaymentType = "RECIPIENT"
+	default:
+		return LandedCost{}, errors.New("landed: incoterm must be DDP or DDU")
+	}
+	if international {
+		shipment.EdtRequestType = "ALL"
+	}
+	if shipment.PreferredCurrency != "" && !hasRateType(shipment.RateRequestType, rate.RatePreferred) {
+		if len(shipment.RateRequestType) == 0 {
+			shipment.RateRequestType = []rate.RateRequestType{rate.RateAccount}
+		}
+		types := append([]rate.RateRequestType{}, shipment.RateRequestType...)
+		shipment.RateRequestType = append(types, rate.RatePreferred)
+	}
+
+	response, err := c.Rate(request)
+	if err != nil {
+		return LandedCost{}, err
+	}
+	if len(response.Errors) > 0 {
+		return LandedCost{}, errors.New("landed: " + response.Errors[0].Code + ": " + response.Errors[0].Message)
+	}
+	rated, ok := ratedDetail(response, shipment.ServiceType, shipment.PreferredCurrency)
+	if !ok {
+		return LandedCost{}, errors.New("landed: no rate for " + string(shipment.ServiceType))
+	}
+	quoted := detailCurrency(rated)
+
+	l := LandedCost{
+		ServiceType: shipment.ServiceType,
+		Incoterm:    incoterm,
+		Currency:    quoted,
+		Source:      SourceFedEx,
+	}
+	if preferred := strings.ToUpper(shipment.PreferredCurrency); preferred != "" && c.Currency != nil {
+		l.Currency = preferred
+	}
+	convert := func(amount float64, from string) (float64, error) {
+		return l.convert(c.Currency, amount, from)
+	}
+	if l.Shipping, err = convert(rated.TotalNetCharge, quoted); err != nil {
+		return LandedCost{}, err
+	}
+	for _, commodity := range customs.Commodities {
+		value, err := convert(customsValue(commodity))
+		if err != nil {
+			return LandedCost{}, err
+		}
+		l.GoodsValue += value
+	}
+
+	if international {
+		if err := l.addFedEx(rated, customs.Commodities, convert); err != nil {
+			return LandedCost{}, err
+		}
+		if len(l.Lines) == 0 && c.Tariff != nil {
+			if err := l.addTariff(c.Tariff, shipment.Recipient.Address.CountryCode, customs.Commodities, convert); err != nil {
+				return LandedCost{}, err
+			}
+			l.Source = SourceTariff
+		}
+	}
+	l.total()
+	return l, nil
+}
+
+// addFedEx adds the commodity taxes and ancillary fees FedEx estimated, or
+// its bare total if it itemized nothing.
+func (l *LandedCost) addFedEx(rated rate.RatedShipmentDetail, commodities []rate.Commodity, convert converter) error {
+	quoted := detailCurrency(rated)
+	descriptions := map[string]string{}
+	for _, c := range commodities {
+		if _, ok := descriptions[c.HarmonizedCode]; !ok {
+			descriptions[c.HarmonizedCode] = c.Description
+		}
+	}
+	for _, commodityTax := range rated.ShipmentRateDetail.DutiesAndTaxes {
+		for _, tax := range commodityTax.Taxes {
+			from := tax.Amount.Currency
+			if from == "" {
+				from = quoted
+			}
+			amount, err := convert(tax.Amount.Amount, from)
+			if err != nil {
+				return errors.New("landed: FedEx's " + tax.TaxType + " estimate: " + strings.TrimPrefix(err.Error(), "landed: "))
+			}
+			description := tax.Description
+			if description == "" {
+				description = tax.Name
+			}
+			l.Lines = append(l.Lines, Line{
+				Commodity:      descriptions[commodityTax.HarmonizedCode],
+				HarmonizedCode: commodityTax.HarmonizedCode,
+				Type:           taxLineType(tax.TaxType),
+				Description:    description,
+				Amount:         amount,
+			})
+		}
+	}
+	for _, fee := range rated.AncillaryFeesAndTaxes {
+		amount, err := convert(fee.Amount, quoted)
+		if err != nil {
+			return err
+		}
+		l.Lines = append(l.Lines, Line{Type: Fee, Description: fee.Description, Amount: amount})
+	}
+	if len(l.Lines) == 0 && rated.TotalDutiesAndTaxes > 0 {
+		amount, err := convert(rated.TotalDutiesAndTaxes, quoted)
+		if err != nil {
+			return err
+		}
+		l.Lines = append(l.Lines, Line{Type: Duty, Description: "Duties and taxes", Amount: amount})
+	}
+	return nil
+}
+
+// addTariff adds a duty, tax and fee line per commodity from the tariff:
+// duty on the customs value, tax on the value plus duty, in l.Currency.
+func (l *LandedCost) addTariff(tariff Tariff, country string, commodities []rate.Commodity, convert converter) error {
+	for _, c := range commodities {
+		r, ok := tariff.Lookup(country, c.HarmonizedCode)
+		if !ok {
+			return errors.New("landed: no tariff for " + c.HarmonizedCode + " into " + country)
+		}
+		value, from := customsValue(c)
+		value, err := convert(value, from)
+		if err != nil {
+			return err
+		}
+		feeCurrency := r.FeeCurrency
+		if feeCurrency == "" {
+			feeCurrency = from
+		}
+		fee, err := convert(r.Fee, feeCurrency)
+		if err != nil {
+			return err
+		}
+		duty := round(value * r.Duty / 100)
+		tax := round((value + duty) * r.Tax / 100)
+		add := func(lineType string, description string, amount float64) {
+			if amount > 0 {
+				l.Lines = append(l.Lines, Line{
+					Commodity:      c.Description,
+					HarmonizedCode: c.HarmonizedCode,
+					Type:           lineType,
+					Description:    description,
+					Amount:         amount,
+				})
+			}
+		}
+		add(Duty, "Import duty", duty)
+		add(Tax, "Import tax", tax)
+		add(Fee, "Clearance fee", round(fee))
+	}
+	return nil
+}
+
+func (l *LandedCost) total() {
+	for _, line := range l.Lines {
+		switch line.Type {
+		case Duty:
+			l.Duties += line.Amount
+		case Tax:
+			l.Taxes += line.Amount
+		default:
+			l.Fees += line.Amount
+		}
+	}
+	l.GoodsValue, l.Duties, l.Taxes, l.Fees = round(l.GoodsValue), round(l.Duties), round(l.Taxes), round(l.Fees)
+	dutiesAndTaxes := round(l.Duties + l.Taxes + l.Fees)
+	l.Total = round(l.GoodsValue + l.Shipping + dutiesAndTaxes)
+	l.ShipperPays = l.Shipping
+	if l.Incoterm == DDP {
+		l.ShipperPays = round(l.Shipping + dutiesAndTaxes)
+	} else {
+		l.RecipientPays = dutiesAndTaxes
+	}
+}
+
+// converter converts an amount from a currency into that of the landed
+// cost.
+type converter func(amount float64, from string) (float64, error)
+
+// convert converts amount from currency from into l.Currency with provider,
+// recording the rate used. Amounts with no currency are taken as is.
+func (l *LandedCost) convert(provider currency.Provider, amount float64, from string) (float64, error) {
+	from = strings.ToUpper(from)
+	if from == "" || l.Currency == "" || from == l.Currency {
+		return amount, nil
+	}
+	if provider == nil {
+		return 0, errors.New("landed: an amount is in " + from + ", not " + l.Currency + ", and no Calculator.Currency converts it")
+	}
+	r, err := currency.Converter{Provider: provider}.Convert(1, from, l.Currency)
+	if err != nil {
+		return 0, errors.New("landed: " + err.Error())
+	}
+	known := false
+	for _, c := range l.Conversions {
+		known = known || c.FromCurrency == from
+	}
+	if !known {
+		l.Conversions = append(l.Conversions, rate.CurrencyExchangeRate{FromCurrency: from, IntoCurrency: l.Currency, Rate: r})
+	}
+	return round(amount * r), nil
+}
+
+// ratedDetail picks the rate of serviceType, in currency if FedEx quoted
+// in it and otherwise the first; detailCurrency tells which it was.
+func ratedDetail(response rate.RateResponse, serviceType rate.ServiceType, currency string) (rate.RatedShipmentDetail, bool) {
+	for _, detail := range response.Output.RateReplyDetails {
+		if detail.ServiceType != string(serviceType) || len(detail.RatedShipmentDetails) == 0 {
+			continue
+		}
+		for _, rated := range detail.RatedShipmentDetails {
+			if currency != "" && strings.EqualFold(detailCurrency(rated), currency) {
+				return rated, true
+			}
+		}
+		return detail.RatedShipmentDetails[0], true
+	}
+	return rate.RatedShipmentDetail{}, false
+}
+
+func detailCurrency(rated rate.RatedShipmentDetail) string {
+	if rated.Currency != "" {
+		return strings.ToUpper(rated.Currency)
+	}
+	return strings.ToUpper(rated.ShipmentRateDetail.Currency)
+}
+
+// taxLineType maps FedEx tax types, e.g. CUSTOMS_DUTIES, GENERAL_SALES_TAX
+// or IMPORT_LICENSE_FEE, onto line types.
+func taxLineType(taxType string) string {
+	taxType = strings.ToUpper(taxType)
+	switch {
+	case strings.Contains(taxType, "DUT") || taxType == "CUSTOMS":
+		return Duty
+	case strings.Contains(taxType, "FEE") || strings.Contains(taxType, "SURCHARGE"):
+		return Fee
+	}
+	return Tax
+}
+
+func customsValue(c rate.Commodity) (float64, string) {
+	if c.CustomsValue.Amount > 0 {
+		return c.CustomsValue.Amount, c.CustomsValue.Currency
+	}
+	quantity := c.Quantity
+	if quantity < 1 {
+		quantity = 1
+	}
+	return c.UnitPrice.Amount * float64(quantity), c.UnitPrice.Currency
+}
+
+func hasRateType(types []rate.RateRequestType, t rate.RateRequestType) bool {
+	for _, have := range types {
+		if have == t {
+			return true
+		}
+	}
+	return false
+}
+
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,157 @@
+package landed
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tirpitz0509/go-fedex/currency"
+	"github.com/tirpitz0509/go-fedex/rate"
+)
+
+// quoting returns a Rate function quoting FEDEX_INTERNATIONAL_PRIORITY at
+// net in each currency given, with FedEx's duty estimate if duty > 0.
+func quoting(net float64, duty float64, currencies ...string) func(rate.RateRequest) (rate.RateResponse, error) {
+	return func(rate.RateRequest) (rate.RateResponse, error) {
+		var response rate.RateResponse
+		detail := rate.RateReplyDetail{ServiceType: string(rate.InternationalPriority)}
+		for _, c := range currencies {
+			rated := rate.RatedShipmentDetail{Currency: c, TotalNetCharge: net}
+			if duty > 0 {
+				rated.ShipmentRateDetail.DutiesAndTaxes = []rate.CommodityTax{{
+					HarmonizedCode: "6109.10",
+					Taxes:          []rate.TaxDetail{{TaxType: "CUSTOMS", Amount: rate.Money{Amount: duty, Currency: c}}},
+				}}
+			}
+			detail.RatedShipmentDetails = append(detail.RatedShipmentDetails, rated)
+		}
+		response.Output.RateReplyDetails = append(response.Output.RateReplyDetails, detail)
+		return response, nil
+	}
+}
+
+func landedRequest(preferred string, value rate.Money) rate.RateRequest {
+	request, _ := rate.NewShipment("123456789").
+		From(rate.Address{CountryCode: "US", PostalCode: "38017"}).
+		To(rate.Address{CountryCode: "GB", PostalCode: "SW1A 1AA"}).
+		Service(rate.InternationalPriority).
+		AddPackage(rate.NewPackage(2, "LB")).
+		Build()
+	request.RequestedShipment.PreferredCurrency = preferred
+	request.RequestedShipment.CustomsClearanceDetail.Commodities = []rate.Commodity{{
+		Description:    "T-shirts",
+		HarmonizedCode: "6109.10",
+		Quantity:       1,
+		CustomsValue:   value,
+	}}
+	return request
+}
+
+func TestEstimateCurrency(t *testing.T) {
+	rates := currency.NewTable()
+	rates.Set("USD", "GBP", 0.8)
+	tariff, err := ReadTable(strings.NewReader("GB,61,10,20,5\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gbpFees, err := ReadTable(strings.NewReader("GB,61,10,20,5,GBP\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	usdFees, err := ReadTable(strings.NewReader("GB,61,10,20,5,USD\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		calculator Calculator
+		request    rate.RateRequest
+		currency   string // of the landed cost, empty if Estimate fails
+		shipping   float64
+		goods      float64
+		duties     float64
+		taxes      float64
+		fees       float64
+		converted  bool
+	}{
+		{"quoted in the preferred currency", Calculator{Rate: quoting(50, 12, "USD", "GBP")},
+			landedRequest("GBP", rate.Money{Amount: 100, Currency: "GBP"}), "GBP", 50, 100, 12, 0, 0, false},
+		{"preferred currency converted", Calculator{Rate: quoting(50, 12, "USD"), Currency: rates},
+			landedRequest("GBP", rate.Money{Amount: 100, Currency: "GBP"}), "GBP", 40, 100, 9.6, 0, 0, true},
+		{"preferred currency without a provider", Calculator{Rate: quoting(50, 12, "USD")},
+			landedRequest("GBP", rate.Money{Amount: 100, Currency: "USD"}), "USD", 50, 100, 12, 0, 0, false},
+		{"commodity value converted", Calculator{Rate: quoting(50, 12, "GBP"), Currency: rates},
+			landedRequest("", rate.Money{Amount: 100, Currency: "USD"}), "GBP", 50, 80, 12, 0, 0, true},
+		{"commodity value without a provider", Calculator{Rate: quoting(50, 12, "GBP")},
+			landedRequest("", rate.Money{Amount: 100, Currency: "USD"}), "", 0, 0, 0, 0, 0, false},
+		{"tariff in the commodity currency", Calculator{Rate: quoting(50, 0, "GBP"), Tariff: tariff},
+			landedRequest("", rate.Money{Amount: 100, Currency: "GBP"}), "GBP", 50, 100, 10, 22, 5, false},
+		{"tariff converted", Calculator{Rate: quoting(50, 0, "GBP"), Tariff: tariff, Currency: rates},
+			landedRequest("", rate.Money{Amount: 100, Currency: "USD"}), "GBP", 50, 80, 8, 17.6, 4, true},
+		{"tariff fee in the landed currency", Calculator{Rate: quoting(50, 0, "GBP"), Tariff: gbpFees, Currency: rates},
+			landedRequest("", rate.Money{Amount: 100, Currency: "USD"}), "GBP", 50, 80, 8, 17.6, 5, true},
+		{"tariff fee converted from its currency", Calculator{Rate: quoting(50, 0, "GBP"), Tariff: usdFees, Currency: rates},
+			landedRequest("", rate.Money{Amount: 100, Currency: "GBP"}), "GBP", 50, 100, 10, 22, 4, true},
+		{"tariff fee currency without a provider", Calculator{Rate: quoting(50, 0, "GBP"), Tariff: usdFees},
+			landedRequest("", rate.Money{Amount: 100, Currency: "GBP"}), "", 0, 0, 0, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := tt.calculator.Estimate(tt.request, DDU)
+			if tt.currency == "" {
+				if err == nil {
+					t.Fatalf("Estimate() = %+v, want an error", l)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if l.Currency != tt.currency {
+				t.Errorf("Currency = %s, want %s", l.Currency, tt.currency)
+			}
+			got := []float64{l.Shipping, l.GoodsValue, l.Duties, l.Taxes, l.Fees}
+			want := []float64{tt.shipping, tt.goods, tt.duties, tt.taxes, tt.fees}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Errorf("shipping, goods, duties, taxes, fees = %v, want %v", got, want)
+					break
+				}
+			}
+			if converted := len(l.Conversions) > 0; converted != tt.converted {
+				t.Errorf("Conversions = %+v, want some: %v", l.Conversions, tt.converted)
+			}
+		})
+	}
+}
+
+func TestReadTable(t *testing.T) {
+	table, err := ReadTable(strings.NewReader("country,code,duty,tax,fee,currency\n" +
+		"GB,6109,12%,20%,5,gbp\n" +
+		"GB,61,10,20\n" +
+		"*,,2.5,0,1.25,USD\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		country string
+		code    string
+		want    TariffRate
+		ok      bool
+	}{
+		{"GB", "6109.10.00", TariffRate{Duty: 12, Tax: 20, Fee: 5, FeeCurrency: "GBP"}, true},
+		{"gb", "6110", TariffRate{Duty: 10, Tax: 20}, true},
+		{"DE", "6109", TariffRate{Duty: 2.5, Fee: 1.25, FeeCurrency: "USD"}, true},
+	}
+	for _, tt := range tests {
+		got, ok := table.Lookup(tt.country, tt.code)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("Lookup(%s, %s) = %+v, %v, want %+v", tt.country, tt.code, got, ok, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"GB,61,10\n", "GB,61,10,20\nGB,62,x,20\n", "GB,61,10,20\nGB,62,10,20,5,EURO\n"} {
+		if _, err := ReadTable(strings.NewReader(bad)); err == nil || !strings.Contains(err.Error(), "line") {
+			t.Errorf("ReadTable(%q) = %v, want an error naming the line", bad, err)
+		}
+	}
+}
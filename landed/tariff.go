@@ -0,0 +1,145 @@
+package landed
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Tariff supplies duty and tax rates when FedEx estimates none.
+type Tariff interface {
+	Lookup(country string, harmonizedCode string) (TariffRate, bool)
+}
+
+type TariffRate struct {
+	Duty        float64 // percent of the customs value
+	Tax         float64 // percent of the customs value plus duty, e.g. VAT
+	Fee         float64 // per commodity, in FeeCurrency
+	FeeCurrency string  // that of the commodity's customs value if empty
+}
+
+// Table is a Tariff read from CSV.
+type Table struct {
+	countries map[string][]tableRow // longest prefix first
+}
+
+type tableRow struct {
+	prefix string // harmonized code digits; empty matches all
+	rate   TariffRate
+}
+
+// LoadTable reads a tariff table; see ReadTable.
+func LoadTable(path string) (*Table, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	t, err := ReadTable(f)
+	if err != nil {
+		return nil, errors.New(path + ": " + err.Error())
+	}
+	return t, nil
+}
+
+// ReadTable reads CSV rows of destination country, harmonized code prefix,
+// duty percent, tax percent and optionally a fee and the ISO currency code
+// of the fee, with an optional header. A fee without a currency is in that
+// of each commodity's customs value. A country or prefix of * or empty
+// matches any; the longest matching prefix of the destination wins, then
+// that of *.
+func ReadTable(r io.Reader) (*Table, error) {
+	c := csv.NewReader(r)
+	c.FieldsPerRecord = -1
+	records, err := c.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	t := &Table{countries: map[string][]tableRow{}}
+	for n, record := range records {
+		if len(record) < 4 {
+			return nil, errors.New("landed: line " + strconv.Itoa(n+1) + ": want country, harmonized code, duty, tax")
+		}
+		var values [3]float64
+		var bad bool
+		for i, cell := range record[2:] {
+			if i == 3 {
+				break
+			}
+			if cell = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(cell), "%")); cell == "" {
+				continue
+			}
+			if values[i], err = strconv.ParseFloat(cell, 64); err != nil {
+				bad = true
+			}
+		}
+		var feeCurrency string
+		if len(record) > 5 {
+			feeCurrency = strings.ToUpper(strings.TrimSpace(record[5]))
+		}
+		if bad || !isCurrency(feeCurrency) {
+			if n == 0 {
+				continue // header
+			}
+			if bad {
+				return nil, errors.New("landed: line " + strconv.Itoa(n+1) + ": bad number")
+			}
+			return nil, errors.New("landed: line " + strconv.Itoa(n+1) + ": bad currency " + feeCurrency)
+		}
+		country := strings.ToUpper(strings.TrimSpace(record[0]))
+		if country == "" {
+			country = "*"
+		}
+		t.countries[country] = append(t.countries[country], tableRow{
+			prefix: digits(record[1]),
+			rate:   TariffRate{Duty: values[0], Tax: values[1], Fee: values[2], FeeCurrency: feeCurrency},
+		})
+	}
+	for _, rows := range t.countries {
+		sort.SliceStable(rows, func(i, j int) bool { return len(rows[i].prefix) > len(rows[j].prefix) })
+	}
+	return t, nil
+}
+
+func (t *Table) Lookup(country string, harmonizedCode string) (TariffRate, bool) {
+	code := digits(harmonizedCode)
+	for _, c := range []string{strings.ToUpper(country), "*"} {
+		for _, row := range t.countries[c] {
+			if strings.HasPrefix(code, row.prefix) {
+				return row.rate, true
+			}
+		}
+	}
+	return TariffRate{}, false
+}
+
+// isCurrency reports whether code is empty or three letters.
+func isCurrency(code string) bool {
+	if code == "" {
+		return true
+	}
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// digits strips the dots and spaces of harmonized codes such as 6109.10.00.
+func digits(code string) string {
+	var b strings.Builder
+	for _, r := range code {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
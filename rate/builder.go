@@ -0,0 +1,604 @@
+package rate
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ShipmentBuilder assembles a RateRequest without spelling out its nested
+// structs. Setters may be called in any order; Build fills in the derived
+// totals and validates the result.
+type ShipmentBuilder struct {
+	request RateRequest
+}
+
+func NewShipment(accountNumber string) *ShipmentBuilder {
+	b := &ShipmentBuilder{}
+	b.request.AccountNumber.Value = accountNumber
+	b.request.RequestedShipment.PickupType = DropoffAtFedexLocation
+	return b
+}
+
+// NewShipmentFrom continues building from an existing request, e.g. one read
+// from a file.
+func NewShipmentFrom(request RateRequest) *ShipmentBuilder {
+	return &ShipmentBuilder{request: request}
+}
+
+func (b *ShipmentBuilder) From(address Address) *ShipmentBuilder {
+	b.request.RequestedShipment.Shipper.Address = address
+	return b
+}
+
+func (b *ShipmentBuilder) To(address Address) *ShipmentBuilder {
+	b.request.RequestedShipment.Recipient.Address = address
+	return b
+}
+
+func (b *ShipmentBuilder) Service(serviceType ServiceType) *ShipmentBuilder {
+	b.request.RequestedShipment.ServiceType = serviceType
+	return b
+}
+
+func (b *ShipmentBuilder) Packaging(packagingType PackagingType) *ShipmentBuilder {
+	b.request.RequestedShipment.PackagingType = packagingType
+	return b
+}
+
+func (b *ShipmentBuilder) Pickup(pickupType PickupType) *ShipmentBuilder {
+	b.request.RequestedShipment.PickupType = pickupType
+	return b
+}
+
+func (b *ShipmentBuilder) RateTypes(types ...RateRequestType) *ShipmentBuilder {
+	b.request.RequestedShipment.RateRequestType = append(b.request.RequestedShipment.RateRequestType, types...)
+	return b
+}
+
+func (b *ShipmentBuilder) PreferredCurrency(currency string) *ShipmentBuilder {
+	b.request.RequestedShipment.PreferredCurrency = currency
+	return b
+}
+
+// ShipDate takes the date in FedEx's YYYY-MM-DD format.
+func (b *ShipmentBuilder) ShipDate(date string) *ShipmentBuilder {
+	b.request.RequestedShipment.ShipDateStamp = date
+	return b
+}
+
+func (b *ShipmentBuilder) Documents() *ShipmentBuilder {
+	b.request.RequestedShipment.DocumentShipment = true
+	return b
+}
+
+func (b *ShipmentBuilder) ReturnTransitTimes() *ShipmentBuilder {
+	b.request.RateRequestControlParameters.ReturnTransitTimes = true
+	return b
+}
+
+func (b *ShipmentBuilder) AddPackage(packages ...Package) *ShipmentBuilder {
+	b.request.RequestedShipment.RequestedPackageLineItems = append(b.request.RequestedShipment.RequestedPackageLineItems, packages...)
+	return b
+}
+
+func (b *ShipmentBuilder) SpecialServices(services ...SpecialServiceType) *ShipmentBuilder {
+	special := &b.request.RequestedShipment.ShipmentSpecialServices
+	for _, s := range services {
+		if !hasSpecialService(special.SpecialServiceTypes, s) {
+			special.SpecialServiceTypes = append(special.SpecialServiceTypes, s)
+		}
+	}
+	return b
+}
+
+func (b *ShipmentBuilder) COD(collectionType string, remitToName string) *ShipmentBuilder {
+	detail := &b.request.RequestedShipment.ShipmentSpecialServices.ShipmentCODDetail
+	detail.CodCollectionType = collectionType
+	detail.RemitToName = remitToName
+	return b.SpecialServices(COD)
+}
+
+func (b *ShipmentBuilder) CODRecipientAccount(accountNumber string) *ShipmentBuilder {
+	b.request.RequestedShipment.ShipmentSpecialServices.ShipmentCODDetail.CodRecipient = &Party{AccountNumber: AccountNumber{Value: accountNumber}}
+	return b
+}
+
+func (b *ShipmentBuilder) DryIce(totalWeight float64, units string, packageCount int) *ShipmentBuilder {
+	detail := &b.request.RequestedShipment.ShipmentSpecialServices.ShipmentDryIceDetail
+	detail.TotalWeight = Weight{Units: units, Value: totalWeight}
+	detail.PackageCount = packageCount
+	return b.SpecialServices(DryIce)
+}
+
+func (b *ShipmentBuilder) HoldAtLocation(locationID string, locationType string, address Address) *ShipmentBuilder {
+	b.request.RequestedShipment.ShipmentSpecialServices.HoldAtLocationDetail = &HoldAtLocationDetail{
+		LocationID:                locationID,
+		LocationType:              locationType,
+		LocationContactAndAddress: ContactAndAddress{Address: address},
+	}
+	return b.SpecialServices(HoldAtLocation)
+}
+
+func (b *ShipmentBuilder) ShipmentPurpose(purpose ShipmentPurpose) *ShipmentBuilder {
+	b.request.RequestedShipment.CustomsClearanceDetail.CommercialInvoice.ShipmentPurpose = purpose
+	return b
+}
+
+// DutiesPayment sets who pays duties and taxes, e.g. SENDER (DDP) or RECIPIENT (DDU).
+func (b *ShipmentBuilder) DutiesPayment(paymentType string) *ShipmentBuilder {
+	b.request.RequestedShipment.CustomsClearanceDetail.DutiesPayment.PaymentType = paymentType
+	return b
+}
+
+// EstimateDutiesAndTaxes asks FedEx to estimate the duties and taxes of the
+// commodities, returned in ShipmentRateDetail.DutiesAndTaxes.
+func (b *ShipmentBuilder) EstimateDutiesAndTaxes() *ShipmentBuilder {
+	b.request.RequestedShipment.EdtRequestType = "ALL"
+	return b
+}
+
+func (b *ShipmentBuilder) AddCommodity(commodities ...Commodity) *ShipmentBuilder {
+	customs := &b.request.RequestedShipment.CustomsClearanceDetail
+	customs.Commodities = append(customs.Commodities, commodities...)
+	return b
+}
+
+// Build returns the assembled request, or the request together with a
+// ValidationErrors describing everything that is missing or inconsistent.
+func (b *ShipmentBuilder) Build() (RateRequest, error) {
+	request := b.request
+	shipment := &request.RequestedShipment
+
+	// TotalWeight is in the units of the first package; the others are
+	// converted, so mixing LB and KG packages does not add them up as is.
+	count := 0
+	weight := float64(0)
+	units := ""
+	for _, p := range shipment.RequestedPackageLineItems {
+		n := p.GroupPackageCount
+		if n < 1 {
+			n = 1
+		}
+		count += n
+		if units == "" {
+			units = p.Weight.Units
+		}
+		weight += float64(n) * convertWeight(p.Weight.Value, p.Weight.Units, units)
+	}
+	shipment.TotalPackageCount = count
+	shipment.TotalWeight = math.Round(weight*1000) / 1000
+
+	return request, request.Validate()
+}
+
+// BuildXML produces the equivalent SOAP request for callers still on the
+// Web Services API, special services, customs detail and payments
+// included. ACCOUNT rates are left out of RateRequestTypes, as SOAP always
+// returns them. What the SOAP model cannot carry is rejected rather than
+// silently dropped: variable handling charges, sub packaging types, return,
+// pending, invoice acceptance, export control and home delivery premium
+// details, and alcohol, dangerous goods, battery and piece count details of
+// packages, as well as INCENTIVE and RETAIL rates.
+func (b *ShipmentBuilder) BuildXML(key string, password string, meterNumber string) (RateXMLRequest, error) {
+	var x RateXMLRequest
+	request, err := b.Build()
+	if err != nil {
+		return x, err
+	}
+	shipment := request.RequestedShipment
+	if what := unsupportedByXML(shipment); what != "" {
+		return x, errors.New("rate: " + what + " are not supported by the SOAP rate request")
+	}
+	for i, p := range shipment.RequestedPackageLineItems {
+		if what := p.unsupportedByXML(); what != "" {
+			return x, errors.New("rate: package " + strconv.Itoa(i+1) + ": " + what + " are not supported by the SOAP rate request")
+		}
+	}
+
+	rr := &x.Body.RateRequest
+	rr.WebAuthenticationDetail.UserCredential.Key = key
+	rr.WebAuthenticationDetail.UserCredential.Password = password
+	rr.ClientDetail.AccountNumber = request.AccountNumber.Value
+	rr.ClientDetail.MeterNumber = meterNumber
+	rr.Version.ServiceId = "crs"
+	rr.Version.Major = "28"
+	rr.Version.Intermediate = "0"
+	rr.Version.Minor = "0"
+
+	rs := &rr.RequestedShipment
+	if shipment.ShipDateStamp != "" {
+		rs.ShipTimestamp = shipment.ShipDateStamp + "T12:00:00"
+	}
+	rs.DropoffType = dropoffForPickup(shipment.PickupType)
+	rs.ServiceType = shipment.ServiceType
+	rs.PackagingType = shipment.PackagingType
+	for _, t := range shipment.RateRequestType {
+		if t != RateAccount && !hasRateType(rs.RateRequestTypes, t) {
+			rs.RateRequestTypes = append(rs.RateRequestTypes, t)
+		}
+	}
+	rs.EdtRequestType = shipment.EdtRequestType
+
+	rs.PreferredCurrency = shipment.PreferredCurrency
+
+	rs.Shipper = shipment.Shipper.toXML()
+	rs.Shipper.AccountNumber = request.AccountNumber.Value
+	rs.Recipient = shipment.Recipient.toXML()
+	payment := Payment{PaymentType: "SENDER"}
+	if shipment.ShippingChargesPayment != nil {
+		payment = *shipment.ShippingChargesPayment
+	}
+	rs.ShippingChargesPayment = paymentToXML(payment.PaymentType, payment.Payor)
+	if rs.ShippingChargesPayment.PaymentType == "" {
+		rs.ShippingChargesPayment.PaymentType = "SENDER"
+	}
+	if rs.ShippingChargesPayment.PaymentType == "SENDER" && rs.ShippingChargesPayment.Payor.ResponsibleParty.AccountNumber == "" {
+		rs.ShippingChargesPayment.Payor.ResponsibleParty.AccountNumber = request.AccountNumber.Value
+	}
+	rs.SpecialServicesRequested = shipment.ShipmentSpecialServices.toXML()
+	rs.CustomsClearanceDetail = shipment.CustomsClearanceDetail.toXML()
+
+	rs.TotalWeight.Units = shipment.RequestedPackageLineItems[0].Weight.Units
+	rs.TotalWeight.Value = strconv.FormatFloat(shipment.TotalWeight, 'f', -1, 64)
+	for _, p := range shipment.RequestedPackageLineItems {
+		var item XMLRequestedPackageLineItem
+		if p.GroupPackageCount > 0 {
+			item.GroupPackageCount = strconv.Itoa(p.GroupPackageCount)
+		}
+		item.InsuredValue = moneyToXML(p.DeclaredValue)
+		item.Weight = weightToXML(p.Weight)
+		if p.Dimensions.Units != "" {
+			item.Dimensions.Length = strconv.Itoa(p.Dimensions.Length)
+			item.Dimensions.Width = strconv.Itoa(p.Dimensions.Width)
+			item.Dimensions.Height = strconv.Itoa(p.Dimensions.Height)
+			item.Dimensions.Units = p.Dimensions.Units
+		}
+		item.SpecialServicesRequested = p.PackageSpecialServices.toXML()
+		for _, record := range p.ContentRecord {
+			item.ContentRecords = append(item.ContentRecords, XMLContentRecord{
+				PartNumber:       record.PartNumber,
+				ItemNumber:       record.ItemNumber,
+				ReceivedQuantity: strconv.Itoa(record.ReceivedQuantity),
+				Description:      record.Description,
+			})
+		}
+		rs.RequestedPackageLineItems = append(rs.RequestedPackageLineItems, item)
+	}
+	x = x.withPackageNumbers()
+
+	return x, x.Validate()
+}
+
+// unsupportedByXML names what of shipment the SOAP rate request cannot
+// carry, or returns "".
+func unsupportedByXML(shipment RequestedShipment) string {
+	special := shipment.ShipmentSpecialServices
+	for _, t := range shipment.RateRequestType {
+		if t == RateIncentive || t == RateRetail {
+			return string(t) + " rates"
+		}
+	}
+	switch {
+	case shipment.VariableHandlingChargeDetail != (VariableHandlingChargeDetail{}):
+		return "variable handling charges"
+	case special.ReturnShipmentDetail.ReturnType != "":
+		return "return shipment details"
+	case special.PendingShipmentDetail.PendingShipmentType != "" || special.PendingShipmentDetail.ExpirationTimeStamp != "":
+		return "pending shipment details"
+	case special.DeliveryOnInvoiceAcceptanceDetail.Recipient != nil:
+		return "delivery on invoice acceptance details"
+	case special.InternationalTrafficInArmsRegulationsDetail.LicenseOrExemptionNumber != "" ||
+		special.InternationalControlledExportDetail.Type != "":
+		return "export control details"
+	case special.HomeDeliveryPremiumDetail.HomedeliveryPremiumType != "":
+		return "home delivery premium details"
+	}
+	return ""
+}
+
+// unsupportedByXML names what of the package the SOAP rate request cannot
+// carry, or returns "".
+func (p Package) unsupportedByXML() string {
+	services := p.PackageSpecialServices
+	goods := services.DangerousGoodsDetail
+	switch {
+	case p.VariableHandlingChargeDetail != (VariableHandlingChargeDetail{}):
+		return "variable handling charges"
+	case p.SubPackagingType != "":
+		return "sub packaging types"
+	case services.AlcoholDetail != (AlcoholDetail{}):
+		return "alcohol details"
+	case goods.Offeror != "" || goods.Accessibility != "" || len(goods.Options) > 0 || len(goods.Containers) > 0:
+		return "dangerous goods details"
+	case len(services.BatteryDetails) > 0:
+		return "battery details"
+	case services.PieceCountVerificationBoxCount != 0:
+		return "piece count verifications"
+	}
+	return ""
+}
+
+func (s ShipmentSpecialServices) toXML() *XMLShipmentSpecialServices {
+	x := XMLShipmentSpecialServices{SpecialServiceTypes: s.SpecialServiceTypes}
+	if cod := s.ShipmentCODDetail; hasSpecialService(s.SpecialServiceTypes, COD) {
+		x.CodDetail = &XMLCodDetail{
+			CollectionType:     cod.CodCollectionType,
+			RemitToName:        cod.RemitToName,
+			ReferenceIndicator: cod.ReturnReferenceIndicatorType,
+		}
+		if t := cod.AddTransportationChargesDetail; t.RateType != "" || t.ChargeType != "" || t.ChargeLevelType != "" {
+			x.CodDetail.AddTransportationChargesDetail = &XMLCodTransportationCharges{
+				RateTypeBasis:    t.RateType,
+				ChargeBasis:      t.ChargeType,
+				ChargeBasisLevel: t.ChargeLevelType,
+			}
+		}
+		if r := cod.CodRecipient; r != nil {
+			recipient := r.toXML()
+			x.CodDetail.CodRecipient = &recipient
+		}
+		if f := cod.FinancialInstitutionContactAndAddress; f != nil {
+			institution := f.toXML()
+			x.CodDetail.FinancialInstitutionContactAndAddress = &institution
+		}
+	}
+	if hal := s.HoldAtLocationDetail; hal != nil && hasSpecialService(s.SpecialServiceTypes, HoldAtLocation) {
+		x.HoldAtLocationDetail = &XMLHoldAtLocationDetail{
+			LocationContactAndAddress: hal.LocationContactAndAddress.toXML(),
+			LocationType:              hal.LocationType,
+			LocationId:                hal.LocationID,
+		}
+	}
+	if dryIce := s.ShipmentDryIceDetail; hasSpecialService(s.SpecialServiceTypes, DryIce) {
+		x.ShipmentDryIceDetail = &XMLDryIceDetail{
+			PackageCount: strconv.Itoa(dryIce.PackageCount),
+			TotalWeight:  weightToXML(dryIce.TotalWeight),
+		}
+	}
+	if len(x.SpecialServiceTypes) == 0 {
+		return nil
+	}
+	return &x
+}
+
+func (s PackageSpecialServices) toXML() *XMLPackageSpecialServices {
+	if len(s.SpecialServiceTypes) == 0 {
+		return nil
+	}
+	x := &XMLPackageSpecialServices{SpecialServiceTypes: s.SpecialServiceTypes}
+	if hasSpecialService(s.SpecialServiceTypes, COD) {
+		x.CodDetail = &XMLCodDetail{
+			CodCollectionAmount: moneyToXML(s.PackageCODDetail.CodCollectionAmount),
+			CollectionType:      s.PackageCODDetail.CodCollectionType,
+		}
+	}
+	if hasSpecialService(s.SpecialServiceTypes, DryIce) {
+		weight := weightToXML(s.DryIceWeight)
+		x.DryIceWeight = &weight
+	}
+	return x
+}
+
+// toXML also totals the customs values of the commodities, which SOAP
+// wants for the shipment, when they share a currency.
+func (c CustomsClearanceDetail) toXML() *XMLCustomsClearanceDetail {
+	var x XMLCustomsClearanceDetail
+	if c.DutiesPayment.PaymentType != "" || c.DutiesPayment.Payor.ResponsibleParty != nil {
+		payment := paymentToXML(c.DutiesPayment.PaymentType, c.DutiesPayment.Payor)
+		x.DutiesPayment = &payment
+	}
+	x.FreightOnValue = c.FreightOnValue
+	if c.CommercialInvoice.ShipmentPurpose != "" {
+		x.CommercialInvoice = &XMLCommercialInvoice{Purpose: c.CommercialInvoice.ShipmentPurpose}
+	}
+	total := Money{}
+	for i, commodity := range c.Commodities {
+		if i == 0 {
+			total.Currency = commodity.CustomsValue.Currency
+		}
+		if commodity.CustomsValue.Currency != total.Currency {
+			total.Currency = "-"
+		}
+		total.Amount += commodity.CustomsValue.Amount
+		x.Commodities = append(x.Commodities, commodity.toXML())
+	}
+	if total.Currency != "-" {
+		x.CustomsValue = moneyToXML(total)
+	}
+	if x.DutiesPayment == nil && x.FreightOnValue == "" && x.CommercialInvoice == nil && len(x.Commodities) == 0 {
+		return nil
+	}
+	return &x
+}
+
+func (c Commodity) toXML() XMLCommodity {
+	x := XMLCommodity{
+		Name:                 c.Name,
+		Description:          c.Description,
+		CountryOfManufacture: c.CountryOfManufacture,
+		HarmonizedCode:       c.HarmonizedCode,
+		QuantityUnits:        c.QuantityUnits,
+		UnitPrice:            moneyToXML(c.UnitPrice),
+		CustomsValue:         moneyToXML(c.CustomsValue),
+		PartNumber:           c.PartNumber,
+	}
+	if c.NumberOfPieces != 0 {
+		x.NumberOfPieces = strconv.Itoa(c.NumberOfPieces)
+	}
+	if c.Quantity != 0 {
+		x.Quantity = strconv.Itoa(c.Quantity)
+	}
+	if c.Weight.Units != "" {
+		weight := weightToXML(c.Weight)
+		x.Weight = &weight
+	}
+	return x
+}
+
+func (c ContactAndAddress) toXML() XMLContactAndAddress {
+	var x XMLContactAndAddress
+	party := Party{Address: c.Address, Contact: c.Contact}.toXML()
+	if party.Contact != nil {
+		x.Contact = *party.Contact
+	}
+	if party.Address != nil {
+		x.Address = *party.Address
+	}
+	return x
+}
+
+func paymentToXML(paymentType string, payor Payor) XMLPayment {
+	x := XMLPayment{PaymentType: paymentType}
+	if payor.ResponsibleParty != nil {
+		x.Payor.ResponsibleParty = payor.ResponsibleParty.toXML()
+	}
+	return x
+}
+
+// moneyToXML returns nil for no amount, leaving the element out.
+func moneyToXML(m Money) *XMLMoney {
+	if m.Amount == 0 && m.Currency == "" {
+		return nil
+	}
+	return &XMLMoney{Currency: m.Currency, Amount: strconv.FormatFloat(m.Amount, 'f', 2, 64)}
+}
+
+func weightToXML(w Weight) XMLWeight {
+	return XMLWeight{Units: w.Units, Value: strconv.FormatFloat(w.Value, 'f', -1, 64)}
+}
+
+func hasRateType(types []RateRequestType, t RateRequestType) bool {
+	for _, have := range types {
+		if have == t {
+			return true
+		}
+	}
+	return false
+}
+
+// toXML maps the party's address and contact; the account number is left
+// to the caller.
+// toXML maps the contact and address only when they are set, so a party
+// known by its account alone stays just that.
+func (p Party) toXML() XMLParty {
+	x := XMLParty{AccountNumber: p.AccountNumber.Value}
+	if p.Contact != (Contact{}) {
+		x.Contact = &XMLContact{
+			PersonName:     p.Contact.PersonName,
+			CompanyName:    p.Contact.CompanyName,
+			PhoneNumber:    p.Contact.PhoneNumber,
+			PhoneExtension: p.Contact.PhoneExtension,
+			FaxNumber:      p.Contact.FaxNumber,
+			EMailAddress:   p.Contact.EmailAddress,
+		}
+	}
+	if !p.Address.isZero() {
+		x.Address = &XMLAddress{
+			StreetLines:         p.Address.StreetLines,
+			City:                p.Address.City,
+			StateOrProvinceCode: p.Address.StateOrProvinceCode,
+			PostalCode:          p.Address.PostalCode,
+			CountryCode:         p.Address.CountryCode,
+			Residential:         strconv.FormatBool(p.Address.Residential),
+		}
+	}
+	return x
+}
+
+func (a Address) isZero() bool {
+	return len(a.StreetLines) == 0 && a.City == "" && a.StateOrProvinceCode == "" &&
+		a.PostalCode == "" && a.CountryCode == "" && !a.Residential
+}
+
+// convertWeight converts value between LB and KG; other units are left
+// to Validate.
+func convertWeight(value float64, from string, to string) float64 {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	switch {
+	case from == "KG" && to == "LB":
+		return value * poundsPerKilogram
+	case from == "LB" && to == "KG":
+		return value / poundsPerKilogram
+	}
+	return value
+}
+
+const poundsPerKilogram = 2.20462262185
+
+func dropoffForPickup(pickupType PickupType) DropoffType {
+	switch pickupType {
+	case ContactFedexToSchedule:
+		return RequestCourier
+	case UseScheduledPickup:
+		return RegularPickup
+	}
+	return BusinessServiceCenter
+}
+
+func NewPackage(weight float64, units string) Package {
+	return Package{Weight: Weight{Units: units, Value: weight}}
+}
+
+func (p Package) WithDimensions(length int, width int, height int, units string) Package {
+	p.Dimensions = Dimensions{Length: length, Width: width, Height: height, Units: units}
+	return p
+}
+
+func (p Package) WithDeclaredValue(amount float64, currency string) Package {
+	p.DeclaredValue = Money{Amount: amount, Currency: currency}
+	return p
+}
+
+// WithCount rates the line item as n identical packages.
+func (p Package) WithCount(n int) Package {
+	p.GroupPackageCount = n
+	return p
+}
+
+func (p Package) WithSpecialServices(services ...SpecialServiceType) Package {
+	// copy so packages derived from the same value don't share a backing array
+	types := append([]SpecialServiceType(nil), p.PackageSpecialServices.SpecialServiceTypes...)
+	for _, s := range services {
+		if !hasSpecialService(types, s) {
+			types = append(types, s)
+		}
+	}
+	p.PackageSpecialServices.SpecialServiceTypes = types
+	return p
+}
+
+func (p Package) WithDryIce(weight float64, units string) Package {
+	p.PackageSpecialServices.DryIceWeight = Weight{Units: units, Value: weight}
+	return p.WithSpecialServices(DryIce)
+}
+
+func (p Package) WithCOD(amount float64, currency string, collectionType string) Package {
+	p.PackageSpecialServices.PackageCODDetail = PackageCODDetail{
+		CodCollectionAmount: Money{Amount: amount, Currency: currency},
+		CodCollectionType:   collectionType,
+	}
+	return p.WithSpecialServices(COD)
+}
+
+func NewCommodity(description string, quantity int, quantityUnits string, value float64, currency string) Commodity {
+	return Commodity{
+		Description:    description,
+		Quantity:       quantity,
+		QuantityUnits:  quantityUnits,
+		NumberOfPieces: quantity,
+		CustomsValue:   Money{Amount: value, Currency: currency},
+	}
+}
+
+func (c Commodity) WithWeight(value float64, units string) Commodity {
+	c.Weight = Weight{Units: units, Value: value}
+	return c
+}
+
+func (c Commodity) WithOrigin(countryOfManufacture string, harmonizedCode string) Commodity {
+	c.CountryOfManufacture = countryOfManufacture
+	c.HarmonizedCode = harmonizedCode
+	return c
+}
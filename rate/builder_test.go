@@ -0,0 +1,269 @@
+package rate
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func testShipment(packages ...Package) *ShipmentBuilder {
+	return NewShipment("123456789").
+		From(Address{CountryCode: "US", PostalCode: "38017", Residential: true}).
+		To(Address{CountryCode: "US", PostalCode: "90210"}).
+		Service(FedexGround).
+		Packaging(YourPackaging).
+		AddPackage(packages...)
+}
+
+func TestBuildTotalWeight(t *testing.T) {
+	tests := []struct {
+		name     string
+		packages []Package
+		want     float64
+	}{
+		{"pounds", []Package{NewPackage(5, "LB"), NewPackage(2.5, "LB").WithCount(2)}, 10},
+		{"kilograms into pounds", []Package{NewPackage(10, "LB"), NewPackage(1, "KG")}, 12.205},
+		{"pounds into kilograms", []Package{NewPackage(1, "KG"), NewPackage(2.20462262185, "LB")}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := testShipment(tt.packages...).Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := request.RequestedShipment.TotalWeight; got != tt.want {
+				t.Errorf("TotalWeight = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildXMLRejectsUnsupported(t *testing.T) {
+	alcohol := NewPackage(5, "LB")
+	alcohol.PackageSpecialServices.AlcoholDetail.AlcoholRecipientType = "CONSUMER"
+	batteries := NewPackage(5, "LB")
+	batteries.PackageSpecialServices.BatteryDetails = []BatteryDetail{{Material: "LITHIUM_ION"}}
+	handling := NewPackage(5, "LB")
+	handling.VariableHandlingChargeDetail.FixedValue.Amount = 5
+	tests := []struct {
+		name string
+		b    *ShipmentBuilder
+		want string
+	}{
+		{"package alcohol", testShipment(alcohol), "package 1: alcohol details"},
+		{"package batteries", testShipment(batteries), "package 1: battery details"},
+		{"package handling", testShipment(handling), "package 1: variable handling charges"},
+		{"retail rates", testShipment(NewPackage(5, "LB")).RateTypes(RateRetail), "RETAIL rates"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.b.BuildXML("key", "password", "meter")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("BuildXML() = %v, want an error about %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildXMLRateTypesAndPayment(t *testing.T) {
+	b := testShipment(NewPackage(5, "LB")).RateTypes(RateList, RatePreferred, RateAccount)
+	b.request.RequestedShipment.ShippingChargesPayment = &Payment{
+		PaymentType: "THIRD_PARTY",
+		Payor:       Payor{ResponsibleParty: &Party{AccountNumber: AccountNumber{Value: "987654321"}}},
+	}
+	x, err := b.BuildXML("key", "password", "meter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs := x.Body.RateRequest.RequestedShipment
+	if got := strings.Join(rateTypes(rs.RateRequestTypes), ","); got != "LIST,PREFERRED" {
+		t.Errorf("RateRequestTypes = %s, want LIST,PREFERRED", got)
+	}
+	if p := rs.ShippingChargesPayment; p.PaymentType != "THIRD_PARTY" || p.Payor.ResponsibleParty.AccountNumber != "987654321" {
+		t.Errorf("ShippingChargesPayment = %+v, want THIRD_PARTY 987654321", p)
+	}
+
+	request := x.ToRateRequest()
+	if got := strings.Join(rateTypes(request.RequestedShipment.RateRequestType), ","); got != "ACCOUNT,LIST,PREFERRED" {
+		t.Errorf("RateRequestType = %s, want ACCOUNT,LIST,PREFERRED", got)
+	}
+
+	x, err = testShipment(NewPackage(5, "LB")).BuildXML("key", "password", "meter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := x.Body.RateRequest.RequestedShipment.ShippingChargesPayment; p.PaymentType != "SENDER" || p.Payor.ResponsibleParty.AccountNumber != "123456789" {
+		t.Errorf("default ShippingChargesPayment = %+v, want SENDER 123456789", p)
+	}
+}
+
+func TestBuildXMLMapsSpecialServices(t *testing.T) {
+	b := testShipment(NewPackage(5, "LB").WithCOD(100, "USD", "ANY"), NewPackage(4, "LB").WithDryIce(1, "KG")).
+		COD("CASH", "Ann Shipper").
+		CODRecipientAccount("555000111").
+		DryIce(1, "KG", 1).
+		SpecialServices(SaturdayDelivery)
+	x, err := b.BuildXML("key", "password", "meter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs := x.Body.RateRequest.RequestedShipment
+	special := rs.SpecialServicesRequested
+	if special == nil || special.CodDetail == nil || special.ShipmentDryIceDetail == nil {
+		t.Fatalf("SpecialServicesRequested = %+v, want COD and dry ice", special)
+	}
+	if special.CodDetail.CollectionType != "CASH" || special.CodDetail.RemitToName != "Ann Shipper" ||
+		special.CodDetail.CodRecipient == nil || special.CodDetail.CodRecipient.AccountNumber != "555000111" {
+		t.Errorf("CodDetail = %+v", special.CodDetail)
+	}
+	if d := special.ShipmentDryIceDetail; d.PackageCount != "1" || d.TotalWeight.Value != "1" || d.TotalWeight.Units != "KG" {
+		t.Errorf("ShipmentDryIceDetail = %+v", d)
+	}
+	cod := rs.RequestedPackageLineItems[0].SpecialServicesRequested
+	if cod == nil || cod.CodDetail == nil || cod.CodDetail.CodCollectionAmount == nil || cod.CodDetail.CodCollectionAmount.Amount != "100.00" {
+		t.Errorf("package 1 SpecialServicesRequested = %+v, want a 100.00 COD", cod)
+	}
+	dryIce := rs.RequestedPackageLineItems[1].SpecialServicesRequested
+	if dryIce == nil || dryIce.DryIceWeight == nil || dryIce.DryIceWeight.Value != "1" {
+		t.Errorf("package 2 SpecialServicesRequested = %+v, want 1 KG of dry ice", dryIce)
+	}
+
+	// and back again
+	request := x.ToRateRequest()
+	got := request.RequestedShipment.ShipmentSpecialServices
+	if got.ShipmentCODDetail.CodCollectionType != "CASH" || got.ShipmentCODDetail.CodRecipient == nil ||
+		got.ShipmentCODDetail.CodRecipient.AccountNumber.Value != "555000111" ||
+		got.ShipmentDryIceDetail.PackageCount != 1 || len(got.SpecialServiceTypes) != 3 {
+		t.Errorf("ShipmentSpecialServices = %+v", got)
+	}
+	if got := request.RequestedShipment.RequestedPackageLineItems[0].PackageSpecialServices.PackageCODDetail.CodCollectionAmount; got != (Money{Amount: 100, Currency: "USD"}) {
+		t.Errorf("package COD amount = %+v", got)
+	}
+}
+
+func TestBuildXMLMapsHoldAtLocation(t *testing.T) {
+	b := testShipment(NewPackage(5, "LB")).
+		HoldAtLocation("MEMA", "FEDEX_OFFICE", Address{CountryCode: "US", PostalCode: "38118", City: "Memphis"})
+	x, err := b.BuildXML("key", "password", "meter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hal := x.Body.RateRequest.RequestedShipment.SpecialServicesRequested.HoldAtLocationDetail
+	if hal == nil || hal.LocationId != "MEMA" || hal.LocationType != "FEDEX_OFFICE" || hal.LocationContactAndAddress.Address.PostalCode != "38118" {
+		t.Fatalf("HoldAtLocationDetail = %+v", hal)
+	}
+	got := x.ToRateRequest().RequestedShipment.ShipmentSpecialServices.HoldAtLocationDetail
+	if got == nil || got.LocationID != "MEMA" || got.LocationContactAndAddress.Address.City != "Memphis" {
+		t.Errorf("HoldAtLocationDetail = %+v", got)
+	}
+}
+
+func TestBuildXMLMapsCustoms(t *testing.T) {
+	b := NewShipment("123456789").
+		From(Address{CountryCode: "US", PostalCode: "38017"}).
+		To(Address{CountryCode: "CA", PostalCode: "M5V3L9"}).
+		Service(InternationalPriority).
+		Packaging(YourPackaging).
+		AddPackage(NewPackage(5, "LB")).
+		ShipmentPurpose("SOLD").
+		DutiesPayment("RECIPIENT").
+		EstimateDutiesAndTaxes().
+		AddCommodity(
+			Commodity{Description: "Shirts", Quantity: 2, QuantityUnits: "EA", NumberOfPieces: 2,
+				CustomsValue: Money{Amount: 40, Currency: "USD"}, Weight: Weight{Units: "LB", Value: 1}, CountryOfManufacture: "US"},
+			Commodity{Description: "Shoes", Quantity: 1, QuantityUnits: "EA", NumberOfPieces: 1,
+				CustomsValue: Money{Amount: 60, Currency: "USD"}, Weight: Weight{Units: "LB", Value: 2}, CountryOfManufacture: "US"},
+		)
+	x, err := b.BuildXML("key", "password", "meter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs := x.Body.RateRequest.RequestedShipment
+	if rs.EdtRequestType != "ALL" {
+		t.Errorf("EdtRequestType = %q, want ALL", rs.EdtRequestType)
+	}
+	customs := rs.CustomsClearanceDetail
+	if customs == nil || customs.DutiesPayment == nil || customs.DutiesPayment.PaymentType != "RECIPIENT" ||
+		customs.CommercialInvoice == nil || customs.CommercialInvoice.Purpose != "SOLD" {
+		t.Fatalf("CustomsClearanceDetail = %+v", customs)
+	}
+	if v := customs.CustomsValue; v == nil || v.Amount != "100.00" || v.Currency != "USD" {
+		t.Errorf("CustomsValue = %+v, want 100.00 USD", v)
+	}
+	if len(customs.Commodities) != 2 || customs.Commodities[1].Description != "Shoes" || customs.Commodities[1].Quantity != "1" {
+		t.Errorf("Commodities = %+v", customs.Commodities)
+	}
+
+	// and back again
+	got := x.ToRateRequest().RequestedShipment.CustomsClearanceDetail
+	if got.DutiesPayment.PaymentType != "RECIPIENT" || got.CommercialInvoice.ShipmentPurpose != "SOLD" ||
+		len(got.Commodities) != 2 || got.Commodities[0].CustomsValue != (Money{Amount: 40, Currency: "USD"}) {
+		t.Errorf("CustomsClearanceDetail = %+v", got)
+	}
+}
+
+func TestBuildXMLMapsPartiesAndDeclaredValue(t *testing.T) {
+	b := testShipment(NewPackage(5, "LB").WithDeclaredValue(250, "USD")).PreferredCurrency("EUR")
+	b.request.RequestedShipment.Shipper.Contact = Contact{PersonName: "Ann Shipper", PhoneNumber: "9015550100", EmailAddress: "ann@example.com"}
+	x, err := b.BuildXML("key", "password", "meter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs := x.Body.RateRequest.RequestedShipment
+	if rs.Shipper.Address.Residential != "true" {
+		t.Errorf("shipper Residential = %q, want true", rs.Shipper.Address.Residential)
+	}
+	if c := rs.Shipper.Contact; c.PersonName != "Ann Shipper" || c.PhoneNumber != "9015550100" || c.EMailAddress != "ann@example.com" {
+		t.Errorf("shipper contact = %+v", c)
+	}
+	if rs.PreferredCurrency != "EUR" {
+		t.Errorf("PreferredCurrency = %q, want EUR", rs.PreferredCurrency)
+	}
+	v := rs.RequestedPackageLineItems[0].InsuredValue
+	if v == nil || v.Amount != "250.00" || v.Currency != "USD" {
+		t.Fatalf("InsuredValue = %+v, want 250.00 USD", v)
+	}
+
+	// and back again
+	request := x.ToRateRequest()
+	if got := request.RequestedShipment.RequestedPackageLineItems[0].DeclaredValue; got != (Money{Amount: 250, Currency: "USD"}) {
+		t.Errorf("DeclaredValue = %+v", got)
+	}
+	if got := request.RequestedShipment.Shipper.Contact.EmailAddress; got != "ann@example.com" {
+		t.Errorf("shipper email = %q", got)
+	}
+}
+
+func TestMinimalRequestHasNoEmptyParties(t *testing.T) {
+	b := testShipment(NewPackage(5, "LB"))
+	request, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := json.Marshal(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(content), `"address"`); n != 2 {
+		t.Errorf("request has %d addresses, want the shipper's and recipient's only: %s", n, content)
+	}
+	if strings.Contains(string(content), `"postalCode":""`) || strings.Contains(string(content), `"responsibleParty"`) {
+		t.Errorf("request has an empty party: %s", content)
+	}
+
+	x, err := b.BuildXML("key", "password", "meter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err = xml.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(content), "<Address>"); n != 2 {
+		t.Errorf("SOAP request has %d addresses, want the shipper's and recipient's only: %s", n, content)
+	}
+	if strings.Contains(string(content), "<Contact></Contact>") {
+		t.Errorf("SOAP request has an empty contact: %s", content)
+	}
+}
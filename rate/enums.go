@@ -0,0 +1,451 @@
+package rate
+
+import "strings"
+
+// The enum types below list the values known when this package was written.
+// Others are encoded and decoded as they are, only normalized to FedEx's
+// case when decoded, as FedEx adds values over time; Validate reports them
+// so typos still fail locally.
+
+type ServiceType string
+
+const (
+	FedexGround                       ServiceType = "FEDEX_GROUND"
+	GroundHomeDelivery                ServiceType = "GROUND_HOME_DELIVERY"
+	SmartPost                         ServiceType = "SMART_POST"
+	FedexExpressSaver                 ServiceType = "FEDEX_EXPRESS_SAVER"
+	Fedex2Day                         ServiceType = "FEDEX_2_DAY"
+	Fedex2DayAM                       ServiceType = "FEDEX_2_DAY_AM"
+	StandardOvernight                 ServiceType = "STANDARD_OVERNIGHT"
+	PriorityOvernight                 ServiceType = "PRIORITY_OVERNIGHT"
+	FirstOvernight                    ServiceType = "FIRST_OVERNIGHT"
+	SameDay                           ServiceType = "SAME_DAY"
+	SameDayCity                       ServiceType = "SAME_DAY_CITY"
+	Fedex1DayFreight                  ServiceType = "FEDEX_1_DAY_FREIGHT"
+	Fedex2DayFreight                  ServiceType = "FEDEX_2_DAY_FREIGHT"
+	Fedex3DayFreight                  ServiceType = "FEDEX_3_DAY_FREIGHT"
+	FedexFirstFreight                 ServiceType = "FEDEX_FIRST_FREIGHT"
+	FedexFreightEconomy               ServiceType = "FEDEX_FREIGHT_ECONOMY"
+	FedexFreightPriority              ServiceType = "FEDEX_FREIGHT_PRIORITY"
+	FedexRegionalEconomy              ServiceType = "FEDEX_REGIONAL_ECONOMY"
+	FedexRegionalEconomyFreight       ServiceType = "FEDEX_REGIONAL_ECONOMY_FREIGHT"
+	InternationalEconomy              ServiceType = "INTERNATIONAL_ECONOMY"
+	InternationalFirst                ServiceType = "INTERNATIONAL_FIRST"
+	InternationalPriority             ServiceType = "INTERNATIONAL_PRIORITY"
+	InternationalPriorityExpress      ServiceType = "INTERNATIONAL_PRIORITY_EXPRESS"
+	FedexInternationalPriority        ServiceType = "FEDEX_INTERNATIONAL_PRIORITY"
+	FedexInternationalPriorityExpress ServiceType = "FEDEX_INTERNATIONAL_PRIORITY_EXPRESS"
+	FedexInternationalConnectPlus     ServiceType = "FEDEX_INTERNATIONAL_CONNECT_PLUS"
+	FedexInternationalDeferredFreight ServiceType = "FEDEX_INTERNATIONAL_DEFERRED_FREIGHT"
+	InternationalEconomyFreight       ServiceType = "INTERNATIONAL_ECONOMY_FREIGHT"
+	InternationalPriorityFreight      ServiceType = "INTERNATIONAL_PRIORITY_FREIGHT"
+	InternationalDistributionFreight  ServiceType = "INTERNATIONAL_DISTRIBUTION_FREIGHT"
+	EuropeFirstInternationalPriority  ServiceType = "EUROPE_FIRST_INTERNATIONAL_PRIORITY"
+)
+
+const (
+	CarrierExpress        = "FDXE"
+	CarrierGround         = "FDXG"
+	CarrierSmartPost      = "FXSP"
+	CarrierFreight        = "FXFR"
+	CarrierCustomCritical = "FXCC"
+)
+
+type ServiceInfo struct {
+	DisplayName   string  //
+	CarrierCode   string  //
+	Domestic      bool    //
+	International bool    //
+	MaxWeight     float64 // per package, in LB
+}
+
+var serviceInfo = map[ServiceType]ServiceInfo{
+	FedexGround:                       {"FedEx Ground", CarrierGround, true, true, 150},
+	GroundHomeDelivery:                {"FedEx Home Delivery", CarrierGround, true, false, 150},
+	SmartPost:                         {"FedEx Ground Economy", CarrierSmartPost, true, false, 70},
+	FedexExpressSaver:                 {"FedEx Express Saver", CarrierExpress, true, false, 150},
+	Fedex2Day:                         {"FedEx 2Day", CarrierExpress, true, false, 150},
+	Fedex2DayAM:                       {"FedEx 2Day A.M.", CarrierExpress, true, false, 150},
+	StandardOvernight:                 {"FedEx Standard Overnight", CarrierExpress, true, false, 150},
+	PriorityOvernight:                 {"FedEx Priority Overnight", CarrierExpress, true, false, 150},
+	FirstOvernight:                    {"FedEx First Overnight", CarrierExpress, true, false, 150},
+	SameDay:                           {"FedEx SameDay", CarrierCustomCritical, true, false, 150},
+	SameDayCity:                       {"FedEx SameDay City", CarrierCustomCritical, true, false, 150},
+	Fedex1DayFreight:                  {"FedEx 1Day Freight", CarrierExpress, true, false, 2200},
+	Fedex2DayFreight:                  {"FedEx 2Day Freight", CarrierExpress, true, false, 2200},
+	Fedex3DayFreight:                  {"FedEx 3Day Freight", CarrierExpress, true, false, 2200},
+	FedexFirstFreight:                 {"FedEx First Overnight Freight", CarrierExpress, true, false, 2200},
+	FedexFreightEconomy:               {"FedEx Freight Economy", CarrierFreight, true, false, 20000},
+	FedexFreightPriority:              {"FedEx Freight Priority", CarrierFreight, true, false, 20000},
+	FedexRegionalEconomy:              {"FedEx Regional Economy", CarrierExpress, true, true, 150},
+	FedexRegionalEconomyFreight:       {"FedEx Regional Economy Freight", CarrierExpress, true, true, 2200},
+	InternationalEconomy:              {"FedEx International Economy", CarrierExpress, false, true, 150},
+	InternationalFirst:                {"FedEx International First", CarrierExpress, false, true, 150},
+	InternationalPriority:             {"FedEx International Priority", CarrierExpress, false, true, 150},
+	InternationalPriorityExpress:      {"FedEx International Priority Express", CarrierExpress, false, true, 150},
+	FedexInternationalPriority:        {"FedEx International Priority", CarrierExpress, false, true, 150},
+	FedexInternationalPriorityExpress: {"FedEx International Priority Express", CarrierExpress, false, true, 150},
+	FedexInternationalConnectPlus:     {"FedEx International Connect Plus", CarrierExpress, false, true, 150},
+	FedexInternationalDeferredFreight: {"FedEx International Deferred Freight", CarrierExpress, false, true, 2200},
+	InternationalEconomyFreight:       {"FedEx International Economy Freight", CarrierExpress, false, true, 2200},
+	InternationalPriorityFreight:      {"FedEx International Priority Freight", CarrierExpress, false, true, 2200},
+	InternationalDistributionFreight:  {"FedEx International Distribution Freight", CarrierExpress, false, true, 2200},
+	EuropeFirstInternationalPriority:  {"FedEx Europe First International Priority", CarrierExpress, false, true, 150},
+}
+
+func (s ServiceType) String() string { return string(s) }
+
+func (s ServiceType) Valid() bool {
+	_, ok := serviceInfo[s]
+	return ok
+}
+
+// Info returns the descriptive metadata for a documented service type.
+func (s ServiceType) Info() (ServiceInfo, bool) {
+	info, ok := serviceInfo[s]
+	return info, ok
+}
+
+func (s ServiceType) DisplayName() string {
+	if info, ok := serviceInfo[s]; ok {
+		return info.DisplayName
+	}
+	return string(s)
+}
+
+// UnmarshalText accepts the values as FedEx spells them but in any case and
+// with spaces or hyphens for underscores, e.g. "fedex ground" in a request
+// file; see normalizeEnum.
+func (s *ServiceType) UnmarshalText(text []byte) error {
+	*s = ServiceType(normalizeEnum(text))
+	return nil
+}
+
+type PackagingType string
+
+const (
+	YourPackaging      PackagingType = "YOUR_PACKAGING"
+	FedexEnvelope      PackagingType = "FEDEX_ENVELOPE"
+	FedexBox           PackagingType = "FEDEX_BOX"
+	FedexSmallBox      PackagingType = "FEDEX_SMALL_BOX"
+	FedexMediumBox     PackagingType = "FEDEX_MEDIUM_BOX"
+	FedexLargeBox      PackagingType = "FEDEX_LARGE_BOX"
+	FedexExtraLargeBox PackagingType = "FEDEX_EXTRA_LARGE_BOX"
+	Fedex10kgBox       PackagingType = "FEDEX_10KG_BOX"
+	Fedex25kgBox       PackagingType = "FEDEX_25KG_BOX"
+	FedexPak           PackagingType = "FEDEX_PAK"
+	FedexTube          PackagingType = "FEDEX_TUBE"
+)
+
+type PackagingInfo struct {
+	DisplayName string  //
+	MaxWeight   float64 // in LB
+}
+
+var packagingInfo = map[PackagingType]PackagingInfo{
+	YourPackaging:      {"Your Packaging", 150},
+	FedexEnvelope:      {"FedEx Envelope", 10},
+	FedexBox:           {"FedEx Box", 50},
+	FedexSmallBox:      {"FedEx Small Box", 50},
+	FedexMediumBox:     {"FedEx Medium Box", 50},
+	FedexLargeBox:      {"FedEx Large Box", 50},
+	FedexExtraLargeBox: {"FedEx Extra Large Box", 50},
+	Fedex10kgBox:       {"FedEx 10kg Box", 22},
+	Fedex25kgBox:       {"FedEx 25kg Box", 55},
+	FedexPak:           {"FedEx Pak", 50},
+	FedexTube:          {"FedEx Tube", 50},
+}
+
+func (p PackagingType) String() string { return string(p) }
+
+func (p PackagingType) Valid() bool {
+	_, ok := packagingInfo[p]
+	return ok
+}
+
+func (p PackagingType) Info() (PackagingInfo, bool) {
+	info, ok := packagingInfo[p]
+	return info, ok
+}
+
+func (p PackagingType) DisplayName() string {
+	if info, ok := packagingInfo[p]; ok {
+		return info.DisplayName
+	}
+	return string(p)
+}
+
+func (p *PackagingType) UnmarshalText(text []byte) error {
+	*p = PackagingType(normalizeEnum(text))
+	return nil
+}
+
+type PickupType string
+
+const (
+	ContactFedexToSchedule PickupType = "CONTACT_FEDEX_TO_SCHEDULE"
+	DropoffAtFedexLocation PickupType = "DROPOFF_AT_FEDEX_LOCATION"
+	UseScheduledPickup     PickupType = "USE_SCHEDULED_PICKUP"
+)
+
+var pickupNames = map[PickupType]string{
+	ContactFedexToSchedule: "Contact FedEx to schedule",
+	DropoffAtFedexLocation: "Drop off at FedEx location",
+	UseScheduledPickup:     "Use scheduled pickup",
+}
+
+func (p PickupType) String() string { return string(p) }
+
+func (p PickupType) Valid() bool {
+	_, ok := pickupNames[p]
+	return ok
+}
+
+func (p PickupType) DisplayName() string {
+	if name, ok := pickupNames[p]; ok {
+		return name
+	}
+	return string(p)
+}
+
+func (p *PickupType) UnmarshalText(text []byte) error {
+	*p = PickupType(normalizeEnum(text))
+	return nil
+}
+
+// DropoffType is the SOAP counterpart of PickupType.
+type DropoffType string
+
+const (
+	BusinessServiceCenter DropoffType = "BUSINESS_SERVICE_CENTER"
+	DropBox               DropoffType = "DROP_BOX"
+	RegularPickup         DropoffType = "REGULAR_PICKUP"
+	RequestCourier        DropoffType = "REQUEST_COURIER"
+	Station               DropoffType = "STATION"
+)
+
+var dropoffNames = map[DropoffType]string{
+	BusinessServiceCenter: "Business service center",
+	DropBox:               "Drop box",
+	RegularPickup:         "Regular pickup",
+	RequestCourier:        "Request courier",
+	Station:               "Station",
+}
+
+func (d DropoffType) String() string { return string(d) }
+
+func (d DropoffType) Valid() bool {
+	_, ok := dropoffNames[d]
+	return ok
+}
+
+func (d DropoffType) DisplayName() string {
+	if name, ok := dropoffNames[d]; ok {
+		return name
+	}
+	return string(d)
+}
+
+func (d *DropoffType) UnmarshalText(text []byte) error {
+	*d = DropoffType(normalizeEnum(text))
+	return nil
+}
+
+type RateRequestType string
+
+const (
+	RateList      RateRequestType = "LIST"
+	RateAccount   RateRequestType = "ACCOUNT"
+	RatePreferred RateRequestType = "PREFERRED"
+	RateIncentive RateRequestType = "INCENTIVE"
+	RateRetail    RateRequestType = "RETAIL"
+	RateNone      RateRequestType = "NONE" // SOAP only
+)
+
+var rateRequestNames = map[RateRequestType]string{
+	RateList:      "List rates",
+	RateAccount:   "Account rates",
+	RatePreferred: "Preferred currency rates",
+	RateIncentive: "Incentive rates",
+	RateRetail:    "Retail rates",
+	RateNone:      "Account rates only",
+}
+
+func (r RateRequestType) String() string { return string(r) }
+
+func (r RateRequestType) Valid() bool {
+	_, ok := rateRequestNames[r]
+	return ok
+}
+
+func (r RateRequestType) DisplayName() string {
+	if name, ok := rateRequestNames[r]; ok {
+		return name
+	}
+	return string(r)
+}
+
+func (r *RateRequestType) UnmarshalText(text []byte) error {
+	*r = RateRequestType(normalizeEnum(text))
+	return nil
+}
+
+type SpecialServiceType string
+
+const (
+	Alcohol                       SpecialServiceType = "ALCOHOL"
+	AppointmentDelivery           SpecialServiceType = "APPOINTMENT_DELIVERY"
+	Battery                       SpecialServiceType = "BATTERY"
+	BrokerSelectOption            SpecialServiceType = "BROKER_SELECT_OPTION"
+	CallBeforeDelivery            SpecialServiceType = "CALL_BEFORE_DELIVERY"
+	COD                           SpecialServiceType = "COD"
+	CustomDeliveryWindow          SpecialServiceType = "CUSTOM_DELIVERY_WINDOW"
+	CutFlowers                    SpecialServiceType = "CUT_FLOWERS"
+	DangerousGoods                SpecialServiceType = "DANGEROUS_GOODS"
+	DateCertain                   SpecialServiceType = "DATE_CERTAIN"
+	DeliveryOnInvoiceAcceptance   SpecialServiceType = "DELIVERY_ON_INVOICE_ACCEPTANCE"
+	DryIce                        SpecialServiceType = "DRY_ICE"
+	ElectronicTradeDocuments      SpecialServiceType = "ELECTRONIC_TRADE_DOCUMENTS"
+	Evening                       SpecialServiceType = "EVENING"
+	EventNotification             SpecialServiceType = "EVENT_NOTIFICATION"
+	FedexOneRate                  SpecialServiceType = "FEDEX_ONE_RATE"
+	FutureDayShipment             SpecialServiceType = "FUTURE_DAY_SHIPMENT"
+	HoldAtLocation                SpecialServiceType = "HOLD_AT_LOCATION"
+	HomeDeliveryPremium           SpecialServiceType = "HOME_DELIVERY_PREMIUM"
+	InsideDelivery                SpecialServiceType = "INSIDE_DELIVERY"
+	InsidePickup                  SpecialServiceType = "INSIDE_PICKUP"
+	InternationalControlledExport SpecialServiceType = "INTERNATIONAL_CONTROLLED_EXPORT_SERVICE"
+	InternationalTrafficInArms    SpecialServiceType = "INTERNATIONAL_TRAFFIC_IN_ARMS_REGULATIONS"
+	LiftgateDelivery              SpecialServiceType = "LIFTGATE_DELIVERY"
+	LiftgatePickup                SpecialServiceType = "LIFTGATE_PICKUP"
+	LimitedAccessDelivery         SpecialServiceType = "LIMITED_ACCESS_DELIVERY"
+	LimitedAccessPickup           SpecialServiceType = "LIMITED_ACCESS_PICKUP"
+	NonStandardContainer          SpecialServiceType = "NON_STANDARD_CONTAINER"
+	PendingShipment               SpecialServiceType = "PENDING_SHIPMENT"
+	PieceCountVerification        SpecialServiceType = "PIECE_COUNT_VERIFICATION"
+	PriorityAlert                 SpecialServiceType = "PRIORITY_ALERT"
+	PriorityAlertPlus             SpecialServiceType = "PRIORITY_ALERT_PLUS"
+	ReturnShipment                SpecialServiceType = "RETURN_SHIPMENT"
+	SaturdayDelivery              SpecialServiceType = "SATURDAY_DELIVERY"
+	SaturdayPickup                SpecialServiceType = "SATURDAY_PICKUP"
+	SignatureOption               SpecialServiceType = "SIGNATURE_OPTION"
+	ThirdPartyConsignee           SpecialServiceType = "THIRD_PARTY_CONSIGNEE"
+)
+
+var specialServiceNames = map[SpecialServiceType]string{
+	Alcohol:                       "Alcohol",
+	AppointmentDelivery:           "Appointment delivery",
+	Battery:                       "Battery",
+	BrokerSelectOption:            "Broker select option",
+	CallBeforeDelivery:            "Call before delivery",
+	COD:                           "Collect on delivery",
+	CustomDeliveryWindow:          "Custom delivery window",
+	CutFlowers:                    "Cut flowers",
+	DangerousGoods:                "Dangerous goods",
+	DateCertain:                   "Date certain",
+	DeliveryOnInvoiceAcceptance:   "Delivery on invoice acceptance",
+	DryIce:                        "Dry ice",
+	ElectronicTradeDocuments:      "Electronic trade documents",
+	Evening:                       "Evening delivery",
+	EventNotification:             "Event notification",
+	FedexOneRate:                  "FedEx One Rate",
+	FutureDayShipment:             "Future day shipment",
+	HoldAtLocation:                "Hold at location",
+	HomeDeliveryPremium:           "Home delivery premium",
+	InsideDelivery:                "Inside delivery",
+	InsidePickup:                  "Inside pickup",
+	InternationalControlledExport: "International controlled export",
+	InternationalTrafficInArms:    "International traffic in arms regulations",
+	LiftgateDelivery:              "Liftgate delivery",
+	LiftgatePickup:                "Liftgate pickup",
+	LimitedAccessDelivery:         "Limited access delivery",
+	LimitedAccessPickup:           "Limited access pickup",
+	NonStandardContainer:          "Non-standard container",
+	PendingShipment:               "Pending shipment",
+	PieceCountVerification:        "Piece count verification",
+	PriorityAlert:                 "Priority alert",
+	PriorityAlertPlus:             "Priority alert plus",
+	ReturnShipment:                "Return shipment",
+	SaturdayDelivery:              "Saturday delivery",
+	SaturdayPickup:                "Saturday pickup",
+	SignatureOption:               "Signature option",
+	ThirdPartyConsignee:           "Third party consignee",
+}
+
+func (s SpecialServiceType) String() string { return string(s) }
+
+func (s SpecialServiceType) Valid() bool {
+	_, ok := specialServiceNames[s]
+	return ok
+}
+
+func (s SpecialServiceType) DisplayName() string {
+	if name, ok := specialServiceNames[s]; ok {
+		return name
+	}
+	return string(s)
+}
+
+func (s *SpecialServiceType) UnmarshalText(text []byte) error {
+	*s = SpecialServiceType(normalizeEnum(text))
+	return nil
+}
+
+type ShipmentPurpose string
+
+const (
+	PurposeGift            ShipmentPurpose = "GIFT"
+	PurposeNotSold         ShipmentPurpose = "NOT_SOLD"
+	PurposePersonalEffects ShipmentPurpose = "PERSONAL_EFFECTS"
+	PurposeRepairAndReturn ShipmentPurpose = "REPAIR_AND_RETURN"
+	PurposeSample          ShipmentPurpose = "SAMPLE"
+	PurposeSold            ShipmentPurpose = "SOLD"
+)
+
+var shipmentPurposeNames = map[ShipmentPurpose]string{
+	PurposeGift:            "Gift",
+	PurposeNotSold:         "Not sold",
+	PurposePersonalEffects: "Personal effects",
+	PurposeRepairAndReturn: "Repair and return",
+	PurposeSample:          "Sample",
+	PurposeSold:            "Sold",
+}
+
+func (p ShipmentPurpose) String() string { return string(p) }
+
+func (p ShipmentPurpose) Valid() bool {
+	_, ok := shipmentPurposeNames[p]
+	return ok
+}
+
+func (p ShipmentPurpose) DisplayName() string {
+	if name, ok := shipmentPurposeNames[p]; ok {
+		return name
+	}
+	return string(p)
+}
+
+func (p *ShipmentPurpose) UnmarshalText(text []byte) error {
+	*p = ShipmentPurpose(normalizeEnum(text))
+	return nil
+}
+
+func hasSpecialService(values []SpecialServiceType, value SpecialServiceType) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeEnum trims text, uppercases it and replaces spaces and hyphens
+// with underscores, the form of every FedEx enum value.
+func normalizeEnum(text []byte) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return '_'
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(string(text))))
+}
@@ -0,0 +1,97 @@
+package rate
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestUnknownEnumsMarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		request RateRequest
+		want    string
+	}{
+		{"service", RateRequest{RequestedShipment: RequestedShipment{ServiceType: "FEDEX_NEW_SERVICE"}}, `"serviceType":"FEDEX_NEW_SERVICE"`},
+		{"packaging", RateRequest{RequestedShipment: RequestedShipment{PackagingType: "FEDEX_NEW_BOX"}}, `"packagingType":"FEDEX_NEW_BOX"`},
+		{"pickup", RateRequest{RequestedShipment: RequestedShipment{PickupType: "NEW_PICKUP"}}, `"pickupType":"NEW_PICKUP"`},
+		{"rate type", RateRequest{RequestedShipment: RequestedShipment{RateRequestType: []RateRequestType{"NEW_RATE"}}}, `"rateRequestType":["NEW_RATE"]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := json.Marshal(tt.request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(content), tt.want) {
+				t.Errorf("got %s, want it to contain %s", content, tt.want)
+			}
+		})
+	}
+
+	var x RateXMLRequest
+	x.Body.RateRequest.RequestedShipment.ServiceType = "FEDEX_NEW_SERVICE"
+	content, err := xml.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "FEDEX_NEW_SERVICE") {
+		t.Errorf("SOAP request lost the service type: %s", content)
+	}
+}
+
+func TestValidateRejectsUnknownEnums(t *testing.T) {
+	request, _ := NewShipment("123").
+		From(Address{CountryCode: "US", PostalCode: "38017"}).
+		To(Address{CountryCode: "US", PostalCode: "90210"}).
+		AddPackage(NewPackage(5, "LB")).
+		Build()
+	request.RequestedShipment.PackagingType = "FEDEX_NEW_BOX"
+	err := request.Validate()
+	if err == nil || !strings.Contains(err.Error(), "packagingType") {
+		t.Errorf("Validate() = %v, want an unknown packaging type", err)
+	}
+}
+
+func TestEnumsNormalizeWhenDecoded(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want RequestedShipment
+	}{
+		{"service", `{"serviceType":" fedex ground"}`, RequestedShipment{ServiceType: FedexGround}},
+		{"packaging", `{"packagingType":"fedex-pak"}`, RequestedShipment{PackagingType: FedexPak}},
+		{"pickup", `{"pickupType":"DROPOFF_AT_FEDEX_LOCATION"}`, RequestedShipment{PickupType: DropoffAtFedexLocation}},
+		{"rate type", `{"rateRequestType":["list","account"]}`, RequestedShipment{RateRequestType: []RateRequestType{RateList, RateAccount}}},
+		{"unknown", `{"serviceType":"fedex new service"}`, RequestedShipment{ServiceType: "FEDEX_NEW_SERVICE"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got RequestedShipment
+			if err := json.Unmarshal([]byte(tt.json), &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.ServiceType != tt.want.ServiceType || got.PackagingType != tt.want.PackagingType ||
+				got.PickupType != tt.want.PickupType || strings.Join(rateTypes(got.RateRequestType), ",") != strings.Join(rateTypes(tt.want.RateRequestType), ",") {
+				t.Errorf("decoded %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	var x XMLRequestedShipment
+	if err := xml.Unmarshal([]byte("<RequestedShipment><DropoffType>regular pickup</DropoffType></RequestedShipment>"), &x); err != nil {
+		t.Fatal(err)
+	}
+	if x.DropoffType != "REGULAR_PICKUP" {
+		t.Errorf("DropoffType = %q, want REGULAR_PICKUP", x.DropoffType)
+	}
+}
+
+func rateTypes(types []RateRequestType) []string {
+	var s []string
+	for _, t := range types {
+		s = append(s, string(t))
+	}
+	return s
+}
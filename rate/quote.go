@@ -0,0 +1,211 @@
+package rate
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Quote is a rate for one service and rate type, independent of whether it
+// came from the REST or the SOAP API. Amounts are in Currency.
+type Quote struct {
+	ServiceType     ServiceType           `json:"serviceType"`              //
+	ServiceName     string                `json:"serviceName,omitempty"`    //
+	PackagingType   string                `json:"packagingType,omitempty"`  //
+	RateType        RateRequestType       `json:"rateType"`                 //
+	Currency        string                `json:"currency"`                 //
+	BaseCharge      float64               `json:"baseCharge"`               //
+	Surcharges      []QuoteCharge         `json:"surcharges,omitempty"`     //
+	TotalSurcharges float64               `json:"totalSurcharges"`          //
+	Discounts       float64               `json:"discounts"`                //
+	Taxes           float64               `json:"taxes"`                    //
+	DutiesAndTaxes  float64               `json:"dutiesAndTaxes,omitempty"` //
+	NetCharge       float64               `json:"netCharge"`                //
+	BillingWeight   Weight                `json:"billingWeight"`            //
+	RateZone        string                `json:"rateZone,omitempty"`       //
+	TransitTime     string                `json:"transitTime,omitempty"`    //
+	TransitDays     int                   `json:"transitDays,omitempty"`    //
+	CommitDate      string                `json:"commitDate,omitempty"`     //
+	DeliveryDay     string                `json:"deliveryDay,omitempty"`    //
+	Packages        []PackageQuote        `json:"packages,omitempty"`       // when FedEx rates packages separately
+	ExchangeRate    *CurrencyExchangeRate `json:"exchangeRate,omitempty"`   // how FedEx converted the amounts into Currency
+	Conversion      *CurrencyExchangeRate `json:"conversion,omitempty"`     // how the amounts were converted after FedEx quoted them, see package currency
+	Source          string                `json:"source"`                   //
+}
+
+// PackageQuote is the share of a Quote for one package group.
+type PackageQuote struct {
+	GroupNumber     int           `json:"groupNumber"`          //
+	BaseCharge      float64       `json:"baseCharge"`           //
+	Surcharges      []QuoteCharge `json:"surcharges,omitempty"` //
+	TotalSurcharges float64       `json:"totalSurcharges"`      //
+	NetCharge       float64       `json:"netCharge"`            //
+	BillingWeight   Weight        `json:"billingWeight"`        //
+}
+
+type QuoteCharge struct {
+	Type        string  `json:"type"`                  //
+	Description string  `json:"description,omitempty"` //
+	Amount      float64 `json:"amount"`                //
+}
+
+const (
+	SourceREST     = "REST"
+	SourceSOAP     = "SOAP"
+	SourceEstimate = "ESTIMATE" // computed offline from published tables, see package estimate
+)
+
+func (c RateResponse) Quotes() []Quote {
+	var quotes []Quote
+	for _, detail := range c.Output.RateReplyDetails {
+		for _, rated := range detail.RatedShipmentDetails {
+			rateDetail := rated.ShipmentRateDetail
+			q := Quote{
+				ServiceType:     ServiceType(detail.ServiceType),
+				ServiceName:     detail.ServiceName,
+				PackagingType:   detail.PackagingType,
+				RateType:        normalizeRateType(rated.RateType),
+				Currency:        rated.Currency,
+				BaseCharge:      rated.TotalBaseCharge,
+				TotalSurcharges: rateDetail.TotalSurcharges,
+				Discounts:       rated.TotalDiscounts,
+				Taxes:           rated.TotalVatCharge,
+				DutiesAndTaxes:  rated.TotalDutiesAndTaxes,
+				NetCharge:       rated.TotalNetCharge,
+				BillingWeight:   rateDetail.TotalBillingWeight,
+				RateZone:        rateDetail.RateZone,
+				TransitTime:     detail.OperationalDetail.TransitTime,
+				TransitDays:     transitDays(detail.OperationalDetail.TransitTime),
+				CommitDate:      detail.OperationalDetail.CommitDate,
+				DeliveryDay:     detail.OperationalDetail.DeliveryDay,
+				Source:          SourceREST,
+			}
+			if q.Currency == "" {
+				q.Currency = rateDetail.Currency
+			}
+			if q.DeliveryDay == "" {
+				q.DeliveryDay = detail.Commit.DateDetail.DayOfWeek
+			}
+			if e := rateDetail.CurrencyExchangeRate; e.FromCurrency != "" && e.Rate > 0 {
+				q.ExchangeRate = &e
+			}
+			for _, s := range rateDetail.SurCharges {
+				q.Surcharges = append(q.Surcharges, QuoteCharge{Type: s.Type, Description: s.Description, Amount: s.Amount})
+			}
+			for _, p := range rated.RatedPackages {
+				d := p.PackageRateDetail
+				pq := PackageQuote{
+					GroupNumber:     p.GroupNumber,
+					BaseCharge:      d.BaseCharge,
+					TotalSurcharges: d.TotalSurcharges,
+					NetCharge:       d.NetCharge,
+					BillingWeight:   d.BillingWeight,
+				}
+				for _, s := range d.Surcharges {
+					pq.Surcharges = append(pq.Surcharges, QuoteCharge{Type: s.Type, Description: s.Description, Amount: s.Amount})
+				}
+				q.Packages = append(q.Packages, pq)
+			}
+			quotes = append(quotes, q)
+		}
+	}
+	return quotes
+}
+
+func (c RateXMLResponse) Quotes() []Quote {
+	var quotes []Quote
+	for _, detail := range c.Body.RateReply.RateReplyDetails {
+		for _, rated := range detail.RatedShipmentDetails {
+			rateDetail := rated.ShipmentRateDetail
+			q := Quote{
+				ServiceType:     ServiceType(detail.ServiceType),
+				ServiceName:     detail.ServiceDescription.Description,
+				PackagingType:   detail.PackagingType,
+				RateType:        normalizeRateType(rateDetail.RateType),
+				Currency:        rateDetail.TotalNetCharge.Currency,
+				BaseCharge:      parseFloat(rateDetail.TotalBaseCharge.Amount),
+				TotalSurcharges: parseFloat(rateDetail.TotalSurcharges.Amount),
+				Discounts:       parseFloat(rateDetail.TotalFreightDiscounts.Amount),
+				Taxes:           parseFloat(rateDetail.TotalTaxes.Amount),
+				DutiesAndTaxes:  parseFloat(rateDetail.TotalDutiesAndTaxes.Amount),
+				NetCharge:       parseFloat(rateDetail.TotalNetCharge.Amount),
+				BillingWeight: Weight{
+					Units: rateDetail.TotalBillingWeight.Units,
+					Value: parseFloat(rateDetail.TotalBillingWeight.Value),
+				},
+				RateZone:    rateDetail.RateZone,
+				TransitTime: detail.TransitTime,
+				TransitDays: transitDays(detail.TransitTime),
+				CommitDate:  detail.DeliveryTimestamp,
+				DeliveryDay: detail.DeliveryDayOfWeek,
+				Source:      SourceSOAP,
+			}
+			if q.ServiceName == "" {
+				q.ServiceName = ServiceType(detail.ServiceType).DisplayName()
+			}
+			if e := rateDetail.CurrencyExchangeRate; e.FromCurrency != "" {
+				q.ExchangeRate = &CurrencyExchangeRate{
+					FromCurrency: e.FromCurrency,
+					IntoCurrency: e.IntoCurrency,
+					Rate:         parseFloat(e.Rate),
+				}
+			}
+			for _, s := range rateDetail.Surcharges {
+				q.Surcharges = append(q.Surcharges, QuoteCharge{
+					Type:        s.SurchargeType,
+					Description: s.Description,
+					Amount:      parseFloat(s.Amount.Amount),
+				})
+			}
+			for _, p := range rated.RatedPackages {
+				d := p.PackageRateDetail
+				group, _ := strconv.Atoi(p.GroupNumber)
+				pq := PackageQuote{
+					GroupNumber:     group,
+					BaseCharge:      parseFloat(d.BaseCharge.Amount),
+					TotalSurcharges: parseFloat(d.TotalSurcharges.Amount),
+					NetCharge:       parseFloat(d.NetCharge.Amount),
+					BillingWeight:   Weight{Units: d.BillingWeight.Units, Value: parseFloat(d.BillingWeight.Value)},
+				}
+				for _, s := range d.Surcharges {
+					pq.Surcharges = append(pq.Surcharges, QuoteCharge{
+						Type:        s.SurchargeType,
+						Description: s.Description,
+						Amount:      parseFloat(s.Amount.Amount),
+					})
+				}
+				q.Packages = append(q.Packages, pq)
+			}
+			quotes = append(quotes, q)
+		}
+	}
+	return quotes
+}
+
+// normalizeRateType maps the REST rate types (ACCOUNT, PREFERRED_CURRENCY,
+// ...) and the SOAP ones (PAYOR_LIST_PACKAGE, RATED_ACCOUNT_SHIPMENT, ...)
+// onto the rate request types they answer.
+func normalizeRateType(rateType string) RateRequestType {
+	switch {
+	case strings.Contains(rateType, "PREFERRED"):
+		return RatePreferred
+	case strings.Contains(rateType, "LIST"):
+		return RateList
+	case strings.Contains(rateType, "INCENTIVE"):
+		return RateIncentive
+	case strings.Contains(rateType, "RETAIL"):
+		return RateRetail
+	}
+	return RateAccount
+}
+
+var transitTimes = map[string]int{
+	"ONE_DAY": 1, "TWO_DAYS": 2, "THREE_DAYS": 3, "FOUR_DAYS": 4, "FIVE_DAYS": 5,
+	"SIX_DAYS": 6, "SEVEN_DAYS": 7, "EIGHT_DAYS": 8, "NINE_DAYS": 9, "TEN_DAYS": 10,
+	"ELEVEN_DAYS": 11, "TWELVE_DAYS": 12, "THIRTEEN_DAYS": 13, "FOURTEEN_DAYS": 14,
+	"FIFTEEN_DAYS": 15, "SIXTEEN_DAYS": 16, "SEVENTEEN_DAYS": 17, "EIGHTEEN_DAYS": 18,
+	"NINETEEN_DAYS": 19, "TWENTY_DAYS": 20,
+}
+
+func transitDays(transitTime string) int {
+	return transitTimes[transitTime]
+}
@@ -0,0 +1,43 @@
+package rate
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDecodeFractionalCharges(t *testing.T) {
+	tests := []struct {
+		name      string
+		rated     string
+		discounts float64
+		taxes     float64
+		freight   float64
+	}{
+		{"fractional", `{"totalDiscounts":3.25,"totalVatCharge":1.5,"totalNetCharge":20.1,"shipmentRateDetail":{"totalFreightDiscount":2.75}}`, 3.25, 1.5, 2.75},
+		{"whole", `{"totalDiscounts":3,"totalVatCharge":0,"totalNetCharge":20,"shipmentRateDetail":{"totalFreightDiscount":1}}`, 3, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := `{"output":{"rateReplyDetails":[{"serviceType":"FEDEX_GROUND","ratedShipmentDetails":[` + tt.rated + `]}]}}`
+			var response RateResponse
+			if err := json.Unmarshal([]byte(content), &response); err != nil {
+				t.Fatal(err)
+			}
+			rated := response.Output.RateReplyDetails[0].RatedShipmentDetails[0]
+			if rated.ShipmentRateDetail.TotalFreightDiscount != tt.freight {
+				t.Errorf("TotalFreightDiscount = %v, want %v", rated.ShipmentRateDetail.TotalFreightDiscount, tt.freight)
+			}
+			q := response.Quotes()[0]
+			if q.Discounts != tt.discounts || q.Taxes != tt.taxes {
+				t.Errorf("Discounts, Taxes = %v, %v, want %v, %v", q.Discounts, q.Taxes, tt.discounts, tt.taxes)
+			}
+
+			// the SOAP translation keeps the cents too
+			x := response.ToRateXMLResponse()
+			got := x.Body.RateReply.RateReplyDetails[0].RatedShipmentDetails[0].ShipmentRateDetail.TotalFreightDiscounts.Amount
+			if got != formatAmount(tt.discounts) {
+				t.Errorf("SOAP TotalFreightDiscounts = %s, want %s", got, formatAmount(tt.discounts))
+			}
+		})
+	}
+}
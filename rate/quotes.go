@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"errors"
+	"io/ioutil"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 
 	conv "github.com/cstockton/go-conv"
@@ -28,999 +30,1231 @@ type Address struct {
 	Residential         bool     `json:"residential,omitempty"`         //
 }
 
+type AccountNumber struct {
+	Value string `json:"value,omitempty"` //
+}
+
+type ParsedPersonName struct {
+	FirstName  string `json:"firstName,omitempty"`  //
+	LastName   string `json:"lastName,omitempty"`   //
+	MiddleName string `json:"middleName,omitempty"` //
+	Suffix     string `json:"suffix,omitempty"`     //
+}
+
+type Contact struct {
+	PersonName       string           `json:"personName,omitempty"`       //
+	EmailAddress     string           `json:"emailAddress,omitempty"`     //
+	ParsedPersonName ParsedPersonName `json:"parsedPersonName,omitempty"` //
+	PhoneNumber      string           `json:"phoneNumber,omitempty"`      //
+	PhoneExtension   string           `json:"phoneExtension,omitempty"`   //
+	CompanyName      string           `json:"companyName,omitempty"`      //
+	FaxNumber        string           `json:"faxNumber,omitempty"`        //
+}
+
+type PhoneNumber struct {
+	AreaCode                     string `json:"areaCode,omitempty"`                     //
+	Extension                    string `json:"extension,omitempty"`                    //
+	CountryCode                  string `json:"countryCode,omitempty"`                  //
+	PersonalIdentificationNumber string `json:"personalIdentificationNumber,omitempty"` //
+	LocalNumber                  string `json:"localNumber,omitempty"`                  //
+}
+
+// Party is a shipper, recipient or other responsible party on a shipment.
+type Party struct {
+	Address       Address       `json:"address"`                 //
+	Contact       Contact       `json:"contact,omitempty"`       //
+	AccountNumber AccountNumber `json:"accountNumber,omitempty"` //
+}
+
+type Money struct {
+	Amount   float64 `json:"amount,omitempty"`   //
+	Currency string  `json:"currency,omitempty"` //
+}
+
+type Weight struct {
+	Units string  `json:"units,omitempty"` //
+	Value float64 `json:"value,omitempty"` //
+}
+
+type Dimensions struct {
+	Length int    `json:"length,omitempty"` //
+	Width  int    `json:"width,omitempty"`  //
+	Height int    `json:"height,omitempty"` //
+	Units  string `json:"units,omitempty"`  //
+}
+
+type ContentRecord struct {
+	ItemNumber       string `json:"itemNumber"`       //
+	ReceivedQuantity int    `json:"receivedQuantity"` //
+	Description      string `json:"description"`      //
+	PartNumber       string `json:"partNumber"`       //
+}
+
+type VariableHandlingChargeDetail struct {
+	RateType         string `json:"rateType,omitempty"`         //
+	PercentValue     int    `json:"percentValue,omitempty"`     //
+	RateLevelType    string `json:"rateLevelType,omitempty"`    //
+	FixedValue       Money  `json:"fixedValue,omitempty"`       //
+	RateElementBasis string `json:"rateElementBasis,omitempty"` //
+}
+
+type HazardousQuantity struct {
+	QuantityType string `json:"quantityType,omitempty"` //
+	Amount       int    `json:"amount,omitempty"`       //
+	Units        string `json:"units,omitempty"`        //
+}
+
+type HazardousReceptacle struct {
+	Quantity HazardousQuantity `json:"quantity,omitempty"` //
+}
+
+type HazardousCommodityOptions struct {
+	LabelTextOption           string `json:"labelTextOption,omitempty"`           //
+	CustomerSuppliedLabelText string `json:"customerSuppliedLabelText,omitempty"` //
+}
+
+type HazardousPackingDetails struct {
+	PackingInstructions string `json:"packingInstructions,omitempty"` //
+	CargoAircraftOnly   bool   `json:"cargoAircraftOnly,omitempty"`   //
+}
+
+type HazardousCommodityDescription struct {
+	SequenceNumber     int                     `json:"sequenceNumber,omitempty"`     //
+	ProcessingOptions  []string                `json:"processingOptions,omitempty"`  //
+	SubsidiaryClasses  string                  `json:"subsidiaryClasses,omitempty"`  //
+	LabelText          string                  `json:"labelText,omitempty"`          //
+	TechnicalName      string                  `json:"technicalName,omitempty"`      //
+	PackingDetails     HazardousPackingDetails `json:"packingDetails,omitempty"`     //
+	Authorization      string                  `json:"authorization,omitempty"`      //
+	ReportableQuantity bool                    `json:"reportableQuantity,omitempty"` //
+	Percentage         int                     `json:"percentage,omitempty"`         //
+	ID                 string                  `json:"id,omitempty"`                 //
+	PackingGroup       string                  `json:"packingGroup,omitempty"`       //
+	ProperShippingName string                  `json:"properShippingName,omitempty"` //
+	HazardClass        string                  `json:"hazardClass,omitempty"`        //
+}
+
+type HazardousCommodity struct {
+	Quantity         HazardousQuantity             `json:"quantity,omitempty"`         //
+	InnerReceptacles []HazardousReceptacle         `json:"innerReceptacles,omitempty"` //
+	Options          HazardousCommodityOptions     `json:"options,omitempty"`          //
+	Description      HazardousCommodityDescription `json:"description,omitempty"`      //
+}
+
+type HazardousPackaging struct {
+	Count int    `json:"count,omitempty"` //
+	Units string `json:"units,omitempty"` //
+}
+
+type DangerousGoodsContainer struct {
+	Offeror                   string               `json:"offeror,omitempty"`                   //
+	HazardousCommodities      []HazardousCommodity `json:"hazardousCommodities,omitempty"`      //
+	NumberOfContainers        int                  `json:"numberOfContainers,omitempty"`        //
+	ContainerType             string               `json:"containerType,omitempty"`             //
+	EmergencyContactNumber    PhoneNumber          `json:"emergencyContactNumber,omitempty"`    //
+	Packaging                 HazardousPackaging   `json:"packaging,omitempty"`                 //
+	PackingType               string               `json:"packingType,omitempty"`               //
+	RadioactiveContainerClass string               `json:"radioactiveContainerClass,omitempty"` //
+}
+
+type DangerousGoodsDetail struct {
+	Offeror                string                    `json:"offeror,omitempty"`                //
+	Accessibility          string                    `json:"accessibility,omitempty"`          //
+	EmergencyContactNumber string                    `json:"emergencyContactNumber,omitempty"` //
+	Options                []string                  `json:"options,omitempty"`                //
+	Containers             []DangerousGoodsContainer `json:"containers,omitempty"`             //
+	Packaging              HazardousPackaging        `json:"packaging,omitempty"`              //
+}
+
+type AlcoholDetail struct {
+	AlcoholRecipientType string `json:"alcoholRecipientType,omitempty"` //
+	ShipperAgreementType string `json:"shipperAgreementType,omitempty"` //
+}
+
+type PackageCODDetail struct {
+	CodCollectionAmount Money  `json:"codCollectionAmount,omitempty"` //
+	CodCollectionType   string `json:"codCollectionType,omitempty"`   //
+}
+
+type BatteryDetail struct {
+	Material          string `json:"material,omitempty"`          //
+	RegulatorySubType string `json:"regulatorySubType,omitempty"` //
+	Packing           string `json:"packing,omitempty"`           //
+}
+
+type PackageSpecialServices struct {
+	SpecialServiceTypes            []SpecialServiceType `json:"specialServiceTypes,omitempty"`            //
+	AlcoholDetail                  AlcoholDetail        `json:"alcoholDetail,omitempty"`                  //
+	DangerousGoodsDetail           DangerousGoodsDetail `json:"dangerousGoodsDetail,omitempty"`           //
+	PackageCODDetail               PackageCODDetail     `json:"packageCODDetail,omitempty"`               //
+	PieceCountVerificationBoxCount int                  `json:"pieceCountVerificationBoxCount,omitempty"` //
+	BatteryDetails                 []BatteryDetail      `json:"batteryDetails,omitempty"`                 //
+	DryIceWeight                   Weight               `json:"dryIceWeight,omitempty"`                   //
+}
+
 type Package struct {
-	SubPackagingType  string `json:"subPackagingType,omitempty"`
-	GroupPackageCount int    `json:"groupPackageCount,omitempty"`
-	ContentRecord     []struct {
-		ItemNumber       string `json:"itemNumber"`
-		ReceivedQuantity int    `json:"receivedQuantity"`
-		Description      string `json:"description"`
-		PartNumber       string `json:"partNumber"`
-	} `json:"contentRecord,omitempty"`
-	DeclaredValue struct {
-		Amount   string `json:"amount,omitempty"`
-		Currency string `json:"currency,omitempty"`
-	} `json:"declaredValue,omitempty"`
-	Weight struct {
-		Units string `json:"units"`
-		Value int    `json:"value"`
-	} `json:"weight"`
-	Dimensions struct {
-		Length int    `json:"length,omitempty"`
-		Width  int    `json:"width,omitempty"`
-		Height int    `json:"height,omitempty"`
-		Units  string `json:"units,omitempty"`
-	} `json:"dimensions,omitempty"`
-	VariableHandlingChargeDetail struct {
-		RateType      string `json:"rateType,omitempty"`
-		PercentValue  int    `json:"percentValue,omitempty"`
-		RateLevelType string `json:"rateLevelType,omitempty"`
-		FixedValue    struct {
-			Amount   string `json:"amount,omitempty"`
-			Currency string `json:"currency,omitempty"`
-		} `json:"fixedValue,omitempty"`
-		RateElementBasis string `json:"rateElementBasis,omitempty"`
-	} `json:"variableHandlingChargeDetail,omitempty"`
-	PackageSpecialServices struct {
-		SpecialServiceTypes []string `json:"specialServiceTypes,omitempty"`
-		AlcoholDetail       struct {
-			AlcoholRecipientType string `json:"alcoholRecipientType,omitempty"`
-			ShipperAgreementType string `json:"shipperAgreementType,omitempty"`
-		} `json:"alcoholDetail,omitempty"`
-		DangerousGoodsDetail struct {
-			Offeror                string   `json:"offeror,omitempty"`
-			Accessibility          string   `json:"accessibility,omitempty"`
-			EmergencyContactNumber string   `json:"emergencyContactNumber,omitempty"`
-			Options                []string `json:"options,omitempty"`
-			Containers             []struct {
-				Offeror              string `json:"offeror,omitempty"`
-				HazardousCommodities []struct {
-					Quantity struct {
-						QuantityType string `json:"quantityType,omitempty"`
-						Amount       int    `json:"amount,omitempty"`
-						Units        string `json:"units,omitempty"`
-					} `json:"quantity,omitempty"`
-					InnerReceptacles []struct {
-						Quantity struct {
-							QuantityType string `json:"quantityType,omitempty"`
-							Amount       int    `json:"amount,omitempty"`
-							Units        string `json:"units,omitempty"`
-						} `json:"quantity,omitempty"`
-					} `json:"innerReceptacles,omitempty"`
-					Options struct {
-						LabelTextOption           string `json:"labelTextOption,omitempty"`
-						CustomerSuppliedLabelText string `json:"customerSuppliedLabelText,omitempty"`
-					} `json:"options,omitempty"`
-					Description struct {
-						SequenceNumber    int      `json:"sequenceNumber,omitempty"`
-						ProcessingOptions []string `json:"processingOptions,omitempty"`
-						SubsidiaryClasses string   `json:"subsidiaryClasses,omitempty"`
-						LabelText         string   `json:"labelText,omitempty"`
-						TechnicalName     string   `json:"technicalName,omitempty"`
-						PackingDetails    struct {
-							PackingInstructions string `json:"packingInstructions,omitempty"`
-							CargoAircraftOnly   bool   `json:"cargoAircraftOnly,omitempty"`
-						} `json:"packingDetails,omitempty"`
-						Authorization      string `json:"authorization,omitempty"`
-						ReportableQuantity bool   `json:"reportableQuantity,omitempty"`
-						Percentage         int    `json:"percentage,omitempty"`
-						ID                 string `json:"id,omitempty"`
-						PackingGroup       string `json:"packingGroup,omitempty"`
-						ProperShippingName string `json:"properShippingName,omitempty"`
-						HazardClass        string `json:"hazardClass,omitempty"`
-					} `json:"description,omitempty"`
-				} `json:"hazardousCommodities,omitempty"`
-				NumberOfContainers     int    `json:"numberOfContainers,omitempty"`
-				ContainerType          string `json:"containerType,omitempty"`
-				EmergencyContactNumber struct {
-					AreaCode                     string `json:"areaCode,omitempty"`
-					Extension                    string `json:"extension,omitempty"`
-					CountryCode                  string `json:"countryCode,omitempty"`
-					PersonalIdentificationNumber string `json:"personalIdentificationNumber,omitempty"`
-					LocalNumber                  string `json:"localNumber,omitempty"`
-				} `json:"emergencyContactNumber,omitempty"`
-				Packaging struct {
-					Count int    `json:"count,omitempty"`
-					Units string `json:"units,omitempty"`
-				} `json:"packaging,omitempty"`
-				PackingType               string `json:"packingType,omitempty"`
-				RadioactiveContainerClass string `json:"radioactiveContainerClass,omitempty"`
-			} `json:"containers,omitempty"`
-			Packaging struct {
-				Count int    `json:"count,omitempty"`
-				Units string `json:"units,omitempty"`
-			} `json:"packaging,omitempty"`
-		} `json:"dangerousGoodsDetail,omitempty"`
-		PackageCODDetail struct {
-			CodCollectionAmount struct {
-				Amount   float64 `json:"amount,omitempty"`
-				Currency string  `json:"currency,omitempty"`
-			} `json:"codCollectionAmount,omitempty"`
-			CodCollectionType string `json:"codCollectionType,omitempty"`
-		} `json:"packageCODDetail,omitempty"`
-		PieceCountVerificationBoxCount int `json:"pieceCountVerificationBoxCount,omitempty"`
-		BatteryDetails                 []struct {
-			Material          string `json:"material,omitempty"`
-			RegulatorySubType string `json:"regulatorySubType,omitempty"`
-			Packing           string `json:"packing,omitempty"`
-		} `json:"batteryDetails,omitempty"`
-		DryIceWeight struct {
-			Units string `json:"units,omitempty"`
-			Value int    `json:"value,omitempty"`
-		} `json:"dryIceWeight,omitempty"`
-	} `json:"packageSpecialServices,omitempty"`
+	SubPackagingType             string                       `json:"subPackagingType,omitempty"`             //
+	GroupPackageCount            int                          `json:"groupPackageCount,omitempty"`            //
+	ContentRecord                []ContentRecord              `json:"contentRecord,omitempty"`                //
+	DeclaredValue                Money                        `json:"declaredValue,omitempty"`                //
+	Weight                       Weight                       `json:"weight"`                                 //
+	Dimensions                   Dimensions                   `json:"dimensions,omitempty"`                   //
+	VariableHandlingChargeDetail VariableHandlingChargeDetail `json:"variableHandlingChargeDetail,omitempty"` //
+	PackageSpecialServices       PackageSpecialServices       `json:"packageSpecialServices,omitempty"`       //
+}
+
+type Commodity struct {
+	Description          string `json:"description,omitempty"`          //
+	Weight               Weight `json:"weight,omitempty"`               //
+	Quantity             int    `json:"quantity,omitempty"`             //
+	CustomsValue         Money  `json:"customsValue,omitempty"`         //
+	UnitPrice            Money  `json:"unitPrice,omitempty"`            //
+	NumberOfPieces       int    `json:"numberOfPieces,omitempty"`       //
+	CountryOfManufacture string `json:"countryOfManufacture,omitempty"` //
+	QuantityUnits        string `json:"quantityUnits,omitempty"`        //
+	Name                 string `json:"name,omitempty"`                 //
+	HarmonizedCode       string `json:"harmonizedCode,omitempty"`       //
+	PartNumber           string `json:"partNumber,omitempty"`           //
+}
+
+type RateRequestControlParameters struct {
+	ReturnTransitTimes          bool   `json:"returnTransitTimes,omitempty"`          //
+	ServicesNeededOnRateFailure bool   `json:"servicesNeededOnRateFailure,omitempty"` //
+	VariableOptions             string `json:"variableOptions,omitempty"`             //
+	RateSortOrder               string `json:"rateSortOrder,omitempty"`               //
+}
+
+type SmsDetail struct {
+	PhoneNumber            string `json:"phoneNumber,omitempty"`            //
+	PhoneNumberCountryCode string `json:"phoneNumberCountryCode,omitempty"` //
+}
+
+type EmailNotificationRecipient struct {
+	EmailAddress                   string    `json:"emailAddress,omitempty"`                   //
+	NotificationEventType          []string  `json:"notificationEventType,omitempty"`          //
+	SmsDetail                      SmsDetail `json:"smsDetail,omitempty"`                      //
+	NotificationFormatType         string    `json:"notificationFormatType,omitempty"`         //
+	EmailNotificationRecipientType string    `json:"emailNotificationRecipientType,omitempty"` //
+	NotificationType               string    `json:"notificationType,omitempty"`               //
+	Locale                         string    `json:"locale,omitempty"`                         //
+}
+
+type PrintedReference struct {
+	PrintedReferenceType string `json:"printedReferenceType,omitempty"` //
+	Value                string `json:"value,omitempty"`                //
+}
+
+type EmailNotificationDetail struct {
+	Recipients       []EmailNotificationRecipient `json:"recipients,omitempty"`       //
+	PersonalMessage  string                       `json:"personalMessage,omitempty"`  //
+	PrintedReference PrintedReference             `json:"PrintedReference,omitempty"` //
+}
+
+type PickupAddress struct {
+	Address
+	AddressVerificationID string `json:"addressVerificationId,omitempty"` //
+}
+
+type PickupOrigin struct {
+	AccountNumber AccountNumber `json:"accountNumber,omitempty"` //
+	Address       PickupAddress `json:"address"`                 //
+	Contact       Contact       `json:"contact,omitempty"`       //
+}
+
+type PickupDetail struct {
+	CompanyCloseTime        string        `json:"companyCloseTime,omitempty"`        //
+	PickupOrigin            *PickupOrigin `json:"pickupOrigin,omitempty"`            // nil for the shipper
+	GeographicalPostalCode  string        `json:"geographicalPostalCode,omitempty"`  //
+	RequestType             string        `json:"requestType,omitempty"`             //
+	BuildingPartDescription string        `json:"buildingPartDescription,omitempty"` //
+	CourierInstructions     string        `json:"courierInstructions,omitempty"`     //
+	BuildingPart            string        `json:"buildingPart,omitempty"`            //
+	LatestPickupDateTime    string        `json:"latestPickupDateTime,omitempty"`    //
+	PackageLocation         string        `json:"packageLocation,omitempty"`         //
+	ReadyPickupDateTime     string        `json:"readyPickupDateTime,omitempty"`     //
+	EarlyPickup             bool          `json:"earlyPickup,omitempty"`             //
+}
+
+type DryIceDetail struct {
+	TotalWeight  Weight `json:"totalWeight,omitempty"`  //
+	PackageCount int    `json:"packageCount,omitempty"` //
+}
+
+// ProcessingOptions lists the options of a pending shipment or of an email
+// label recipient.
+type ProcessingOptions struct {
+	Options []string `json:"options,omitempty"` //
+}
+
+type RecommendedDocumentSpecification struct {
+	Types []string `json:"types,omitempty"` //
+}
+
+type Locale struct {
+	Country  string `json:"country,omitempty"`  //
+	Language string `json:"language,omitempty"` //
+}
+
+type EmailLabelRecipient struct {
+	EmailAddress     string            `json:"emailAddress,omitempty"`     //
+	OptionsRequested ProcessingOptions `json:"optionsRequested,omitempty"` //
+	Role             string            `json:"role,omitempty"`             //
+	Locale           Locale            `json:"locale,omitempty"`           //
+}
+
+type EmailLabelDetail struct {
+	Recipients []EmailLabelRecipient `json:"recipients,omitempty"` //
+	Message    string                `json:"message,omitempty"`    //
+}
+
+type DocumentReference struct {
+	DocumentType      string `json:"documentType,omitempty"`      //
+	CustomerReference string `json:"customerReference,omitempty"` //
+	Description       string `json:"description,omitempty"`       //
+	DocumentID        string `json:"documentId,omitempty"`        //
+}
+
+type PendingShipmentDetail struct {
+	PendingShipmentType              string                           `json:"pendingShipmentType,omitempty"`              //
+	ProcessingOptions                ProcessingOptions                `json:"processingOptions,omitempty"`                //
+	RecommendedDocumentSpecification RecommendedDocumentSpecification `json:"recommendedDocumentSpecification,omitempty"` //
+	EmailLabelDetail                 EmailLabelDetail                 `json:"emailLabelDetail,omitempty"`                 //
+	DocumentReferences               []DocumentReference              `json:"documentReferences,omitempty"`               //
+	ExpirationTimeStamp              string                           `json:"expirationTimeStamp,omitempty"`              //
+	ShipmentDryIceDetail             DryIceDetail                     `json:"shipmentDryIceDetail,omitempty"`             //
+}
+
+type ContactAndAddress struct {
+	Address Address `json:"address,omitempty"` //
+	Contact Contact `json:"contact,omitempty"` //
+}
+
+type HoldAtLocationDetail struct {
+	LocationID                string            `json:"locationId,omitempty"`                //
+	LocationContactAndAddress ContactAndAddress `json:"locationContactAndAddress,omitempty"` //
+	LocationType              string            `json:"locationType,omitempty"`              //
+}
+
+type CodTransportationCharges struct {
+	RateType        string `json:"rateType,omitempty"`        //
+	RateLevelType   string `json:"rateLevelType,omitempty"`   //
+	ChargeLevelType string `json:"chargeLevelType,omitempty"` //
+	ChargeType      string `json:"chargeType,omitempty"`      //
+}
+
+type ShipmentCODDetail struct {
+	AddTransportationChargesDetail        CodTransportationCharges `json:"addTransportationChargesDetail,omitempty"`        //
+	CodRecipient                          *Party                   `json:"codRecipient,omitempty"`                          // nil for the shipper
+	RemitToName                           string                   `json:"remitToName,omitempty"`                           //
+	CodCollectionType                     string                   `json:"codCollectionType,omitempty"`                     //
+	FinancialInstitutionContactAndAddress *ContactAndAddress       `json:"financialInstitutionContactAndAddress,omitempty"` //
+	ReturnReferenceIndicatorType          string                   `json:"returnReferenceIndicatorType,omitempty"`          //
+}
+
+type HomeDeliveryPremiumDetail struct {
+	PhoneNumber             PhoneNumber `json:"phoneNumber,omitempty"`             //
+	ShipTimestamp           string      `json:"shipTimestamp,omitempty"`           //
+	HomedeliveryPremiumType string      `json:"homedeliveryPremiumType,omitempty"` //
+}
+
+type ReturnShipmentDetail struct {
+	ReturnType string `json:"returnType,omitempty"` //
+}
+
+type DeliveryOnInvoiceAcceptanceDetail struct {
+	Recipient *Party `json:"recipient,omitempty"` //
+}
+
+type InternationalTrafficInArmsRegulationsDetail struct {
+	LicenseOrExemptionNumber string `json:"licenseOrExemptionNumber,omitempty"` //
+}
+
+type InternationalControlledExportDetail struct {
+	Type string `json:"type,omitempty"` //
+}
+
+type ShipmentSpecialServices struct {
+	ReturnShipmentDetail                        ReturnShipmentDetail                        `json:"returnShipmentDetail,omitempty"`                        //
+	DeliveryOnInvoiceAcceptanceDetail           DeliveryOnInvoiceAcceptanceDetail           `json:"deliveryOnInvoiceAcceptanceDetail,omitempty"`           //
+	InternationalTrafficInArmsRegulationsDetail InternationalTrafficInArmsRegulationsDetail `json:"internationalTrafficInArmsRegulationsDetail,omitempty"` //
+	PendingShipmentDetail                       PendingShipmentDetail                       `json:"pendingShipmentDetail,omitempty"`                       //
+	HoldAtLocationDetail                        *HoldAtLocationDetail                       `json:"holdAtLocationDetail,omitempty"`                        //
+	ShipmentCODDetail                           ShipmentCODDetail                           `json:"shipmentCODDetail,omitempty"`                           //
+	ShipmentDryIceDetail                        DryIceDetail                                `json:"shipmentDryIceDetail,omitempty"`                        //
+	InternationalControlledExportDetail         InternationalControlledExportDetail         `json:"internationalControlledExportDetail,omitempty"`         //
+	HomeDeliveryPremiumDetail                   HomeDeliveryPremiumDetail                   `json:"homeDeliveryPremiumDetail,omitempty"`                   //
+	SpecialServiceTypes                         []SpecialServiceType                        `json:"specialServiceTypes,omitempty"`                         //
+}
+
+// Payor is who a payment bills; the account of the request when
+// ResponsibleParty is nil.
+type Payor struct {
+	ResponsibleParty *Party `json:"responsibleParty,omitempty"` //
+}
+
+// AccountNumber returns the account of the responsible party, or "".
+func (p Payor) AccountNumber() string {
+	if p.ResponsibleParty == nil {
+		return ""
+	}
+	return p.ResponsibleParty.AccountNumber.Value
+}
+
+type DutiesPayment struct {
+	Payor       Payor  `json:"payor,omitempty"`       //
+	PaymentType string `json:"paymentType,omitempty"` //
+}
+
+// Payment says who pays the shipping charges; FedEx bills the account of
+// the request when it is nil.
+type Payment struct {
+	Payor       Payor  `json:"payor,omitempty"`       //
+	PaymentType string `json:"paymentType,omitempty"` // SENDER, RECIPIENT, THIRD_PARTY or ACCOUNT
+}
+
+type CommercialInvoice struct {
+	ShipmentPurpose ShipmentPurpose `json:"shipmentPurpose,omitempty"` //
+}
+
+type CustomsClearanceDetail struct {
+	CommercialInvoice CommercialInvoice `json:"commercialInvoice,omitempty"` //
+	FreightOnValue    string            `json:"freightOnValue,omitempty"`    //
+	DutiesPayment     DutiesPayment     `json:"dutiesPayment,omitempty"`     //
+	Commodities       []Commodity       `json:"commodities,omitempty"`       //
+}
+
+type RequestedShipment struct {
+	Shipper                      Party                        `json:"shipper,omitempty"`                      //
+	Recipient                    Party                        `json:"recipient,omitempty"`                    //
+	ServiceType                  ServiceType                  `json:"serviceType"`                            //
+	EmailNotificationDetail      EmailNotificationDetail      `json:"emailNotificationDetail,omitempty"`      //
+	PreferredCurrency            string                       `json:"preferredCurrency,omitempty"`            //
+	EdtRequestType               string                       `json:"edtRequestType,omitempty"`               // ALL to estimate duties and taxes
+	RateRequestType              []RateRequestType            `json:"rateRequestType,omitempty"`              //
+	ShipDateStamp                string                       `json:"shipDateStamp,omitempty"`                //
+	PickupType                   PickupType                   `json:"pickupType,omitempty"`                   //
+	RequestedPackageLineItems    []Package                    `json:"requestedPackageLineItems,omitempty"`    //
+	DocumentShipment             bool                         `json:"documentShipment,omitempty"`             //
+	PickupDetail                 PickupDetail                 `json:"pickupDetail,omitempty"`                 //
+	VariableHandlingChargeDetail VariableHandlingChargeDetail `json:"variableHandlingChargeDetail,omitempty"` //
+	PackagingType                PackagingType                `json:"packagingType,omitempty"`                //
+	TotalPackageCount            int                          `json:"totalPackageCount,omitempty"`            //
+	TotalWeight                  float64                      `json:"totalWeight,omitempty"`                  //
+	ShipmentSpecialServices      ShipmentSpecialServices      `json:"shipmentSpecialServices,omitempty"`      //
+	ShippingChargesPayment       *Payment                     `json:"shippingChargesPayment,omitempty"`       //
+	CustomsClearanceDetail       CustomsClearanceDetail       `json:"customsClearanceDetail,omitempty"`       //
+	GroupShipment                bool                         `json:"groupShipment,omitempty"`                //
+	ServiceTypeDetail            ServiceTypeDetail            `json:"serviceTypeDetail,omitempty"`            //
+	SmartPostInfoDetail          SmartPostInfoDetail          `json:"smartPostInfoDetail,omitempty"`          //
+	ExpressFreightDetail         ExpressFreightDetail         `json:"expressFreightDetail,omitempty"`         //
+	GroundShipment               bool                         `json:"groundShipment,omitempty"`               //
+}
+
+type ServiceTypeDetail struct {
+	CarrierCode     string `json:"carrierCode,omitempty"`     //
+	Description     string `json:"description,omitempty"`     //
+	ServiceName     string `json:"serviceName,omitempty"`     //
+	ServiceCategory string `json:"serviceCategory,omitempty"` //
+}
+
+type SmartPostInfoDetail struct {
+	AncillaryEndorsement string `json:"ancillaryEndorsement,omitempty"` //
+	HubID                string `json:"hubId,omitempty"`                //
+	Indicia              string `json:"indicia,omitempty"`              //
+	SpecialServices      string `json:"specialServices,omitempty"`      //
+}
+
+type ExpressFreightDetail struct {
+	BookingConfirmationNumber string `json:"bookingConfirmationNumber,omitempty"` //
+	ShippersLoadAndCount      int    `json:"shippersLoadAndCount,omitempty"`      //
 }
 
 type RateRequest struct {
-	AccountNumber struct {
-		Value string `json:"value"` //
-	} `json:"accountNumber"` //
-	RateRequestControlParameters struct {
-		ReturnTransitTimes          bool   `json:"returnTransitTimes,omitempty"`          //
-		ServicesNeededOnRateFailure bool   `json:"servicesNeededOnRateFailure,omitempty"` //
-		VariableOptions             string `json:"variableOptions,omitempty"`             //
-		RateSortOrder               string `json:"rateSortOrder,omitempty"`               //
-	} `json:"rateRequestControlParameters,omitempty"` //
-	RequestedShipment struct {
-		Shipper struct {
-			Address Address `json:"address"` //
-		} `json:"shipper,omitempty"` //
-		Recipient struct {
-			Address Address `json:"address"` //
-		} `json:"recipient,omitempty"` //
-		ServiceType             string `json:"serviceType"` //
-		EmailNotificationDetail struct {
-			Recipients []struct {
-				EmailAddress          string   `json:"emailAddress,omitempty"`
-				NotificationEventType []string `json:"notificationEventType,omitempty"`
-				SmsDetail             struct {
-					PhoneNumber            string `json:"phoneNumber,omitempty"`
-					PhoneNumberCountryCode string `json:"phoneNumberCountryCode,omitempty"`
-				} `json:"smsDetail,omitempty"`
-				NotificationFormatType         string `json:"notificationFormatType,omitempty"`
-				EmailNotificationRecipientType string `json:"emailNotificationRecipientType,omitempty"`
-				NotificationType               string `json:"notificationType,omitempty"`
-				Locale                         string `json:"locale,omitempty"`
-			} `json:"recipients,omitempty"`
-			PersonalMessage  string `json:"personalMessage,omitempty"`
-			PrintedReference struct {
-				PrintedReferenceType string `json:"printedReferenceType,omitempty"`
-				Value                string `json:"value,omitempty"`
-			} `json:"PrintedReference,omitempty"`
-		} `json:"emailNotificationDetail,omitempty"` //
-		PreferredCurrency         string    `json:"preferredCurrency,omitempty"`         //
-		RateRequestType           []string  `json:"rateRequestType,omitempty"`           //
-		ShipDateStamp             string    `json:"shipDateStamp,omitempty"`             //
-		PickupType                string    `json:"pickupType,omitempty"`                //
-		RequestedPackageLineItems []Package `json:"requestedPackageLineItems,omitempty"` //
-		DocumentShipment          bool      `json:"documentShipment,omitempty"`          //
-		PickupDetail              struct {
-			CompanyCloseTime string `json:"companyCloseTime,omitempty"`
-			PickupOrigin     struct {
-				AccountNumber struct {
-					Value int `json:"value,omitempty"`
-				} `json:"accountNumber,omitempty"`
-				Address struct {
-					AddressVerificationID string   `json:"addressVerificationId,omitempty"`
-					CountryCode           string   `json:"countryCode,omitempty"`
-					StreetLines           []string `json:"streetLines,omitempty"`
-				} `json:"address,omitempty"`
-				Contact struct {
-					CompanyName string `json:"companyName,omitempty"`
-					FaxNumber   string `json:"faxNumber,omitempty"`
-					PersonName  string `json:"personName,omitempty"`
-					PhoneNumber string `json:"phoneNumber,omitempty"`
-				} `json:"contact,omitempty"`
-			} `json:"pickupOrigin,omitempty"`
-			GeographicalPostalCode  string `json:"geographicalPostalCode,omitempty"`
-			RequestType             string `json:"requestType,omitempty"`
-			BuildingPartDescription string `json:"buildingPartDescription,omitempty"`
-			CourierInstructions     string `json:"courierInstructions,omitempty"`
-			BuildingPart            string `json:"buildingPart,omitempty"`
-			LatestPickupDateTime    string `json:"latestPickupDateTime,omitempty"`
-			PackageLocation         string `json:"packageLocation,omitempty"`
-			ReadyPickupDateTime     string `json:"readyPickupDateTime,omitempty"`
-			EarlyPickup             bool   `json:"earlyPickup,omitempty"`
-		} `json:"pickupDetail,omitempty"` //
-		VariableHandlingChargeDetail struct {
-			RateType      string `json:"rateType,omitempty"`
-			PercentValue  int    `json:"percentValue,omitempty"`
-			RateLevelType string `json:"rateLevelType,omitempty"`
-			FixedValue    struct {
-				Amount   string `json:"amount,omitempty"`
-				Currency string `json:"currency,omitempty"`
-			} `json:"fixedValue,omitempty"`
-			RateElementBasis string `json:"rateElementBasis,omitempty"`
-		} `json:"variableHandlingChargeDetail,omitempty"` //
-		PackagingType           string  `json:"packagingType,omitempty"`     //
-		TotalPackageCount       int     `json:"totalPackageCount,omitempty"` //
-		TotalWeight             float64 `json:"totalWeight,omitempty"`       //
-		ShipmentSpecialServices struct {
-			ReturnShipmentDetail struct {
-				ReturnType string `json:"returnType,omitempty"`
-			} `json:"returnShipmentDetail,omitempty"`
-			DeliveryOnInvoiceAcceptanceDetail struct {
-				Recipient struct {
-					AccountNumber struct {
-						Value int `json:"value,omitempty"`
-					} `json:"accountNumber,omitempty"`
-					Address struct {
-						StreetLines []string `json:"streetLines,omitempty"`
-						CountryCode string   `json:"countryCode,omitempty"`
-					} `json:"address,omitempty"`
-					Contact struct {
-						CompanyName string `json:"companyName,omitempty"`
-						FaxNumber   string `json:"faxNumber,omitempty"`
-						PersonName  string `json:"personName,omitempty"`
-						PhoneNumber string `json:"phoneNumber,omitempty"`
-					} `json:"contact,omitempty"`
-				} `json:"recipient,omitempty"`
-			} `json:"deliveryOnInvoiceAcceptanceDetail,omitempty"`
-			InternationalTrafficInArmsRegulationsDetail struct {
-				LicenseOrExemptionNumber string `json:"licenseOrExemptionNumber,omitempty"`
-			} `json:"internationalTrafficInArmsRegulationsDetail,omitempty"`
-			PendingShipmentDetail struct {
-				PendingShipmentType string `json:"pendingShipmentType,omitempty"`
-				ProcessingOptions   struct {
-					Options []string `json:"options,omitempty"`
-				} `json:"processingOptions,omitempty"`
-				RecommendedDocumentSpecification struct {
-					Types []string `json:"types,omitempty"`
-				} `json:"recommendedDocumentSpecification,omitempty"`
-				EmailLabelDetail struct {
-					Recipients []struct {
-						EmailAddress     string `json:"emailAddress,omitempty"`
-						OptionsRequested struct {
-							Options []string `json:"options,omitempty"`
-						} `json:"optionsRequested,omitempty"`
-						Role   string `json:"role,omitempty"`
-						Locale struct {
-							Country  string `json:"country,omitempty"`
-							Language string `json:"language,omitempty"`
-						} `json:"locale,omitempty"`
-					} `json:"recipients,omitempty"`
-					Message string `json:"message,omitempty"`
-				} `json:"emailLabelDetail,omitempty"`
-				DocumentReferences []struct {
-					DocumentType      string `json:"documentType,omitempty"`
-					CustomerReference string `json:"customerReference,omitempty"`
-					Description       string `json:"description,omitempty"`
-					DocumentID        string `json:"documentId,omitempty"`
-				} `json:"documentReferences,omitempty"`
-				ExpirationTimeStamp  string `json:"expirationTimeStamp,omitempty"`
-				ShipmentDryIceDetail struct {
-					TotalWeight struct {
-						Units string `json:"units,omitempty"`
-						Value int    `json:"value,omitempty"`
-					} `json:"totalWeight,omitempty"`
-					PackageCount int `json:"packageCount,omitempty"`
-				} `json:"shipmentDryIceDetail,omitempty"`
-			} `json:"pendingShipmentDetail,omitempty"`
-			HoldAtLocationDetail struct {
-				LocationID                string `json:"locationId,omitempty"`
-				LocationContactAndAddress struct {
-					Address struct {
-						StreetLines         []string `json:"streetLines,omitempty"`
-						City                string   `json:"city,omitempty"`
-						StateOrProvinceCode string   `json:"stateOrProvinceCode,omitempty"`
-						PostalCode          string   `json:"postalCode,omitempty"`
-						CountryCode         string   `json:"countryCode,omitempty"`
-						Residential         bool     `json:"residential,omitempty"`
-					} `json:"address,omitempty"`
-					Contact struct {
-						PersonName       string `json:"personName,omitempty"`
-						EmailAddress     string `json:"emailAddress,omitempty"`
-						ParsedPersonName struct {
-							FirstName  string `json:"firstName,omitempty"`
-							LastName   string `json:"lastName,omitempty"`
-							MiddleName string `json:"middleName,omitempty"`
-							Suffix     string `json:"suffix,omitempty"`
-						} `json:"parsedPersonName,omitempty"`
-						PhoneNumber    string `json:"phoneNumber,omitempty"`
-						PhoneExtension string `json:"phoneExtension,omitempty"`
-						CompanyName    string `json:"companyName,omitempty"`
-						FaxNumber      string `json:"faxNumber,omitempty"`
-					} `json:"contact,omitempty"`
-				} `json:"locationContactAndAddress,omitempty"`
-				LocationType string `json:"locationType,omitempty"`
-			} `json:"holdAtLocationDetail,omitempty"`
-			ShipmentCODDetail struct {
-				AddTransportationChargesDetail struct {
-					RateType        string `json:"rateType,omitempty"`
-					RateLevelType   string `json:"rateLevelType,omitempty"`
-					ChargeLevelType string `json:"chargeLevelType,omitempty"`
-					ChargeType      string `json:"chargeType,omitempty"`
-				} `json:"addTransportationChargesDetail,omitempty"`
-				CodRecipient struct {
-					AccountNumber struct {
-						Value int `json:"value,omitempty"`
-					} `json:"accountNumber,omitempty"`
-				} `json:"codRecipient"`
-				RemitToName                           string `json:"remitToName,omitempty"`
-				CodCollectionType                     string `json:"codCollectionType,omitempty"`
-				FinancialInstitutionContactAndAddress struct {
-					Address struct {
-						StreetLines         []string `json:"streetLines,omitempty"`
-						City                string   `json:"city,omitempty"`
-						StateOrProvinceCode string   `json:"stateOrProvinceCode,omitempty"`
-						PostalCode          string   `json:"postalCode,omitempty"`
-						CountryCode         string   `json:"countryCode,omitempty"`
-						Residential         bool     `json:"residential,omitempty"`
-					} `json:"address,omitempty"`
-					Contact struct {
-						PersonName       string `json:"personName,omitempty"`
-						EmailAddress     string `json:"emailAddress,omitempty"`
-						ParsedPersonName struct {
-							FirstName  string `json:"firstName,omitempty"`
-							LastName   string `json:"lastName,omitempty"`
-							MiddleName string `json:"middleName,omitempty"`
-							Suffix     string `json:"suffix,omitempty"`
-						} `json:"parsedPersonName,omitempty"`
-						PhoneNumber    string `json:"phoneNumber,omitempty"`
-						PhoneExtension string `json:"phoneExtension,omitempty"`
-						CompanyName    string `json:"companyName,omitempty"`
-						FaxNumber      string `json:"faxNumber,omitempty"`
-					} `json:"contact,omitempty"`
-				} `json:"financialInstitutionContactAndAddress,omitempty"`
-				ReturnReferenceIndicatorType string `json:"returnReferenceIndicatorType,omitempty"`
-			} `json:"shipmentCODDetail,omitempty"`
-			ShipmentDryIceDetail struct {
-				TotalWeight struct {
-					Units string `json:"units,omitempty"`
-					Value int    `json:"value,omitempty"`
-				} `json:"totalWeight,omitempty"`
-				PackageCount int `json:"packageCount,omitempty"`
-			} `json:"shipmentDryIceDetail,omitempty"`
-			InternationalControlledExportDetail struct {
-				Type string `json:"type,omitempty"`
-			} `json:"internationalControlledExportDetail,omitempty"`
-			HomeDeliveryPremiumDetail struct {
-				PhoneNumber struct {
-					AreaCode                     string `json:"areaCode,omitempty"`
-					Extension                    string `json:"extension,omitempty"`
-					CountryCode                  string `json:"countryCode,omitempty"`
-					PersonalIdentificationNumber string `json:"personalIdentificationNumber,omitempty"`
-					LocalNumber                  string `json:"localNumber,omitempty"`
-				} `json:"phoneNumber,omitempty"`
-				ShipTimestamp           string `json:"shipTimestamp,omitempty"`
-				HomedeliveryPremiumType string `json:"homedeliveryPremiumType,omitempty"`
-			} `json:"homeDeliveryPremiumDetail,omitempty"`
-			SpecialServiceTypes []string `json:"specialServiceTypes,omitempty"`
-		} `json:"shipmentSpecialServices,omitempty"` //
-		CustomsClearanceDetail struct {
-			CommercialInvoice struct {
-				ShipmentPurpose string `json:"shipmentPurpose,omitempty"`
-			} `json:"commercialInvoice,omitempty"`
-			FreightOnValue string `json:"freightOnValue,omitempty"`
-			DutiesPayment  struct {
-				Payor struct {
-					ResponsibleParty struct {
-						Address struct {
-							StreetLines         []string `json:"streetLines,omitempty"`
-							City                string   `json:"city,omitempty"`
-							StateOrProvinceCode string   `json:"stateOrProvinceCode,omitempty"`
-							PostalCode          string   `json:"postalCode,omitempty"`
-							CountryCode         string   `json:"countryCode,omitempty"`
-							Residential         bool     `json:"residentia,omitempty"`
-						} `json:"address,omitempty"`
-						Contact struct {
-							PersonName       string `json:"personName,omitempty"`
-							EmailAddress     string `json:"emailAddress,omitempty"`
-							ParsedPersonName struct {
-								FirstName  string `json:"firstName,omitempty"`
-								LastName   string `json:"lastName,omitempty"`
-								MiddleName string `json:"middleName,omitempty"`
-								Suffix     string `json:"suffix,omitempty"`
-							} `json:"parsedPersonName,omitempty"`
-							PhoneNumber    string `json:"phoneNumber,omitempty"`
-							PhoneExtension string `json:"phoneExtension,omitempty"`
-							CompanyName    string `json:"companyName,omitempty"`
-							FaxNumber      string `json:"faxNumber,omitempty"`
-						} `json:"contact,omitempty"`
-						AccountNumber struct {
-							Value string `json:"value,omitempty"`
-						} `json:"accountNumber,omitempty"`
-					} `json:"responsibleParty,omitempty"`
-				} `json:"payor,omitempty"`
-				PaymentType string `json:"paymentType,omitempty"`
-			} `json:"dutiesPayment,omitempty"`
-			Commodities []struct {
-				Description string `json:"description,omitempty"`
-				Weight      struct {
-					Units string `json:"units,omitempty"`
-					Value int    `json:"value,omitempty"`
-				} `json:"weight,omitempty"`
-				Quantity     int `json:"quantity,omitempty"`
-				CustomsValue struct {
-					Amount   string `json:"amount,omitempty"`
-					Currency string `json:"currency,omitempty"`
-				} `json:"customsValue,omitempty"`
-				UnitPrice struct {
-					Amount   string `json:"amount,omitempty"`
-					Currency string `json:"currency,omitempty"`
-				} `json:"unitPrice,omitempty"`
-				NumberOfPieces       int    `json:"numberOfPieces,omitempty"`
-				CountryOfManufacture string `json:"countryOfManufacture,omitempty"`
-				QuantityUnits        string `json:"quantityUnits,omitempty"`
-				Name                 string `json:"name,omitempty"`
-				HarmonizedCode       string `json:"harmonizedCode,omitempty"`
-				PartNumber           string `json:"partNumber,omitempty"`
-			} `json:"commodities,omitempty"`
-		} `json:"customsClearanceDetail,omitempty"` //
-		GroupShipment     bool `json:"groupShipment,omitempty"` //
-		ServiceTypeDetail struct {
-			CarrierCode     string `json:"carrierCode,omitempty"`
-			Description     string `json:"description,omitempty"`
-			ServiceName     string `json:"serviceName,omitempty"`
-			ServiceCategory string `json:"serviceCategory,omitempty"`
-		} `json:"serviceTypeDetail,omitempty"` //
-		SmartPostInfoDetail struct {
-			AncillaryEndorsement string `json:"ancillaryEndorsement,omitempty"`
-			HubID                string `json:"hubId,omitempty"`
-			Indicia              string `json:"indicia,omitempty"`
-			SpecialServices      string `json:"specialServices,omitempty"`
-		} `json:"smartPostInfoDetail,omitempty"` //
-		ExpressFreightDetail struct {
-			BookingConfirmationNumber string `json:"bookingConfirmationNumber,omitempty"`
-			ShippersLoadAndCount      int    `json:"shippersLoadAndCount,omitempty"`
-		} `json:"expressFreightDetail,omitempty"` //
-		GroundShipment bool `json:"groundShipment,omitempty"` //
-	} `json:"requestedShipment,omitempty"` //
-	CarrierCodes []string `json:"carrierCodes,omitempty"` //
+	AccountNumber                AccountNumber                `json:"accountNumber"`                          //
+	RateRequestControlParameters RateRequestControlParameters `json:"rateRequestControlParameters,omitempty"` //
+	RequestedShipment            RequestedShipment            `json:"requestedShipment,omitempty"`            //
+	CarrierCodes                 []string                     `json:"carrierCodes,omitempty"`                 //
+}
+
+type Message struct {
+	Code    string `json:"code,omitempty"`    //
+	Message string `json:"message,omitempty"` //
+}
+
+type Alert struct {
+	Code      string `json:"code"`      //
+	Message   string `json:"message"`   //
+	AlertType string `json:"alertType"` //
+}
+
+type Surcharge struct {
+	Type        string  `json:"type"`        //
+	Description string  `json:"description"` //
+	Amount      float64 `json:"amount"`      //
+}
+
+type CurrencyExchangeRate struct {
+	FromCurrency string  `json:"fromCurrency"` //
+	IntoCurrency string  `json:"intoCurrency"` //
+	Rate         float64 `json:"rate"`         // units of IntoCurrency per FromCurrency
+}
+
+type ShipmentRateDetail struct {
+	RateZone             string               `json:"rateZone"`                 //
+	DimDivisor           int                  `json:"dimDivisor"`               //
+	FuelSurchargePercent float64              `json:"fuelSurchargePercent"`     //
+	TotalSurcharges      float64              `json:"totalSurcharges"`          //
+	TotalFreightDiscount float64              `json:"totalFreightDiscount"`     //
+	SurCharges           []Surcharge          `json:"surCharges"`               //
+	PricingCode          string               `json:"pricingCode"`              //
+	CurrencyExchangeRate CurrencyExchangeRate `json:"currencyExchangeRate"`     //
+	TotalBillingWeight   Weight               `json:"totalBillingWeight"`       //
+	DutiesAndTaxes       []CommodityTax       `json:"dutiesAndTaxes,omitempty"` // with edtRequestType ALL
+	Currency             string               `json:"currency"`                 //
+}
+
+// CommodityTax is the estimated duties and taxes of the commodities with one
+// harmonized code.
+type CommodityTax struct {
+	HarmonizedCode string      `json:"harmonizedCode"` //
+	Taxes          []TaxDetail `json:"taxes"`          //
+}
+
+type TaxDetail struct {
+	TaxType       string `json:"taxType"`                 // e.g. CUSTOMS, INSURANCE, VAT
+	Name          string `json:"name,omitempty"`          //
+	Description   string `json:"description,omitempty"`   //
+	Formula       string `json:"formula,omitempty"`       //
+	EffectiveDate string `json:"effectiveDate,omitempty"` //
+	TaxableValue  Money  `json:"taxableValue"`            //
+	Amount        Money  `json:"amount"`                  //
+}
+
+type RatedShipmentDetail struct {
+	RateType                         string             `json:"rateType"`                         //
+	RatedWeightMethod                string             `json:"ratedWeightMethod"`                //
+	TotalDiscounts                   float64            `json:"totalDiscounts"`                   //
+	TotalBaseCharge                  float64            `json:"totalBaseCharge"`                  //
+	TotalNetCharge                   float64            `json:"totalNetCharge"`                   //
+	TotalVatCharge                   float64            `json:"totalVatCharge"`                   //
+	TotalNetFedExCharge              float64            `json:"totalNetFedExCharge"`              //
+	TotalDutiesAndTaxes              float64            `json:"totalDutiesAndTaxes"`              //
+	TotalNetChargeWithDutiesAndTaxes float64            `json:"totalNetChargeWithDutiesAndTaxes"` //
+	TotalDutiesTaxesAndFees          float64            `json:"totalDutiesTaxesAndFees"`          //
+	TotalAncillaryFeesAndTaxes       float64            `json:"totalAncillaryFeesAndTaxes"`       //
+	AncillaryFeesAndTaxes            []Surcharge        `json:"ancillaryFeesAndTaxes,omitempty"`  // e.g. clearance fees
+	ShipmentRateDetail               ShipmentRateDetail `json:"shipmentRateDetail,omitempty"`     //
+	RatedPackages                    []RatedPackage     `json:"ratedPackages,omitempty"`          //
+	Currency                         string             `json:"currency"`                         //
+}
+
+type RatedPackage struct {
+	GroupNumber          int               `json:"groupNumber"`          //
+	EffectiveNetDiscount float64           `json:"effectiveNetDiscount"` //
+	PackageRateDetail    PackageRateDetail `json:"packageRateDetail"`    //
+}
+
+type PackageRateDetail struct {
+	RateType              string      `json:"rateType"`              //
+	RatedWeightMethod     string      `json:"ratedWeightMethod"`     //
+	BaseCharge            float64     `json:"baseCharge"`            //
+	NetFreight            float64     `json:"netFreight"`            //
+	TotalSurcharges       float64     `json:"totalSurcharges"`       //
+	NetFedExCharge        float64     `json:"netFedExCharge"`        //
+	TotalTaxes            float64     `json:"totalTaxes"`            //
+	NetCharge             float64     `json:"netCharge"`             //
+	TotalRebates          float64     `json:"totalRebates"`          //
+	BillingWeight         Weight      `json:"billingWeight"`         //
+	TotalFreightDiscounts float64     `json:"totalFreightDiscounts"` //
+	Surcharges            []Surcharge `json:"surcharges"`            //
+	Currency              string      `json:"currency"`              //
+}
+
+type OperationalDetail struct {
+	OriginLocationIds                       string `json:"originLocationIds"`                       //
+	CommitDays                              string `json:"commitDays"`                              //
+	ServiceCode                             string `json:"serviceCode"`                             //
+	AirportID                               string `json:"airportId"`                               //
+	Scac                                    string `json:"scac"`                                    //
+	OriginServiceAreas                      string `json:"originServiceAreas"`                      //
+	DeliveryDay                             string `json:"deliveryDay"`                             //
+	OriginLocationNumbers                   int    `json:"originLocationNumbers"`                   //
+	DestinationPostalCode                   string `json:"destinationPostalCode"`                   //
+	CommitDate                              string `json:"commitDate"`                              //
+	AstraDescription                        string `json:"astraDescription"`                        //
+	DeliveryDate                            string `json:"deliveryDate"`                            //
+	DeliveryEligibilities                   string `json:"deliveryEligibilities"`                   //
+	IneligibleForMoneyBackGuarantee         bool   `json:"ineligibleForMoneyBackGuarantee"`         //
+	MaximumTransitTime                      string `json:"maximumTransitTime"`                      //
+	AstraPlannedServiceLevel                string `json:"astraPlannedServiceLevel"`                //
+	DestinationLocationIds                  string `json:"destinationLocationIds"`                  //
+	DestinationLocationStateOrProvinceCodes string `json:"destinationLocationStateOrProvinceCodes"` //
+	TransitTime                             string `json:"transitTime"`                             //
+	PackagingCode                           string `json:"packagingCode"`                           //
+	DestinationLocationNumbers              int    `json:"destinationLocationNumbers"`              //
+	PublishedDeliveryTime                   string `json:"publishedDeliveryTime"`                   //
+	CountryCodes                            string `json:"countryCodes"`                            //
+	StateOrProvinceCodes                    string `json:"stateOrProvinceCodes"`                    //
+	UrsaPrefixCode                          string `json:"ursaPrefixCode"`                          //
+	UrsaSuffixCode                          string `json:"ursaSuffixCode"`                          //
+	DestinationServiceAreas                 string `json:"destinationServiceAreas"`                 //
+	OriginPostalCodes                       string `json:"originPostalCodes"`                       //
+	CustomTransitTime                       string `json:"customTransitTime"`                       //
+}
+
+type ServiceName struct {
+	Type     string `json:"type"`     //
+	Encoding string `json:"encoding"` //
+	Value    string `json:"value"`    //
+}
+
+type ServiceDescription struct {
+	ServiceID         string        `json:"serviceId"`         //
+	ServiceType       string        `json:"serviceType"`       //
+	Code              string        `json:"code"`              //
+	Names             []ServiceName `json:"names"`             //
+	OperatingOrgCodes []string      `json:"operatingOrgCodes"` //
+	ServiceCategory   string        `json:"serviceCategory"`   //
+	Description       string        `json:"description"`       //
+	AstraDescription  string        `json:"astraDescription"`  //
+}
+
+type RateReplyDetail struct {
+	ServiceType          string                `json:"serviceType"`                    //
+	ServiceName          string                `json:"serviceName"`                    //
+	PackagingType        string                `json:"packagingType"`                  //
+	CustomerMessages     []Message             `json:"customerMessages,omitempty"`     //
+	RatedShipmentDetails []RatedShipmentDetail `json:"ratedShipmentDetails,omitempty"` //
+	AnonymouslyAllowable bool                  `json:"anonymouslyAllowable,omitempty"` //
+	OperationalDetail    OperationalDetail     `json:"operationalDetail,omitempty"`    //
+	SignatureOptionType  string                `json:"signatureOptionType,omitempty"`  //
+	ServiceDescription   ServiceDescription    `json:"serviceDescription,omitempty"`   //
+	Commit               Commit                `json:"commit,omitempty"`               //
+}
+
+type CommitDateDetail struct {
+	DayOfWeek    string `json:"dayOfWeek"`    //
+	DayCxsFormat string `json:"dayCxsFormat"` //
+}
+
+type Commit struct {
+	DateDetail CommitDateDetail `json:"dateDetail"` //
+}
+
+type RateOutput struct {
+	RateReplyDetails []RateReplyDetail `json:"rateReplyDetails,omitempty"` //
+	QuoteDate        string            `json:"quoteDate"`                  //
+	Encoded          bool              `json:"encoded"`                    //
+	Alerts           []Alert           `json:"alerts"`                     //
 }
 
 type RateResponse struct {
-	TransactionID         string `json:"transactionId"`
-	CustomerTransactionID string `json:"customerTransactionId"`
-	Output                struct {
-		RateReplyDetails []struct {
-			ServiceType      string `json:"serviceType"`
-			ServiceName      string `json:"serviceName"`
-			PackagingType    string `json:"packagingType"`
-			CustomerMessages []struct {
-				Code    string `json:"code"`
-				Message string `json:"message"`
-			} `json:"customerMessages,omitempty"`
-			RatedShipmentDetails []struct {
-				RateType                         string  `json:"rateType"`
-				RatedWeightMethod                string  `json:"ratedWeightMethod"`
-				TotalDiscounts                   int     `json:"totalDiscounts"`
-				TotalBaseCharge                  float64 `json:"totalBaseCharge"`
-				TotalNetCharge                   float64 `json:"totalNetCharge"`
-				TotalVatCharge                   int     `json:"totalVatCharge"`
-				TotalNetFedExCharge              float64 `json:"totalNetFedExCharge"`
-				TotalDutiesAndTaxes              int     `json:"totalDutiesAndTaxes"`
-				TotalNetChargeWithDutiesAndTaxes float64 `json:"totalNetChargeWithDutiesAndTaxes"`
-				TotalDutiesTaxesAndFees          int     `json:"totalDutiesTaxesAndFees"`
-				TotalAncillaryFeesAndTaxes       int     `json:"totalAncillaryFeesAndTaxes"`
-				ShipmentRateDetail               struct {
-					RateZone             string  `json:"rateZone"`
-					DimDivisor           int     `json:"dimDivisor"`
-					FuelSurchargePercent float64 `json:"fuelSurchargePercent"`
-					TotalSurcharges      float64 `json:"totalSurcharges"`
-					TotalFreightDiscount int     `json:"totalFreightDiscount"`
-					SurCharges           []struct {
-						Type        string  `json:"type"`
-						Description string  `json:"description"`
-						Amount      float64 `json:"amount"`
-					} `json:"surCharges"`
-					PricingCode          string `json:"pricingCode"`
-					CurrencyExchangeRate struct {
-						FromCurrency string `json:"fromCurrency"`
-						IntoCurrency string `json:"intoCurrency"`
-						Rate         int    `json:"rate"`
-					} `json:"currencyExchangeRate"`
-					TotalBillingWeight struct {
-						Units string `json:"units"`
-						Valu  int    `json:"valu"`
-					} `json:"totalBillingWeight"`
-					Currency string `json:"currency"`
-				} `json:"shipmentRateDetail,omitempty"`
-				Currency string `json:"currency"`
-			} `json:"ratedShipmentDetails,omitempty"`
-			AnonymouslyAllowable bool `json:"anonymouslyAllowable,omitempty"`
-			OperationalDetail    struct {
-				OriginLocationIds                       string `json:"originLocationIds"`
-				CommitDays                              string `json:"commitDays"`
-				ServiceCode                             string `json:"serviceCode"`
-				AirportID                               string `json:"airportId"`
-				Scac                                    string `json:"scac"`
-				OriginServiceAreas                      string `json:"originServiceAreas"`
-				DeliveryDay                             string `json:"deliveryDay"`
-				OriginLocationNumbers                   int    `json:"originLocationNumbers"`
-				DestinationPostalCode                   string `json:"destinationPostalCode"`
-				CommitDate                              string `json:"commitDate"`
-				AstraDescription                        string `json:"astraDescription"`
-				DeliveryDate                            string `json:"deliveryDate"`
-				DeliveryEligibilities                   string `json:"deliveryEligibilities"`
-				IneligibleForMoneyBackGuarantee         bool   `json:"ineligibleForMoneyBackGuarantee"`
-				MaximumTransitTime                      string `json:"maximumTransitTime"`
-				AstraPlannedServiceLevel                string `json:"astraPlannedServiceLevel"`
-				DestinationLocationIds                  string `json:"destinationLocationIds"`
-				DestinationLocationStateOrProvinceCodes string `json:"destinationLocationStateOrProvinceCodes"`
-				TransitTime                             string `json:"transitTime"`
-				PackagingCode                           string `json:"packagingCode"`
-				DestinationLocationNumbers              int    `json:"destinationLocationNumbers"`
-				PublishedDeliveryTime                   string `json:"publishedDeliveryTime"`
-				CountryCodes                            string `json:"countryCodes"`
-				StateOrProvinceCodes                    string `json:"stateOrProvinceCodes"`
-				UrsaPrefixCode                          string `json:"ursaPrefixCode"`
-				UrsaSuffixCode                          string `json:"ursaSuffixCode"`
-				DestinationServiceAreas                 string `json:"destinationServiceAreas"`
-				OriginPostalCodes                       string `json:"originPostalCodes"`
-				CustomTransitTime                       string `json:"customTransitTime"`
-			} `json:"operationalDetail,omitempty"`
-			SignatureOptionType string `json:"signatureOptionType,omitempty"`
-			ServiceDescription  struct {
-				ServiceID   string `json:"serviceId"`
-				ServiceType string `json:"serviceType"`
-				Code        string `json:"code"`
-				Names       []struct {
-					Type     string `json:"type"`
-					Encoding string `json:"encoding"`
-					Value    string `json:"value"`
-				} `json:"names"`
-				OperatingOrgCodes []string `json:"operatingOrgCodes"`
-				ServiceCategory   string   `json:"serviceCategory"`
-				Description       string   `json:"description"`
-				AstraDescription  string   `json:"astraDescription"`
-			} `json:"serviceDescription,omitempty"`
-			Commit struct {
-				DateDetail struct {
-					DayOfWeek    string `json:"dayOfWeek"`
-					DayCxsFormat string `json:"dayCxsFormat"`
-				} `json:"dateDetail"`
-			} `json:"commit,omitempty"`
-		} `json:"rateReplyDetails,omitempty"`
-		QuoteDate string `json:"quoteDate"`
-		Encoded   bool   `json:"encoded"`
-		Alerts    []struct {
-			Code      string `json:"code"`
-			Message   string `json:"message"`
-			AlertType string `json:"alertType"`
-		} `json:"alerts"`
-	} `json:"output,omitempty"`
-	Errors []struct {
-		Code    string `json:"code,omitempty"`
-		Message string `json:"message,omitempty"`
-	} `json:"errors,omitempty"`
+	TransactionID         string     `json:"transactionId"`         //
+	CustomerTransactionID string     `json:"customerTransactionId"` //
+	Output                RateOutput `json:"output,omitempty"`      //
+	Errors                []Message  `json:"errors,omitempty"`      //
+}
+
+type XMLCredential struct {
+	Text     string `xml:",chardata"`
+	Key      string `xml:"Key,omitempty"`
+	Password string `xml:"Password,omitempty"`
+}
+
+type XMLTransactionDetail struct {
+	Text                  string `xml:",chardata"`
+	CustomerTransactionId string `xml:"CustomerTransactionId,omitempty"`
+}
+
+type XMLVersion struct {
+	Text         string `xml:",chardata"`
+	ServiceId    string `xml:"ServiceId,omitempty"`
+	Major        string `xml:"Major,omitempty"`
+	Intermediate string `xml:"Intermediate,omitempty"`
+	Minor        string `xml:"Minor,omitempty"`
+}
+
+type XMLWeight struct {
+	Text  string `xml:",chardata"`
+	Units string `xml:"Units,omitempty"`
+	Value string `xml:"Value,omitempty"`
+}
+
+type XMLDimensions struct {
+	Text   string `xml:",chardata"`
+	Length string `xml:"Length,omitempty"`
+	Width  string `xml:"Width,omitempty"`
+	Height string `xml:"Height,omitempty"`
+	Units  string `xml:"Units,omitempty"`
+}
+
+type XMLMoney struct {
+	Text     string `xml:",chardata"`
+	Currency string `xml:"Currency,omitempty"`
+	Amount   string `xml:"Amount,omitempty"`
+}
+
+type XMLContact struct {
+	Text           string `xml:",chardata"`
+	PersonName     string `xml:"PersonName,omitempty"`
+	CompanyName    string `xml:"CompanyName,omitempty"`
+	PhoneNumber    string `xml:"PhoneNumber,omitempty"`
+	PhoneExtension string `xml:"PhoneExtension,omitempty"`
+	FaxNumber      string `xml:"FaxNumber,omitempty"`
+	EMailAddress   string `xml:"EMailAddress,omitempty"`
+}
+
+type XMLAddress struct {
+	Text                string   `xml:",chardata"`
+	StreetLines         []string `xml:"StreetLines,omitempty"`
+	City                string   `xml:"City,omitempty"`
+	StateOrProvinceCode string   `xml:"StateOrProvinceCode,omitempty"`
+	PostalCode          string   `xml:"PostalCode,omitempty"`
+	CountryCode         string   `xml:"CountryCode,omitempty"`
+	CountryName         string   `xml:"CountryName,omitempty"`
+	Residential         string   `xml:"Residential,omitempty"`
+}
+
+type XMLTin struct {
+	Text    string `xml:",chardata"`
+	TinType string `xml:"TinType,omitempty"`
+	Number  string `xml:"Number,omitempty"`
+}
+
+// XMLParty leaves out Contact and Address when they are nil, as for a payor
+// known only by its account.
+type XMLParty struct {
+	Text          string      `xml:",chardata"`
+	AccountNumber string      `xml:"AccountNumber,omitempty"`
+	Tins          []XMLTin    `xml:"Tins,omitempty"`
+	Contact       *XMLContact `xml:"Contact,omitempty"`
+	Address       *XMLAddress `xml:"Address,omitempty"`
+}
+
+type XMLPayor struct {
+	Text             string   `xml:",chardata"`
+	ResponsibleParty XMLParty `xml:"ResponsibleParty,omitempty"`
+}
+
+type XMLPayment struct {
+	Text        string   `xml:",chardata"`
+	PaymentType string   `xml:"PaymentType,omitempty"`
+	Payor       XMLPayor `xml:"Payor,omitempty"`
+}
+
+type XMLContentRecord struct {
+	Text             string `xml:",chardata"`
+	PartNumber       string `xml:"PartNumber,omitempty"`
+	ItemNumber       string `xml:"ItemNumber,omitempty"`
+	ReceivedQuantity string `xml:"ReceivedQuantity,omitempty"`
+	Description      string `xml:"Description,omitempty"`
+}
+
+type XMLContactAndAddress struct {
+	Text    string     `xml:",chardata"`
+	Contact XMLContact `xml:"Contact,omitempty"`
+	Address XMLAddress `xml:"Address,omitempty"`
+}
+
+type XMLCodTransportationCharges struct {
+	Text             string `xml:",chardata"`
+	RateTypeBasis    string `xml:"RateTypeBasis,omitempty"`
+	ChargeBasis      string `xml:"ChargeBasis,omitempty"`
+	ChargeBasisLevel string `xml:"ChargeBasisLevel,omitempty"`
+}
+
+type XMLCodDetail struct {
+	Text                                  string                       `xml:",chardata"`
+	CodCollectionAmount                   *XMLMoney                    `xml:"CodCollectionAmount,omitempty"`
+	AddTransportationChargesDetail        *XMLCodTransportationCharges `xml:"AddTransportationChargesDetail,omitempty"`
+	CollectionType                        string                       `xml:"CollectionType,omitempty"`
+	CodRecipient                          *XMLParty                    `xml:"CodRecipient,omitempty"`
+	FinancialInstitutionContactAndAddress *XMLContactAndAddress        `xml:"FinancialInstitutionContactAndAddress,omitempty"`
+	RemitToName                           string                       `xml:"RemitToName,omitempty"`
+	ReferenceIndicator                    string                       `xml:"ReferenceIndicator,omitempty"`
+}
+
+type XMLHoldAtLocationDetail struct {
+	Text                      string               `xml:",chardata"`
+	LocationContactAndAddress XMLContactAndAddress `xml:"LocationContactAndAddress,omitempty"`
+	LocationType              string               `xml:"LocationType,omitempty"`
+	LocationId                string               `xml:"LocationId,omitempty"`
+}
+
+type XMLDryIceDetail struct {
+	Text         string    `xml:",chardata"`
+	PackageCount string    `xml:"PackageCount,omitempty"`
+	TotalWeight  XMLWeight `xml:"TotalWeight,omitempty"`
+}
+
+type XMLShipmentSpecialServices struct {
+	Text                 string                   `xml:",chardata"`
+	SpecialServiceTypes  []SpecialServiceType     `xml:"SpecialServiceTypes,omitempty"`
+	CodDetail            *XMLCodDetail            `xml:"CodDetail,omitempty"`
+	HoldAtLocationDetail *XMLHoldAtLocationDetail `xml:"HoldAtLocationDetail,omitempty"`
+	ShipmentDryIceDetail *XMLDryIceDetail         `xml:"ShipmentDryIceDetail,omitempty"`
+}
+
+type XMLPackageSpecialServices struct {
+	Text                string               `xml:",chardata"`
+	SpecialServiceTypes []SpecialServiceType `xml:"SpecialServiceTypes,omitempty"`
+	CodDetail           *XMLCodDetail        `xml:"CodDetail,omitempty"`
+	DryIceWeight        *XMLWeight           `xml:"DryIceWeight,omitempty"`
+}
+
+type XMLCommodity struct {
+	Text                 string     `xml:",chardata"`
+	Name                 string     `xml:"Name,omitempty"`
+	NumberOfPieces       string     `xml:"NumberOfPieces,omitempty"`
+	Description          string     `xml:"Description,omitempty"`
+	CountryOfManufacture string     `xml:"CountryOfManufacture,omitempty"`
+	HarmonizedCode       string     `xml:"HarmonizedCode,omitempty"`
+	Weight               *XMLWeight `xml:"Weight,omitempty"`
+	Quantity             string     `xml:"Quantity,omitempty"`
+	QuantityUnits        string     `xml:"QuantityUnits,omitempty"`
+	UnitPrice            *XMLMoney  `xml:"UnitPrice,omitempty"`
+	CustomsValue         *XMLMoney  `xml:"CustomsValue,omitempty"`
+	PartNumber           string     `xml:"PartNumber,omitempty"`
+}
+
+type XMLCommercialInvoice struct {
+	Text    string          `xml:",chardata"`
+	Purpose ShipmentPurpose `xml:"Purpose,omitempty"`
+}
+
+type XMLCustomsClearanceDetail struct {
+	Text              string                `xml:",chardata"`
+	DutiesPayment     *XMLPayment           `xml:"DutiesPayment,omitempty"`
+	CustomsValue      *XMLMoney             `xml:"CustomsValue,omitempty"`
+	FreightOnValue    string                `xml:"FreightOnValue,omitempty"`
+	CommercialInvoice *XMLCommercialInvoice `xml:"CommercialInvoice,omitempty"`
+	Commodities       []XMLCommodity        `xml:"Commodities,omitempty"`
+}
+
+type XMLRequestedPackageLineItem struct {
+	Text                     string                     `xml:",chardata"`
+	SequenceNumber           string                     `xml:"SequenceNumber,omitempty"`
+	GroupNumber              string                     `xml:"GroupNumber,omitempty"`
+	GroupPackageCount        string                     `xml:"GroupPackageCount,omitempty"`
+	InsuredValue             *XMLMoney                  `xml:"InsuredValue,omitempty"`
+	Weight                   XMLWeight                  `xml:"Weight,omitempty"`
+	Dimensions               XMLDimensions              `xml:"Dimensions,omitempty"`
+	SpecialServicesRequested *XMLPackageSpecialServices `xml:"SpecialServicesRequested,omitempty"`
+	ContentRecords           []XMLContentRecord         `xml:"ContentRecords,omitempty"`
+}
+
+type XMLRequestedShipment struct {
+	Text                      string                        `xml:",chardata"`
+	ShipTimestamp             string                        `xml:"ShipTimestamp,omitempty"`
+	DropoffType               DropoffType                   `xml:"DropoffType,omitempty"`
+	ServiceType               ServiceType                   `xml:"ServiceType,omitempty"`
+	PackagingType             PackagingType                 `xml:"PackagingType,omitempty"`
+	TotalWeight               XMLWeight                     `xml:"TotalWeight,omitempty"`
+	PreferredCurrency         string                        `xml:"PreferredCurrency,omitempty"`
+	Shipper                   XMLParty                      `xml:"Shipper,omitempty"`
+	Recipient                 XMLParty                      `xml:"Recipient,omitempty"`
+	ShippingChargesPayment    XMLPayment                    `xml:"ShippingChargesPayment,omitempty"`
+	SpecialServicesRequested  *XMLShipmentSpecialServices   `xml:"SpecialServicesRequested,omitempty"`
+	CustomsClearanceDetail    *XMLCustomsClearanceDetail    `xml:"CustomsClearanceDetail,omitempty"`
+	RateRequestTypes          []RateRequestType             `xml:"RateRequestTypes,omitempty"`
+	EdtRequestType            string                        `xml:"EdtRequestType,omitempty"`
+	PackageCount              string                        `xml:"PackageCount,omitempty"`
+	RequestedPackageLineItems []XMLRequestedPackageLineItem `xml:"RequestedPackageLineItems,omitempty"`
 }
 
 type RateXMLRequest struct {
-	XMLName    xml.Name `xml:"SOAP-ENV:Envelope"`
-	Xmlns_xsi  string   `xml:"xmlns xsi,attr,omitempty"`
-	Xmlns_xsd  string   `xml:"xmlns xsd,attr,omitempty"`
-	Xmlnsns    string   `xml:"xmlns xns,attr,omitempty"`
-	Xmlns_soap string   `xml:"xmlns SOAP-ENV,attr,omitempty"`
-	Xmlns_enc  string   `xml:"xmlns SOAP-ENC,attr,omitempty"`
-	Text       string   `xml:",chardata"`
-	Body       struct {
-		Text        string `xml:",chardata"`
-		RateRequest struct {
-			Text                    string `xml:",chardata"`
-			WebAuthenticationDetail struct {
-				Text             string `xml:",chardata"`
-				ParentCredential struct {
-					Text     string `xml:",chardata"`
-					Key      string `xml:"Key,omitempty"`
-					Password string `xml:"Password,omitempty"`
-				} `xml:"ParentCredential,omitempty"`
-				UserCredential struct {
-					Text     string `xml:",chardata"`
-					Key      string `xml:"Key,omitempty"`
-					Password string `xml:"Password,omitempty"`
-				} `xml:"UserCredential"`
-			} `xml:"WebAuthenticationDetail"`
-			ClientDetail struct {
-				Text          string `xml:",chardata"`
-				AccountNumber string `xml:"AccountNumber,omitempty"`
-				MeterNumber   string `xml:"MeterNumber,omitempty"`
-				SoftwareId    string `xml:"SoftwareId,omitempty"`
-			} `xml:"ClientDetail,omitempty"`
-			TransactionDetail struct {
-				Text                  string `xml:",chardata"`
-				CustomerTransactionId string `xml:"CustomerTransactionId,omitempty"`
-			} `xml:"TransactionDetail,omitempty"`
-			Version struct {
-				Text         string `xml:",chardata"`
-				ServiceId    string `xml:"ServiceId,omitempty"`
-				Major        string `xml:"Major,omitempty"`
-				Intermediate string `xml:"Intermediate,omitempty"`
-				Minor        string `xml:"Minor,omitempty"`
-			} `xml:"Version,omitempty"`
-			RequestedShipment struct {
-				Text          string `xml:",chardata"`
-				ShipTimestamp string `xml:"ShipTimestamp,omitempty"`
-				DropoffType   string `xml:"DropoffType,omitempty"`
-				ServiceType   string `xml:"ServiceType,omitempty"`
-				PackagingType string `xml:"PackagingType,omitempty"`
-				TotalWeight   struct {
-					Text  string `xml:",chardata"`
-					Units string `xml:"Units,omitempty"`
-					Value string `xml:"Value,omitempty"`
-				} `xml:"TotalWeight,omitempty"`
-				Shipper struct {
-					Text          string `xml:",chardata"`
-					AccountNumber string `xml:"AccountNumber,omitempty"`
-					Contact       struct {
-						Text        string `xml:",chardata"`
-						CompanyName string `xml:"CompanyName,omitempty"`
-						PhoneNumber string `xml:"PhoneNumber,omitempty"`
-					} `xml:"Contact,omitempty"`
-					Address struct {
-						Text                string   `xml:",chardata"`
-						StreetLines         []string `xml:"StreetLines,omitempty"`
-						City                string   `xml:"City,omitempty"`
-						StateOrProvinceCode string   `xml:"StateOrProvinceCode,omitempty"`
-						PostalCode          string   `xml:"PostalCode,omitempty"`
-						CountryCode         string   `xml:"CountryCode,omitempty"`
-					} `xml:"Address,omitempty"`
-				} `xml:"Shipper,omitempty"`
-				Recipient struct {
-					Text          string `xml:",chardata"`
-					AccountNumber string `xml:"AccountNumber,omitempty"`
-					Contact       struct {
-						Text        string `xml:",chardata"`
-						PersonName  string `xml:"PersonName,omitempty"`
-						PhoneNumber string `xml:"PhoneNumber,omitempty"`
-					} `xml:"Contact,omitempty"`
-					Address struct {
-						Text                string   `xml:",chardata"`
-						StreetLines         []string `xml:"StreetLines,omitempty"`
-						City                string   `xml:"City,omitempty"`
-						StateOrProvinceCode string   `xml:"StateOrProvinceCode,omitempty"`
-						PostalCode          string   `xml:"PostalCode,omitempty"`
-						CountryCode         string   `xml:"CountryCode,omitempty"`
-						CountryName         string   `xml:"CountryName,omitempty"`
-						Residential         string   `xml:"Residential,omitempty"`
-					} `xml:"Address,omitempty"`
-				} `xml:"Recipient,omitempty"`
-				ShippingChargesPayment struct {
-					Text        string `xml:",chardata"`
-					PaymentType string `xml:"PaymentType,omitempty"`
-					Payor       struct {
-						Text             string `xml:",chardata"`
-						ResponsibleParty struct {
-							Text          string `xml:",chardata"`
-							AccountNumber string `xml:"AccountNumber,omitempty"`
-							Tins          struct {
-								Text    string `xml:",chardata"`
-								TinType string `xml:"TinType,omitempty"`
-								Number  string `xml:"Number,omitempty"`
-							} `xml:"Tins,omitempty"`
-						} `xml:"ResponsibleParty,omitempty"`
-					} `xml:"Payor,omitempty"`
-				} `xml:"ShippingChargesPayment,omitempty"`
-				RateRequestTypes          string `xml:"RateRequestTypes,omitempty"`
-				PackageCount              string `xml:"PackageCount,omitempty"`
-				RequestedPackageLineItems struct {
-					Text              string `xml:",chardata"`
-					SequenceNumber    string `xml:"SequenceNumber,omitempty"`
-					GroupNumber       string `xml:"GroupNumber,omitempty"`
-					GroupPackageCount string `xml:"GroupPackageCount,omitempty"`
-					Weight            struct {
-						Text  string `xml:",chardata"`
-						Units string `xml:"Units,omitempty"`
-						Value string `xml:"Value,omitempty"`
-					} `xml:"Weight,omitempty"`
-					Dimensions struct {
-						Text   string `xml:",chardata"`
-						Length string `xml:"Length,omitempty"`
-						Width  string `xml:"Width,omitempty"`
-						Height string `xml:"Height,omitempty"`
-						Units  string `xml:"Units,omitempty"`
-					} `xml:"Dimensions,omitempty"`
-					ContentRecords struct {
-						Text             string `xml:",chardata"`
-						PartNumber       string `xml:"PartNumber,omitempty"`
-						ItemNumber       string `xml:"ItemNumber,omitempty"`
-						ReceivedQuantity string `xml:"ReceivedQuantity,omitempty"`
-						Description      string `xml:"Description,omitempty"`
-					} `xml:"ContentRecords,omitempty"`
-				} `xml:"RequestedPackageLineItems,omitempty"`
-			} `xml:"RequestedShipment,omitempty"`
-		} `xml:"RateRequest,omitempty"`
-	} `xml:"SOAP-ENV:Body"`
+	XMLName    xml.Name       `xml:"SOAP-ENV:Envelope"`
+	Xmlns_xsi  string         `xml:"xmlns xsi,attr,omitempty"`
+	Xmlns_xsd  string         `xml:"xmlns xsd,attr,omitempty"`
+	Xmlnsns    string         `xml:"xmlns xns,attr,omitempty"`
+	Xmlns_soap string         `xml:"xmlns SOAP-ENV,attr,omitempty"`
+	Xmlns_enc  string         `xml:"xmlns SOAP-ENC,attr,omitempty"`
+	Text       string         `xml:",chardata"`
+	Body       XMLRequestBody `xml:"SOAP-ENV:Body"`
+}
+
+type XMLRequestBody struct {
+	Text        string         `xml:",chardata"`
+	RateRequest XMLRateRequest `xml:"RateRequest,omitempty"`
+}
+
+type XMLWebAuthenticationDetail struct {
+	Text             string        `xml:",chardata"`
+	ParentCredential XMLCredential `xml:"ParentCredential,omitempty"`
+	UserCredential   XMLCredential `xml:"UserCredential"`
+}
+
+type XMLClientDetail struct {
+	Text          string `xml:",chardata"`
+	AccountNumber string `xml:"AccountNumber,omitempty"`
+	MeterNumber   string `xml:"MeterNumber,omitempty"`
+	SoftwareId    string `xml:"SoftwareId,omitempty"`
+}
+
+type XMLRateRequest struct {
+	Text                    string                     `xml:",chardata"`
+	WebAuthenticationDetail XMLWebAuthenticationDetail `xml:"WebAuthenticationDetail"`
+	ClientDetail            XMLClientDetail            `xml:"ClientDetail,omitempty"`
+	TransactionDetail       XMLTransactionDetail       `xml:"TransactionDetail,omitempty"`
+	Version                 XMLVersion                 `xml:"Version,omitempty"`
+	RequestedShipment       XMLRequestedShipment       `xml:"RequestedShipment,omitempty"`
+}
+
+type XMLNotification struct {
+	Text             string `xml:",chardata"`
+	Severity         string `xml:"Severity,omitempty"`
+	Source           string `xml:"Source,omitempty"`
+	Code             string `xml:"Code,omitempty"`
+	Message          string `xml:"Message,omitempty"`
+	LocalizedMessage string `xml:"LocalizedMessage,omitempty"`
+}
+
+type XMLSurcharge struct {
+	Text          string   `xml:",chardata"`
+	SurchargeType string   `xml:"SurchargeType,omitempty"`
+	Level         string   `xml:"Level,omitempty"`
+	Description   string   `xml:"Description,omitempty"`
+	Amount        XMLMoney `xml:"Amount,omitempty"`
+}
+
+type XMLShipmentRateDetail struct {
+	Text                             string                  `xml:",chardata"`
+	RateType                         string                  `xml:"RateType,omitempty"`
+	RateZone                         string                  `xml:"RateZone,omitempty"`
+	RatedWeightMethod                string                  `xml:"RatedWeightMethod,omitempty"`
+	CurrencyExchangeRate             XMLCurrencyExchangeRate `xml:"CurrencyExchangeRate,omitempty"`
+	DimDivisor                       string                  `xml:"DimDivisor,omitempty"`
+	FuelSurchargePercent             string                  `xml:"FuelSurchargePercent,omitempty"`
+	TotalBillingWeight               XMLWeight               `xml:"TotalBillingWeight,omitempty"`
+	TotalBaseCharge                  XMLMoney                `xml:"TotalBaseCharge,omitempty"`
+	TotalFreightDiscounts            XMLMoney                `xml:"TotalFreightDiscounts,omitempty"`
+	TotalNetFreight                  XMLMoney                `xml:"TotalNetFreight,omitempty"`
+	TotalSurcharges                  XMLMoney                `xml:"TotalSurcharges,omitempty"`
+	TotalNetFedExCharge              XMLMoney                `xml:"TotalNetFedExCharge,omitempty"`
+	TotalTaxes                       XMLMoney                `xml:"TotalTaxes,omitempty"`
+	TotalNetCharge                   XMLMoney                `xml:"TotalNetCharge,omitempty"`
+	TotalRebates                     XMLMoney                `xml:"TotalRebates,omitempty"`
+	TotalDutiesAndTaxes              XMLMoney                `xml:"TotalDutiesAndTaxes,omitempty"`
+	TotalAncillaryFeesAndTaxes       XMLMoney                `xml:"TotalAncillaryFeesAndTaxes,omitempty"`
+	TotalDutiesTaxesAndFees          XMLMoney                `xml:"TotalDutiesTaxesAndFees,omitempty"`
+	TotalNetChargeWithDutiesAndTaxes XMLMoney                `xml:"TotalNetChargeWithDutiesAndTaxes,omitempty"`
+	Surcharges                       []XMLSurcharge          `xml:"Surcharges,omitempty"`
+}
+
+type XMLCurrencyExchangeRate struct {
+	Text         string `xml:",chardata"`
+	FromCurrency string `xml:"FromCurrency,omitempty"`
+	IntoCurrency string `xml:"IntoCurrency,omitempty"`
+	Rate         string `xml:"Rate,omitempty"`
+}
+
+type XMLPackageRateDetail struct {
+	Text                  string         `xml:",chardata"`
+	RateType              string         `xml:"RateType,omitempty"`
+	RatedWeightMethod     string         `xml:"RatedWeightMethod,omitempty"`
+	BillingWeight         XMLWeight      `xml:"BillingWeight,omitempty"`
+	BaseCharge            XMLMoney       `xml:"BaseCharge,omitempty"`
+	TotalFreightDiscounts XMLMoney       `xml:"TotalFreightDiscounts,omitempty"`
+	NetFreight            XMLMoney       `xml:"NetFreight,omitempty"`
+	TotalSurcharges       XMLMoney       `xml:"TotalSurcharges,omitempty"`
+	NetFedExCharge        XMLMoney       `xml:"NetFedExCharge,omitempty"`
+	TotalTaxes            XMLMoney       `xml:"TotalTaxes,omitempty"`
+	NetCharge             XMLMoney       `xml:"NetCharge,omitempty"`
+	TotalRebates          XMLMoney       `xml:"TotalRebates,omitempty"`
+	Surcharges            []XMLSurcharge `xml:"Surcharges,omitempty"`
+}
+
+type XMLRatedPackage struct {
+	Text                 string               `xml:",chardata"`
+	GroupNumber          string               `xml:"GroupNumber,omitempty"`
+	EffectiveNetDiscount XMLMoney             `xml:"EffectiveNetDiscount,omitempty"`
+	PackageRateDetail    XMLPackageRateDetail `xml:"PackageRateDetail,omitempty"`
+}
+
+type XMLRatedShipmentDetail struct {
+	Text                 string                `xml:",chardata"`
+	EffectiveNetDiscount XMLMoney              `xml:"EffectiveNetDiscount,omitempty"`
+	ShipmentRateDetail   XMLShipmentRateDetail `xml:"ShipmentRateDetail,omitempty"`
+	RatedPackages        []XMLRatedPackage     `xml:"RatedPackages,omitempty"`
+}
+
+type XMLServiceDescription struct {
+	Text             string           `xml:",chardata"`
+	ServiceType      string           `xml:"ServiceType,omitempty"`
+	Code             string           `xml:"Code,omitempty"`
+	Names            []XMLServiceName `xml:"Names,omitempty"`
+	Description      string           `xml:"Description,omitempty"`
+	AstraDescription string           `xml:"AstraDescription,omitempty"`
+}
+
+type XMLServiceName struct {
+	Text     string `xml:",chardata"`
+	Type     string `xml:"Type,omitempty"`
+	Encoding string `xml:"Encoding,omitempty"`
+	Value    string `xml:"Value,omitempty"`
+}
+
+type XMLRateReplyDetail struct {
+	Text                            string                   `xml:",chardata"`
+	ServiceType                     string                   `xml:"ServiceType,omitempty"`
+	ServiceDescription              XMLServiceDescription    `xml:"ServiceDescription,omitempty"`
+	PackagingType                   string                   `xml:"PackagingType,omitempty"`
+	DestinationAirportId            string                   `xml:"DestinationAirportId,omitempty"`
+	IneligibleForMoneyBackGuarantee string                   `xml:"IneligibleForMoneyBackGuarantee,omitempty"`
+	SignatureOption                 string                   `xml:"SignatureOption,omitempty"`
+	ActualRateType                  string                   `xml:"ActualRateType,omitempty"`
+	DeliveryDayOfWeek               string                   `xml:"DeliveryDayOfWeek,omitempty"`
+	DeliveryTimestamp               string                   `xml:"DeliveryTimestamp,omitempty"`
+	TransitTime                     string                   `xml:"TransitTime,omitempty"`
+	MaximumTransitTime              string                   `xml:"MaximumTransitTime,omitempty"`
+	RatedShipmentDetails            []XMLRatedShipmentDetail `xml:"RatedShipmentDetails,omitempty"`
+}
+
+type XMLFaultString struct {
+	Text string `xml:",chardata"`
+	Lang string `xml:"lang,attr,omitempty"`
+}
+
+type XMLFaultDetail struct {
+	Text  string `xml:",chardata"`
+	Cause string `xml:"cause,omitempty"`
+	Code  string `xml:"code,omitempty"`
+	Desc  string `xml:"desc,omitempty"`
+}
+
+type XMLFault struct {
+	Text        string         `xml:",chardata"`
+	Faultcode   string         `xml:"faultcode,omitempty"`
+	Faultstring XMLFaultString `xml:"faultstring,omitempty"`
+	Detail      XMLFaultDetail `xml:"detail,omitempty"`
+}
+
+type XMLRateReply struct {
+	Text              string               `xml:",chardata"`
+	Xmlns             string               `xml:"xmlns,attr"`
+	HighestSeverity   string               `xml:"HighestSeverity"`
+	Notifications     []XMLNotification    `xml:"Notifications,omitempty"`
+	TransactionDetail XMLTransactionDetail `xml:"TransactionDetail,omitempty"`
+	Version           XMLVersion           `xml:"Version,omitempty"`
+	RateReplyDetails  []XMLRateReplyDetail `xml:"RateReplyDetails,omitempty"`
+}
+
+type XMLResponseBody struct {
+	Text      string       `xml:",chardata"`
+	Fault     XMLFault     `xml:"Fault,omitempty"`
+	RateReply XMLRateReply `xml:"RateReply,omitempty"`
 }
 
 type RateXMLResponse struct {
-	Body struct {
-		Text  string `xml:",chardata"`
-		Fault struct {
-			Text        string `xml:",chardata"`
-			Faultcode   string `xml:"faultcode,omitempty"`
-			Faultstring struct {
-				Text string `xml:",chardata"`
-				Lang string `xml:"lang,attr,omitempty"`
-			} `xml:"faultstring,omitempty"`
-			Detail struct {
-				Text  string `xml:",chardata"`
-				Cause string `xml:"cause,omitempty"`
-				Code  string `xml:"code,omitempty"`
-				Desc  string `xml:"desc,omitempty"`
-			} `xml:"detail,omitempty"`
-		} `xml:"Fault,omitempty"`
-		RateReply struct {
-			Text            string `xml:",chardata"`
-			Xmlns           string `xml:"xmlns,attr"`
-			HighestSeverity string `xml:"HighestSeverity"`
-			Notifications   struct {
-				Text             string `xml:",chardata"`
-				Severity         string `xml:"Severity,omitempty"`
-				Source           string `xml:"Source,omitempty"`
-				Code             string `xml:"Code,omitempty"`
-				Message          string `xml:"Message,omitempty"`
-				LocalizedMessage string `xml:"LocalizedMessage,omitempty"`
-			} `xml:"Notifications,omitempty"`
-			TransactionDetail struct {
-				Text                  string `xml:",chardata"`
-				CustomerTransactionId string `xml:"CustomerTransactionId,omitempty"`
-			} `xml:"TransactionDetail,omitempty"`
-			Version struct {
-				Text         string `xml:",chardata"`
-				ServiceId    string `xml:"ServiceId,omitempty"`
-				Major        string `xml:"Major,omitempty"`
-				Intermediate string `xml:"Intermediate,omitempty"`
-				Minor        string `xml:"Minor,omitempty"`
-			} `xml:"Version,omitempty"`
-			RateReplyDetails struct {
-				Text               string `xml:",chardata"`
-				ServiceType        string `xml:"ServiceType,omitempty"`
-				ServiceDescription struct {
-					Text        string `xml:",chardata"`
-					ServiceType string `xml:"ServiceType,omitempty"`
-					Code        string `xml:"Code,omitempty"`
-					Names       []struct {
-						Text     string `xml:",chardata"`
-						Type     string `xml:"Type,omitempty"`
-						Encoding string `xml:"Encoding,omitempty"`
-						Value    string `xml:"Value,omitempty"`
-					} `xml:"Names,omitempty"`
-					Description      string `xml:"Description,omitempty"`
-					AstraDescription string `xml:"AstraDescription,omitempty"`
-				} `xml:"ServiceDescription,omitempty"`
-				PackagingType                   string `xml:"PackagingType,omitempty"`
-				DestinationAirportId            string `xml:"DestinationAirportId,omitempty"`
-				IneligibleForMoneyBackGuarantee string `xml:"IneligibleForMoneyBackGuarantee,omitempty"`
-				SignatureOption                 string `xml:"SignatureOption,omitempty"`
-				ActualRateType                  string `xml:"ActualRateType,omitempty"`
-				RatedShipmentDetails            []struct {
-					Text                 string `xml:",chardata"`
-					EffectiveNetDiscount struct {
-						Text     string `xml:",chardata"`
-						Currency string `xml:"Currency,omitempty"`
-						Amount   string `xml:"Amount,omitempty"`
-					} `xml:"EffectiveNetDiscount,omitempty"`
-					ShipmentRateDetail struct {
-						Text                 string `xml:",chardata"`
-						RateType             string `xml:"RateType,omitempty"`
-						RateZone             string `xml:"RateZone,omitempty"`
-						RatedWeightMethod    string `xml:"RatedWeightMethod,omitempty"`
-						DimDivisor           string `xml:"DimDivisor,omitempty"`
-						FuelSurchargePercent string `xml:"FuelSurchargePercent,omitempty"`
-						TotalBillingWeight   struct {
-							Text  string `xml:",chardata"`
-							Units string `xml:"Units,omitempty"`
-							Value string `xml:"Value,omitempty"`
-						} `xml:"TotalBillingWeight,omitempty"`
-						TotalBaseCharge struct {
-							Text     string `xml:",chardata"`
-							Currency string `xml:"Currency,omitempty"`
-							Amount   string `xml:"Amount,omitempty"`
-						} `xml:"TotalBaseCharge,omitempty"`
-						TotalFreightDiscounts struct {
-							Text     string `xml:",chardata"`
-							Currency string `xml:"Currency,omitempty"`
-							Amount   string `xml:"Amount,omitempty"`
-						} `xml:"TotalFreightDiscounts,omitempty"`
-						TotalNetFreight struct {
-							Text     string `xml:",chardata"`
-							Currency string `xml:"Currency,omitempty"`
-							Amount   string `xml:"Amount,omitempty"`
-						} `xml:"TotalNetFreight,omitempty"`
-						TotalSurcharges struct {
-							Text     string `xml:",chardata"`
-							Currency string `xml:"Currency,omitempty"`
-							Amount   string `xml:"Amount,omitempty"`
-						} `xml:"TotalSurcharges,omitempty"`
-						TotalNetFedExCharge struct {
-							Text     string `xml:",chardata"`
-							Currency string `xml:"Currency,omitempty"`
-							Amount   string `xml:"Amount,omitempty"`
-						} `xml:"TotalNetFedExCharge,omitempty"`
-						TotalTaxes struct {
-							Text     string `xml:",chardata"`
-							Currency string `xml:"Currency,omitempty"`
-							Amount   string `xml:"Amount,omitempty"`
-						} `xml:"TotalTaxes,omitempty"`
-						TotalNetCharge struct {
-							Text     string `xml:",chardata"`
-							Currency string `xml:"Currency,omitempty"`
-							Amount   string `xml:"Amount,omitempty"`
-						} `xml:"TotalNetCharge,omitempty"`
-						TotalRebates struct {
-							Text     string `xml:",chardata"`
-							Currency string `xml:"Currency,omitempty"`
-							Amount   string `xml:"Amount,omitempty"`
-						} `xml:"TotalRebates,omitempty"`
-						TotalDutiesAndTaxes struct {
-							Text     string `xml:",chardata"`
-							Currency string `xml:"Currency,omitempty"`
-							Amount   string `xml:"Amount,omitempty"`
-						} `xml:"TotalDutiesAndTaxes,omitempty"`
-						TotalAncillaryFeesAndTaxes struct {
-							Text     string `xml:",chardata"`
-							Currency string `xml:"Currency,omitempty"`
-							Amount   string `xml:"Amount,omitempty"`
-						} `xml:"TotalAncillaryFeesAndTaxes,omitempty"`
-						TotalDutiesTaxesAndFees struct {
-							Text     string `xml:",chardata"`
-							Currency string `xml:"Currency,omitempty"`
-							Amount   string `xml:"Amount,omitempty"`
-						} `xml:"TotalDutiesTaxesAndFees,omitempty"`
-						TotalNetChargeWithDutiesAndTaxes struct {
-							Text     string `xml:",chardata"`
-							Currency string `xml:"Currency,omitempty"`
-							Amount   string `xml:"Amount,omitempty"`
-						} `xml:"TotalNetChargeWithDutiesAndTaxes,omitempty"`
-						Surcharges []struct {
-							Text          string `xml:",chardata"`
-							SurchargeType string `xml:"SurchargeType,omitempty"`
-							Level         string `xml:"Level,omitempty"`
-							Description   string `xml:"Description,omitempty"`
-							Amount        struct {
-								Text     string `xml:",chardata"`
-								Currency string `xml:"Currency,omitempty"`
-								Amount   string `xml:"Amount,omitempty"`
-							} `xml:"Amount,omitempty"`
-						} `xml:"Surcharges,omitempty"`
-					} `xml:"ShipmentRateDetail,omitempty"`
-					RatedPackages struct {
-						Text                 string `xml:",chardata"`
-						GroupNumber          string `xml:"GroupNumber,omitempty"`
-						EffectiveNetDiscount struct {
-							Text     string `xml:",chardata"`
-							Currency string `xml:"Currency,omitempty"`
-							Amount   string `xml:"Amount,omitempty"`
-						} `xml:"EffectiveNetDiscount,omitempty"`
-						PackageRateDetail struct {
-							Text              string `xml:",chardata"`
-							RateType          string `xml:"RateType,omitempty"`
-							RatedWeightMethod string `xml:"RatedWeightMethod,omitempty"`
-							BillingWeight     struct {
-								Text  string `xml:",chardata"`
-								Units string `xml:"Units,omitempty"`
-								Value string `xml:"Value,omitempty"`
-							} `xml:"BillingWeight,omitempty"`
-							BaseCharge struct {
-								Text     string `xml:",chardata"`
-								Currency string `xml:"Currency,omitempty"`
-								Amount   string `xml:"Amount,omitempty"`
-							} `xml:"BaseCharge,omitempty"`
-							TotalFreightDiscounts struct {
-								Text     string `xml:",chardata"`
-								Currency string `xml:"Currency,omitempty"`
-								Amount   string `xml:"Amount,omitempty"`
-							} `xml:"TotalFreightDiscounts,omitempty"`
-							NetFreight struct {
-								Text     string `xml:",chardata"`
-								Currency string `xml:"Currency,omitempty"`
-								Amount   string `xml:"Amount,omitempty"`
-							} `xml:"NetFreight,omitempty"`
-							TotalSurcharges struct {
-								Text     string `xml:",chardata"`
-								Currency string `xml:"Currency,omitempty"`
-								Amount   string `xml:"Amount,omitempty"`
-							} `xml:"TotalSurcharges,omitempty"`
-							NetFedExCharge struct {
-								Text     string `xml:",chardata"`
-								Currency string `xml:"Currency,omitempty"`
-								Amount   string `xml:"Amount,omitempty"`
-							} `xml:"NetFedExCharge,omitempty"`
-							TotalTaxes struct {
-								Text     string `xml:",chardata"`
-								Currency string `xml:"Currency,omitempty"`
-								Amount   string `xml:"Amount,omitempty"`
-							} `xml:"TotalTaxes,omitempty"`
-							NetCharge struct {
-								Text     string `xml:",chardata"`
-								Currency string `xml:"Currency,omitempty"`
-								Amount   string `xml:"Amount,omitempty"`
-							} `xml:"NetCharge,omitempty"`
-							TotalRebates struct {
-								Text     string `xml:",chardata"`
-								Currency string `xml:"Currency,omitempty"`
-								Amount   string `xml:"Amount,omitempty"`
-							} `xml:"TotalRebates,omitempty"`
-							Surcharges []struct {
-								Text          string `xml:",chardata"`
-								SurchargeType string `xml:"SurchargeType,omitempty"`
-								Level         string `xml:"Level,omitempty"`
-								Description   string `xml:"Description,omitempty"`
-								Amount        struct {
-									Text     string `xml:",chardata"`
-									Currency string `xml:"Currency,omitempty"`
-									Amount   string `xml:"Amount,omitempty"`
-								} `xml:"Amount,omitempty"`
-							} `xml:"Surcharges,omitempty"`
-						} `xml:"PackageRateDetail,omitempty"`
-					} `xml:"RatedPackages,omitempty"`
-				} `xml:"RatedShipmentDetails,omitempty"`
-			} `xml:"RateReplyDetails,omitempty"`
-		} `xml:"RateReply,omitempty"`
-	} `xml:"Body"`
+	Body XMLResponseBody `xml:"Body"`
 }
 
 func (c RateRequest) Rate(token string, apiUrl string) (RateResponse, error) {
+	return c.RateWith(common.Fedex{BaseURL: apiUrl}, token)
+}
+
+// RateWith sends the request through the given transport; fedex.BaseURL is
+// the REST API URL, e.g. auth.FedexAuthResponse.Url.
+func (c RateRequest) RateWith(fedex common.Fedex, token string) (RateResponse, error) {
+	fedex.CustomerTransactionID = fedex.TransactionID()
+	call := fedex.StartCall(common.OperationRate)
+	fedex.Context = call.Context()
+
+	var response RateResponse
+	var statusCode int
+	ex := &common.Exchange{Operation: common.OperationRate, Request: &c}
+	err := fedex.Run(ex, func(ex *common.Exchange) error {
+		var err error
+		response, statusCode, err = c.send(fedex, token, ex)
+		ex.Result = &response
+		return err
+	})
+	// middleware may have answered or replaced the result itself
+	if r, ok := ex.Result.(*RateResponse); ok {
+		response = *r
+	}
+
+	if response.CustomerTransactionID == "" {
+		response.CustomerTransactionID = fedex.CustomerTransactionID
+	}
+	if err != nil {
+		err = &common.TransactionError{Err: err, CustomerTransactionID: response.CustomerTransactionID, TransactionID: response.TransactionID, StatusCode: statusCode}
+	}
+
+	call.SetAttribute(common.AttributeServiceType, string(c.RequestedShipment.ServiceType))
+	call.SetAttribute(common.AttributeTransactionID, response.TransactionID)
+	call.SetAttribute(common.AttributeCustomerTransactionID, response.CustomerTransactionID)
+	if len(response.Errors) > 0 {
+		call.ErrorCode(response.Errors[0].Code)
+	}
+	call.End(statusCode, err)
+	return response, err
+}
+
+func (c RateRequest) send(fedex common.Fedex, token string, ex *common.Exchange) (RateResponse, int, error) {
 	var _response RateResponse
-	client := &http.Client{}
 
-	reqUrl := apiUrl + "/rate/v1/rates/quotes"
+	reqUrl := fedex.BaseURL + "/rate/v1/rates/quotes"
 
 	request, errJSON := json.Marshal(c)
 
 	if errJSON != nil {
-		return _response, errJSON
+		return _response, 0, errJSON
 	}
 
 	req, err := http.NewRequest("POST", reqUrl, bytes.NewBuffer(request))
+	if err != nil {
+		return _response, 0, err
+	}
 	req.Header.Add("Content-type", "application/json")
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("Authorization", "Bearer "+token)
 	req.Header.Add("X-locale", "en_US")
+	req.Header.Add(common.HeaderCustomerTransactionID, fedex.CustomerTransactionID)
+	ex.Sent(req, request)
 
-	resp, err := client.Do(req)
+	resp, err := fedex.Do(req)
 
 	if err != nil {
-		return _response, err
+		return _response, 0, err
 	}
 	defer resp.Body.Close()
 
-	log.Println(resp.StatusCode)
+	log.Println(resp.StatusCode, fedex.CustomerTransactionID)
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return _response, resp.StatusCode, err
+	}
+	ex.Received(resp, content)
 
 	if resp.StatusCode == 200 {
-		data := json.NewDecoder(resp.Body)
-		errjson := data.Decode(&_response)
+		errjson := json.Unmarshal(content, &_response)
 		if errjson != nil {
 			log.Println(errjson)
 		}
-		return _response, nil
+		return _response, resp.StatusCode, nil
 	} else {
-		dataError := json.NewDecoder(resp.Body)
-		errjson := dataError.Decode(&_response)
+		errjson := json.Unmarshal(content, &_response)
 		if errjson != nil {
 			log.Println(errjson)
 		}
-		return _response, errors.New(resp.Status)
+		return _response, resp.StatusCode, errors.New(resp.Status)
 	}
+}
 
-	return _response, nil
+// withPackageNumbers fills in SequenceNumber, GroupNumber and PackageCount
+// where the caller left them empty. Line items are copied so the caller's
+// request is not modified.
+func (c RateXMLRequest) withPackageNumbers() RateXMLRequest {
+	shipment := &c.Body.RateRequest.RequestedShipment
+	items := make([]XMLRequestedPackageLineItem, len(shipment.RequestedPackageLineItems))
+	copy(items, shipment.RequestedPackageLineItems)
+
+	count := 0
+	for i := range items {
+		n := strconv.Itoa(i + 1)
+		if items[i].SequenceNumber == "" {
+			items[i].SequenceNumber = n
+		}
+		if items[i].GroupNumber == "" {
+			items[i].GroupNumber = n
+		}
+		if items[i].GroupPackageCount == "" {
+			items[i].GroupPackageCount = "1"
+		}
+		if groupCount, err := strconv.Atoi(items[i].GroupPackageCount); err == nil {
+			count += groupCount
+		}
+	}
+	shipment.RequestedPackageLineItems = items
+	if shipment.PackageCount == "" && count > 0 {
+		shipment.PackageCount = strconv.Itoa(count)
+	}
+	return c
 }
 
+// Rate posts the request to url, or to the REST API while compatibility is
+// enabled, see EnableRESTCompatibility.
 func (c RateXMLRequest) Rate(url string, testMode bool) (RateXMLResponse, error) {
+	return c.RateWith(common.Fedex{TestMode: testMode, RESTTokens: restCompatibility()}, url)
+}
+
+// RateWith posts the request to path (e.g. "/rate") through the given
+// transport, or translates it for the REST API if fedex.RESTTokens is set.
+func (c RateXMLRequest) RateWith(fedex common.Fedex, url string) (RateXMLResponse, error) {
+	transaction := &c.Body.RateRequest.TransactionDetail
+	if transaction.CustomerTransactionId == "" {
+		transaction.CustomerTransactionId = fedex.TransactionID()
+	}
+	fedex.CustomerTransactionID = transaction.CustomerTransactionId
+	call := fedex.StartCall(common.OperationRateSOAP)
+	fedex.Context = call.Context()
+
+	var response RateXMLResponse
+	var statusCode int
+	ex := &common.Exchange{Operation: common.OperationRateSOAP, Request: &c}
+	err := fedex.Run(ex, func(ex *common.Exchange) error {
+		var err error
+		if fedex.RESTTokens != nil {
+			response, statusCode, err = c.sendREST(fedex, fedex.RESTTokens, ex)
+		} else {
+			response, statusCode, err = c.send(fedex, url, ex)
+		}
+		ex.Result = &response
+		return err
+	})
+	if r, ok := ex.Result.(*RateXMLResponse); ok {
+		response = *r
+	}
+
+	call.SetAttribute(common.AttributeServiceType, string(c.Body.RateRequest.RequestedShipment.ServiceType))
+
+	reply := &response.Body.RateReply
+	if reply.TransactionDetail.CustomerTransactionId == "" {
+		reply.TransactionDetail.CustomerTransactionId = fedex.CustomerTransactionID
+	}
+	if err != nil {
+		err = &common.TransactionError{Err: err, CustomerTransactionID: fedex.CustomerTransactionID, StatusCode: statusCode}
+	}
+	call.SetAttribute(common.AttributeCustomerTransactionID, reply.TransactionDetail.CustomerTransactionId)
+	if response.Body.Fault.Faultcode != "" {
+		call.ErrorCode(response.Body.Fault.Detail.Code)
+	}
+	for _, n := range reply.Notifications {
+		if n.Severity == "ERROR" || n.Severity == "FAILURE" {
+			call.ErrorCode(n.Code)
+			break
+		}
+	}
+	call.End(statusCode, err)
+	return response, err
+}
+
+func (c RateXMLRequest) send(fedex common.Fedex, url string, ex *common.Exchange) (RateXMLResponse, int, error) {
 	var _response RateXMLResponse
-	request, _ := xml.Marshal(c)
+	request, _ := xml.Marshal(c.withPackageNumbers())
 	newStr := `SOAP-ENV:Envelope xmlns:SOAP-ENV="http://schemas.xmlsoap.org/soap/envelope/" xmlns:SOAP-ENC="http://schemas.xmlsoap.org/soap/encoding/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns="http://fedex.com/ws/rate/v28"`
 	s := strings.Replace(string(request), "SOAP-ENV:Envelope", newStr, 1)
 
-	content, err, statusCode := common.Fedex{TestMode: testMode}.PostRequest(s, url)
+	content, err, statusCode := fedex.PostExchange(ex, s, url)
 
 	if err != nil {
-		log.Printf("%s", err)
-		return RateXMLResponse{}, err
+		log.Printf("%s %s", err, fedex.CustomerTransactionID)
+		return RateXMLResponse{}, statusCode, err
 	}
 
 	if statusCode == 503 {
 		_statusCode, _ := conv.String(statusCode)
-		return RateXMLResponse{}, errors.New("Backend Error with code " + _statusCode)
+		return RateXMLResponse{}, statusCode, errors.New("Backend Error with code " + _statusCode)
 	}
 
 	err = xml.Unmarshal(content, &_response)
 	if err != nil {
-		log.Println("%s", err)
-		return RateXMLResponse{}, err
+		log.Println(err)
+		return RateXMLResponse{}, statusCode, err
 	}
 
 	//log.Printf("%s", _response.Body)
-	return _response, nil
+	return _response, statusCode, nil
 }
@@ -0,0 +1,424 @@
+package rate
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/tirpitz0509/go-fedex/common"
+)
+
+// TokenSource returns a REST access token and the API base URL it is valid
+// for, in the shape of auth.FedexAuthResponse.
+type TokenSource func() (token string, apiUrl string, err error)
+
+var (
+	compatMu     sync.RWMutex
+	compatTokens TokenSource
+)
+
+// EnableRESTCompatibility makes RateXMLRequest.Rate translate requests to the
+// REST API and translate the replies back, so SOAP callers keep working once
+// the Web Services endpoints are retired. It does not affect RateWith, whose
+// callers choose per call with common.Fedex.RESTTokens, e.g. one tenant's
+// TokenSource.
+func EnableRESTCompatibility(tokens TokenSource) {
+	compatMu.Lock()
+	compatTokens = tokens
+	compatMu.Unlock()
+}
+
+func DisableRESTCompatibility() {
+	EnableRESTCompatibility(nil)
+}
+
+func restCompatibility() func() (string, string, error) {
+	compatMu.RLock()
+	defer compatMu.RUnlock()
+	return compatTokens
+}
+
+// sendREST sends c to the REST API within the exchange of the SOAP call, so
+// middleware and instrumentation run once, around the whole translation.
+func (c RateXMLRequest) sendREST(fedex common.Fedex, tokens func() (string, string, error), ex *common.Exchange) (RateXMLResponse, int, error) {
+	token, apiUrl, err := tokens()
+	if err != nil {
+		return RateXMLResponse{}, 0, err
+	}
+	fedex.BaseURL = apiUrl
+	response, statusCode, err := c.ToRateRequest().send(fedex, token, ex)
+	return response.ToRateXMLResponse(), statusCode, err
+}
+
+// ToRateRequest converts a SOAP rate request into the equivalent REST request.
+// Web Services credentials have no REST counterpart and are dropped.
+func (c RateXMLRequest) ToRateRequest() RateRequest {
+	var r RateRequest
+	request := c.Body.RateRequest
+	shipment := request.RequestedShipment
+
+	r.AccountNumber.Value = request.ClientDetail.AccountNumber
+	if r.AccountNumber.Value == "" {
+		r.AccountNumber.Value = shipment.Shipper.AccountNumber
+	}
+
+	rs := &r.RequestedShipment
+	rs.Shipper = shipment.Shipper.toParty()
+	rs.Recipient = shipment.Recipient.toParty()
+	rs.ServiceType = shipment.ServiceType
+	rs.PackagingType = shipment.PackagingType
+	rs.PickupType = pickupForDropoff(shipment.DropoffType)
+	rs.PreferredCurrency = shipment.PreferredCurrency
+	if len(shipment.ShipTimestamp) >= 10 {
+		rs.ShipDateStamp = shipment.ShipTimestamp[:10]
+	}
+	// SOAP always returns account rates; the requested types are in addition.
+	rs.RateRequestType = []RateRequestType{RateAccount}
+	for _, t := range shipment.RateRequestTypes {
+		if t != RateAccount && !hasRateType(rs.RateRequestType, t) {
+			rs.RateRequestType = append(rs.RateRequestType, t)
+		}
+	}
+	rs.EdtRequestType = shipment.EdtRequestType
+	if payment := shipment.ShippingChargesPayment; payment.PaymentType != "" || !payment.Payor.ResponsibleParty.isZero() {
+		rs.ShippingChargesPayment = &Payment{PaymentType: payment.PaymentType, Payor: payment.Payor.toPayor()}
+	}
+	if special := shipment.SpecialServicesRequested; special != nil {
+		rs.ShipmentSpecialServices = special.toSpecialServices()
+	}
+	if customs := shipment.CustomsClearanceDetail; customs != nil {
+		rs.CustomsClearanceDetail = customs.toCustomsClearanceDetail()
+	}
+
+	for _, item := range shipment.RequestedPackageLineItems {
+		p := Package{
+			Weight: Weight{Units: item.Weight.Units, Value: parseFloat(item.Weight.Value)},
+		}
+		if n, err := strconv.Atoi(item.GroupPackageCount); err == nil && n > 1 {
+			p.GroupPackageCount = n
+		}
+		if v := item.InsuredValue; v != nil {
+			p.DeclaredValue = Money{Currency: v.Currency, Amount: parseFloat(v.Amount)}
+		}
+		if item.Dimensions.Units != "" {
+			p.Dimensions = Dimensions{
+				Length: int(parseFloat(item.Dimensions.Length)),
+				Width:  int(parseFloat(item.Dimensions.Width)),
+				Height: int(parseFloat(item.Dimensions.Height)),
+				Units:  item.Dimensions.Units,
+			}
+		}
+		if special := item.SpecialServicesRequested; special != nil {
+			p.PackageSpecialServices.SpecialServiceTypes = special.SpecialServiceTypes
+			if cod := special.CodDetail; cod != nil {
+				p.PackageSpecialServices.PackageCODDetail.CodCollectionType = cod.CollectionType
+				p.PackageSpecialServices.PackageCODDetail.CodCollectionAmount = cod.CodCollectionAmount.toMoney()
+			}
+			if w := special.DryIceWeight; w != nil {
+				p.PackageSpecialServices.DryIceWeight = Weight{Units: w.Units, Value: parseFloat(w.Value)}
+			}
+		}
+		for _, record := range item.ContentRecords {
+			received, _ := strconv.Atoi(record.ReceivedQuantity)
+			p.ContentRecord = append(p.ContentRecord, ContentRecord{
+				ItemNumber:       record.ItemNumber,
+				ReceivedQuantity: received,
+				Description:      record.Description,
+				PartNumber:       record.PartNumber,
+			})
+		}
+		rs.RequestedPackageLineItems = append(rs.RequestedPackageLineItems, p)
+	}
+	if n, err := strconv.Atoi(shipment.PackageCount); err == nil {
+		rs.TotalPackageCount = n
+	}
+	rs.TotalWeight = parseFloat(shipment.TotalWeight.Value)
+
+	return r
+}
+
+func (p XMLParty) toParty() Party {
+	party := Party{AccountNumber: AccountNumber{Value: p.AccountNumber}}
+	if a := p.Address; a != nil {
+		residential, _ := strconv.ParseBool(a.Residential)
+		party.Address = Address{
+			StreetLines:         a.StreetLines,
+			City:                a.City,
+			StateOrProvinceCode: a.StateOrProvinceCode,
+			PostalCode:          a.PostalCode,
+			CountryCode:         a.CountryCode,
+			Residential:         residential,
+		}
+	}
+	if c := p.Contact; c != nil {
+		party.Contact = Contact{
+			PersonName:     c.PersonName,
+			CompanyName:    c.CompanyName,
+			PhoneNumber:    c.PhoneNumber,
+			PhoneExtension: c.PhoneExtension,
+			FaxNumber:      c.FaxNumber,
+			EmailAddress:   c.EMailAddress,
+		}
+	}
+	return party
+}
+
+func (s XMLShipmentSpecialServices) toSpecialServices() ShipmentSpecialServices {
+	special := ShipmentSpecialServices{SpecialServiceTypes: s.SpecialServiceTypes}
+	if cod := s.CodDetail; cod != nil {
+		d := &special.ShipmentCODDetail
+		d.CodCollectionType = cod.CollectionType
+		d.RemitToName = cod.RemitToName
+		d.ReturnReferenceIndicatorType = cod.ReferenceIndicator
+		if t := cod.AddTransportationChargesDetail; t != nil {
+			d.AddTransportationChargesDetail.RateType = t.RateTypeBasis
+			d.AddTransportationChargesDetail.ChargeType = t.ChargeBasis
+			d.AddTransportationChargesDetail.ChargeLevelType = t.ChargeBasisLevel
+		}
+		if r := cod.CodRecipient; r != nil {
+			recipient := r.toParty()
+			d.CodRecipient = &recipient
+		}
+		if f := cod.FinancialInstitutionContactAndAddress; f != nil {
+			institution := f.toContactAndAddress()
+			d.FinancialInstitutionContactAndAddress = &institution
+		}
+	}
+	if hal := s.HoldAtLocationDetail; hal != nil {
+		special.HoldAtLocationDetail = &HoldAtLocationDetail{
+			LocationID:                hal.LocationId,
+			LocationContactAndAddress: hal.LocationContactAndAddress.toContactAndAddress(),
+			LocationType:              hal.LocationType,
+		}
+	}
+	if dryIce := s.ShipmentDryIceDetail; dryIce != nil {
+		special.ShipmentDryIceDetail.PackageCount, _ = strconv.Atoi(dryIce.PackageCount)
+		special.ShipmentDryIceDetail.TotalWeight = Weight{Units: dryIce.TotalWeight.Units, Value: parseFloat(dryIce.TotalWeight.Value)}
+	}
+	return special
+}
+
+func (c XMLCustomsClearanceDetail) toCustomsClearanceDetail() CustomsClearanceDetail {
+	var customs CustomsClearanceDetail
+	if p := c.DutiesPayment; p != nil {
+		customs.DutiesPayment.PaymentType = p.PaymentType
+		customs.DutiesPayment.Payor = p.Payor.toPayor()
+	}
+	customs.FreightOnValue = c.FreightOnValue
+	if c.CommercialInvoice != nil {
+		customs.CommercialInvoice.ShipmentPurpose = c.CommercialInvoice.Purpose
+	}
+	for _, x := range c.Commodities {
+		commodity := Commodity{
+			Name:                 x.Name,
+			Description:          x.Description,
+			CountryOfManufacture: x.CountryOfManufacture,
+			HarmonizedCode:       x.HarmonizedCode,
+			QuantityUnits:        x.QuantityUnits,
+			UnitPrice:            x.UnitPrice.toMoney(),
+			CustomsValue:         x.CustomsValue.toMoney(),
+			PartNumber:           x.PartNumber,
+		}
+		commodity.NumberOfPieces, _ = strconv.Atoi(x.NumberOfPieces)
+		commodity.Quantity, _ = strconv.Atoi(x.Quantity)
+		if w := x.Weight; w != nil {
+			commodity.Weight = Weight{Units: w.Units, Value: parseFloat(w.Value)}
+		}
+		customs.Commodities = append(customs.Commodities, commodity)
+	}
+	return customs
+}
+
+func (c XMLContactAndAddress) toContactAndAddress() ContactAndAddress {
+	contact, address := c.Contact, c.Address
+	party := XMLParty{Contact: &contact, Address: &address}.toParty()
+	return ContactAndAddress{Address: party.Address, Contact: party.Contact}
+}
+
+// toPayor leaves ResponsibleParty nil when the SOAP payor names no one.
+func (p XMLPayor) toPayor() Payor {
+	if p.ResponsibleParty.isZero() {
+		return Payor{}
+	}
+	party := p.ResponsibleParty.toParty()
+	return Payor{ResponsibleParty: &party}
+}
+
+// address returns the address of the party, or none when it is nil.
+func (p XMLParty) address() XMLAddress {
+	if p.Address == nil {
+		return XMLAddress{}
+	}
+	return *p.Address
+}
+
+func (p XMLParty) isZero() bool {
+	return p.AccountNumber == "" && len(p.Tins) == 0 && p.Contact == nil && p.Address == nil
+}
+
+// toMoney returns no money for a nil m.
+func (m *XMLMoney) toMoney() Money {
+	if m == nil {
+		return Money{}
+	}
+	return Money{Currency: m.Currency, Amount: parseFloat(m.Amount)}
+}
+
+func pickupForDropoff(dropoffType DropoffType) PickupType {
+	switch dropoffType {
+	case RequestCourier:
+		return ContactFedexToSchedule
+	case RegularPickup:
+		return UseScheduledPickup
+	}
+	return DropoffAtFedexLocation
+}
+
+// ToRateXMLResponse presents a REST rate reply in the SOAP v28 reply shape.
+func (c RateResponse) ToRateXMLResponse() RateXMLResponse {
+	var x RateXMLResponse
+	reply := &x.Body.RateReply
+	reply.Xmlns = RATESOAP
+	reply.TransactionDetail.CustomerTransactionId = c.CustomerTransactionID
+	reply.Version = XMLVersion{ServiceId: "crs", Major: "28", Intermediate: "0", Minor: "0"}
+
+	reply.HighestSeverity = "SUCCESS"
+	for _, alert := range c.Output.Alerts {
+		reply.HighestSeverity = "WARNING"
+		reply.Notifications = append(reply.Notifications, XMLNotification{
+			Severity: "WARNING", Source: "crs", Code: alert.Code, Message: alert.Message,
+		})
+	}
+	for _, e := range c.Errors {
+		reply.HighestSeverity = "ERROR"
+		reply.Notifications = append(reply.Notifications, XMLNotification{
+			Severity: "ERROR", Source: "crs", Code: e.Code, Message: e.Message,
+		})
+	}
+
+	for _, detail := range c.Output.RateReplyDetails {
+		d := XMLRateReplyDetail{
+			ServiceType:       detail.ServiceType,
+			PackagingType:     detail.PackagingType,
+			SignatureOption:   detail.SignatureOptionType,
+			TransitTime:       detail.OperationalDetail.TransitTime,
+			DeliveryTimestamp: detail.OperationalDetail.CommitDate,
+			DeliveryDayOfWeek: detail.OperationalDetail.DeliveryDay,
+		}
+		d.ServiceDescription.ServiceType = detail.ServiceType
+		d.ServiceDescription.Code = detail.ServiceDescription.Code
+		d.ServiceDescription.Description = detail.ServiceName
+		if detail.OperationalDetail.IneligibleForMoneyBackGuarantee {
+			d.IneligibleForMoneyBackGuarantee = "true"
+		}
+		for _, rated := range detail.RatedShipmentDetails {
+			d.RatedShipmentDetails = append(d.RatedShipmentDetails, rated.toXML())
+		}
+		if len(d.RatedShipmentDetails) > 0 {
+			d.ActualRateType = d.RatedShipmentDetails[0].ShipmentRateDetail.RateType
+		}
+		reply.RateReplyDetails = append(reply.RateReplyDetails, d)
+	}
+	return x
+}
+
+func (c RatedShipmentDetail) toXML() XMLRatedShipmentDetail {
+	detail := c.ShipmentRateDetail
+	currency := c.Currency
+	if currency == "" {
+		currency = detail.Currency
+	}
+	money := func(amount float64) XMLMoney {
+		return XMLMoney{Currency: currency, Amount: formatAmount(amount)}
+	}
+
+	var x XMLRatedShipmentDetail
+	s := &x.ShipmentRateDetail
+	s.RateType = soapRateType(c.RateType)
+	s.RateZone = detail.RateZone
+	s.RatedWeightMethod = c.RatedWeightMethod
+	if detail.DimDivisor != 0 {
+		s.DimDivisor = strconv.Itoa(detail.DimDivisor)
+	}
+	s.FuelSurchargePercent = formatAmount(detail.FuelSurchargePercent)
+	if e := detail.CurrencyExchangeRate; e.FromCurrency != "" {
+		s.CurrencyExchangeRate = XMLCurrencyExchangeRate{
+			FromCurrency: e.FromCurrency,
+			IntoCurrency: e.IntoCurrency,
+			Rate:         strconv.FormatFloat(e.Rate, 'f', -1, 64),
+		}
+	}
+	s.TotalBillingWeight = XMLWeight{
+		Units: detail.TotalBillingWeight.Units,
+		Value: strconv.FormatFloat(detail.TotalBillingWeight.Value, 'f', -1, 64),
+	}
+	s.TotalBaseCharge = money(c.TotalBaseCharge)
+	s.TotalFreightDiscounts = money(c.TotalDiscounts)
+	s.TotalNetFreight = money(c.TotalBaseCharge - c.TotalDiscounts)
+	s.TotalSurcharges = money(detail.TotalSurcharges)
+	s.TotalNetFedExCharge = money(c.TotalNetFedExCharge)
+	s.TotalTaxes = money(c.TotalVatCharge)
+	s.TotalNetCharge = money(c.TotalNetCharge)
+	s.TotalRebates = money(0)
+	s.TotalDutiesAndTaxes = money(c.TotalDutiesAndTaxes)
+	s.TotalAncillaryFeesAndTaxes = money(c.TotalAncillaryFeesAndTaxes)
+	s.TotalDutiesTaxesAndFees = money(c.TotalDutiesTaxesAndFees)
+	s.TotalNetChargeWithDutiesAndTaxes = money(c.TotalNetChargeWithDutiesAndTaxes)
+	for _, surcharge := range detail.SurCharges {
+		s.Surcharges = append(s.Surcharges, XMLSurcharge{
+			SurchargeType: surcharge.Type,
+			Level:         "PACKAGE",
+			Description:   surcharge.Description,
+			Amount:        money(surcharge.Amount),
+		})
+	}
+	for _, p := range c.RatedPackages {
+		d := p.PackageRateDetail
+		var rp XMLRatedPackage
+		rp.GroupNumber = strconv.Itoa(p.GroupNumber)
+		rp.EffectiveNetDiscount = money(p.EffectiveNetDiscount)
+		r := &rp.PackageRateDetail
+		r.RateType = soapRateType(c.RateType)
+		r.RatedWeightMethod = d.RatedWeightMethod
+		r.BillingWeight = XMLWeight{
+			Units: d.BillingWeight.Units,
+			Value: strconv.FormatFloat(d.BillingWeight.Value, 'f', -1, 64),
+		}
+		r.BaseCharge = money(d.BaseCharge)
+		r.TotalFreightDiscounts = money(d.TotalFreightDiscounts)
+		r.NetFreight = money(d.NetFreight)
+		r.TotalSurcharges = money(d.TotalSurcharges)
+		r.NetFedExCharge = money(d.NetFedExCharge)
+		r.TotalTaxes = money(d.TotalTaxes)
+		r.NetCharge = money(d.NetCharge)
+		r.TotalRebates = money(d.TotalRebates)
+		for _, surcharge := range d.Surcharges {
+			r.Surcharges = append(r.Surcharges, XMLSurcharge{
+				SurchargeType: surcharge.Type,
+				Level:         "PACKAGE",
+				Description:   surcharge.Description,
+				Amount:        money(surcharge.Amount),
+			})
+		}
+		x.RatedPackages = append(x.RatedPackages, rp)
+	}
+	x.EffectiveNetDiscount = money(c.TotalDiscounts)
+	return x
+}
+
+// soapRateType maps REST rate types onto the shipment-level SOAP rate types.
+func soapRateType(rateType string) string {
+	switch {
+	case strings.HasPrefix(rateType, "PREFERRED"):
+		return "PREFERRED_ACCOUNT_SHIPMENT"
+	case rateType == "LIST":
+		return "PAYOR_LIST_SHIPMENT"
+	case rateType == "INCENTIVE":
+		return "INCENTIVE"
+	}
+	return "PAYOR_ACCOUNT_SHIPMENT"
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
@@ -0,0 +1,136 @@
+package rate_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tirpitz0509/go-fedex/auth"
+	"github.com/tirpitz0509/go-fedex/common"
+	"github.com/tirpitz0509/go-fedex/fedextest"
+	"github.com/tirpitz0509/go-fedex/rate"
+)
+
+func soapRequest(t *testing.T) rate.RateXMLRequest {
+	x, err := rate.NewShipment("123456789").
+		From(rate.Address{CountryCode: "US", PostalCode: "38017"}).
+		To(rate.Address{CountryCode: "US", PostalCode: "90210"}).
+		Service(rate.FedexGround).
+		Packaging(rate.YourPackaging).
+		AddPackage(rate.NewPackage(5, "LB")).
+		BuildXML("key", "password", "meter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return x
+}
+
+func TestRESTCompatibility(t *testing.T) {
+	s := fedextest.NewServer()
+	defer s.Close()
+	s.Quotes(rate.Quote{NetCharge: 12.5, Discounts: 1.25, Currency: "USD"})
+	cache := auth.NewTokenCache(s.Auth("id", "secret"))
+	tokens := cache.TokenSource(cache.Auth.GrantStrategy())
+
+	// another tenant's tokens set globally must not reach RateWith
+	rate.EnableRESTCompatibility(func() (string, string, error) {
+		return "", "", errors.New("global token source used")
+	})
+	defer rate.DisableRESTCompatibility()
+
+	tests := []struct {
+		name   string
+		tokens func() (string, string, error)
+		path   string
+	}{
+		{"SOAP", nil, fedextest.SOAPPath},
+		{"REST per call", tokens, fedextest.RatePath},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := len(s.Requests())
+			runs := 0
+			fedex := s.SOAP()
+			fedex.RESTTokens = tt.tokens
+			fedex.Middleware = []common.Middleware{func(next common.Handler) common.Handler {
+				return func(ex *common.Exchange) error {
+					runs++
+					return next(ex)
+				}
+			}}
+
+			response, err := soapRequest(t).RateWith(fedex, "/rate")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if runs != 1 {
+				t.Errorf("middleware ran %d times, want once", runs)
+			}
+			var paths []string
+			for _, r := range s.Requests()[before:] {
+				if r.Path != fedextest.TokenPath {
+					paths = append(paths, r.Path)
+				}
+			}
+			if len(paths) != 1 || paths[0] != tt.path {
+				t.Errorf("requests = %v, want one to %s", paths, tt.path)
+			}
+			quotes := response.Quotes()
+			if len(quotes) != 1 || quotes[0].NetCharge != 12.5 {
+				t.Errorf("quotes = %+v, want one of 12.50", quotes)
+			}
+		})
+	}
+}
+
+func TestRESTCompatibilityErrorWrappedOnce(t *testing.T) {
+	s := fedextest.NewServer()
+	defer s.Close()
+	s.Fail(400, "SERVICE.UNAVAILABLE.ERROR", "unavailable")
+	cache := auth.NewTokenCache(s.Auth("id", "secret"))
+
+	fedex := s.SOAP()
+	fedex.RESTTokens = cache.TokenSource(cache.Auth.GrantStrategy())
+	_, err := soapRequest(t).RateWith(fedex, "/rate")
+	var transactionErr *common.TransactionError
+	if !errors.As(err, &transactionErr) {
+		t.Fatalf("err = %v, want a TransactionError", err)
+	}
+	if errors.As(transactionErr.Err, new(*common.TransactionError)) {
+		t.Errorf("err = %v, wrapped twice", err)
+	}
+}
+
+func TestToRateRequestShippingChargesPayment(t *testing.T) {
+	tests := []struct {
+		name        string
+		paymentType string
+		account     string
+	}{
+		{"sender", "SENDER", "123456789"},
+		{"third party", "THIRD_PARTY", "987654321"},
+		{"none", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x := soapRequest(t)
+			payment := &x.Body.RateRequest.RequestedShipment.ShippingChargesPayment
+			payment.PaymentType = tt.paymentType
+			payment.Payor.ResponsibleParty.AccountNumber = tt.account
+
+			request := x.ToRateRequest()
+			content, _ := json.Marshal(request)
+			got := request.RequestedShipment.ShippingChargesPayment
+			if tt.paymentType == "" {
+				if got != nil || strings.Contains(string(content), "shippingChargesPayment") {
+					t.Errorf("ShippingChargesPayment = %+v, want none", got)
+				}
+				return
+			}
+			if got == nil || got.PaymentType != tt.paymentType || got.Payor.AccountNumber() != tt.account {
+				t.Errorf("ShippingChargesPayment = %+v, want %s paid by %s", got, tt.paymentType, tt.account)
+			}
+		})
+	}
+}
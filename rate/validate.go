@@ -0,0 +1,374 @@
+package rate
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+type ValidationError struct {
+	Path    string `json:"path"`    //
+	Message string `json:"message"` //
+}
+
+func (e ValidationError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors collects every problem found in a request so callers can
+// report them all at once instead of round-tripping through FedEx 400s.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e *ValidationErrors) add(path string, message string) {
+	*e = append(*e, ValidationError{Path: path, Message: message})
+}
+
+func (e ValidationErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// countries without postal codes, for which FedEx accepts an empty PostalCode
+var noPostalCodeCountries = stringSet(
+	"AE", "AG", "AW", "BS", "BZ", "BO", "BW", "FJ", "GD", "GH", "HK", "IE",
+	"JM", "KN", "LC", "MO", "QA", "SA", "TT", "UG", "VC", "ZW",
+)
+
+func stringSet(values ...string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+func isCountryCode(code string) bool {
+	return len(code) == 2 && isUpperAlpha(code)
+}
+
+func isCurrencyCode(code string) bool {
+	return len(code) == 3 && isUpperAlpha(code)
+}
+
+func isUpperAlpha(s string) bool {
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func validateAddress(errs *ValidationErrors, countryPath string, postalPath string, countryCode string, postalCode string) {
+	if countryCode == "" {
+		errs.add(countryPath, "is required")
+	} else if !isCountryCode(countryCode) {
+		errs.add(countryPath, "must be a two-letter ISO country code")
+	}
+	if postalCode == "" && !noPostalCodeCountries[countryCode] {
+		errs.add(postalPath, "is required")
+	}
+}
+
+func validateServiceType(errs *ValidationErrors, path string, serviceType ServiceType, from string, to string) {
+	if serviceType == "" {
+		return
+	}
+	info, ok := serviceType.Info()
+	if !ok {
+		errs.add(path, "unknown service type "+strconv.Quote(string(serviceType)))
+		return
+	}
+	if from == "" || to == "" {
+		return
+	}
+	switch {
+	case !info.International && from != to:
+		errs.add(path, string(serviceType)+" is not available from "+from+" to "+to)
+	case !info.Domestic && from == to:
+		errs.add(path, string(serviceType)+" is not available for shipments within "+from)
+	}
+}
+
+func validateSpecialServices(errs *ValidationErrors, path string, services []SpecialServiceType) {
+	for i, s := range services {
+		if !s.Valid() {
+			errs.add(path+"["+strconv.Itoa(i)+"]", "unknown special service type "+strconv.Quote(string(s)))
+		}
+	}
+}
+
+func validateCurrency(errs *ValidationErrors, path string, currency string) {
+	if currency != "" && !isCurrencyCode(currency) {
+		errs.add(path, "must be a three-letter currency code")
+	}
+}
+
+// maxWeight is the per-package limit of the requested service in LB.
+func validateWeight(errs *ValidationErrors, valuePath string, unitsPath string, units string, value float64, maxWeight float64) {
+	if value <= 0 {
+		errs.add(valuePath, "must be greater than zero")
+	}
+	switch units {
+	case "LB":
+		if value > maxWeight {
+			errs.add(valuePath, "exceeds the "+strconv.FormatFloat(maxWeight, 'f', -1, 64)+" LB per-package limit")
+		}
+	case "KG":
+		if limit := math.Floor(maxWeight * 0.45359237); value > limit {
+			errs.add(valuePath, "exceeds the "+strconv.FormatFloat(limit, 'f', -1, 64)+" KG per-package limit")
+		}
+	case "":
+		errs.add(unitsPath, "is required")
+	default:
+		errs.add(unitsPath, "must be LB or KG")
+	}
+}
+
+// names holds the length, width, height and units field names, which differ
+// in case between the REST and SOAP models.
+func validateDimensions(errs *ValidationErrors, path string, names [4]string, units string, length float64, width float64, height float64) {
+	if units == "" && length == 0 && width == 0 && height == 0 {
+		return
+	}
+	for i, v := range []float64{length, width, height} {
+		if v <= 0 {
+			errs.add(path+"."+names[i], "must be greater than zero")
+		}
+	}
+	if units != "IN" && units != "CM" {
+		errs.add(path+"."+names[3], "must be IN or CM")
+	}
+}
+
+var (
+	jsonDimensionNames = [4]string{"length", "width", "height", "units"}
+	xmlDimensionNames  = [4]string{"Length", "Width", "Height", "Units"}
+)
+
+func maxPackageWeight(serviceType ServiceType, packagingType PackagingType) float64 {
+	limit := float64(150)
+	if info, ok := serviceType.Info(); ok {
+		limit = info.MaxWeight
+	}
+	if info, ok := packagingType.Info(); ok && info.MaxWeight < limit {
+		limit = info.MaxWeight
+	}
+	return limit
+}
+
+func (c RateRequest) Validate() error {
+	var errs ValidationErrors
+	shipment := c.RequestedShipment
+	from := shipment.Shipper.Address.CountryCode
+	to := shipment.Recipient.Address.CountryCode
+
+	if c.AccountNumber.Value == "" {
+		errs.add("accountNumber.value", "is required")
+	}
+
+	validateAddress(&errs, "requestedShipment.shipper.address.countryCode", "requestedShipment.shipper.address.postalCode",
+		from, shipment.Shipper.Address.PostalCode)
+	validateAddress(&errs, "requestedShipment.recipient.address.countryCode", "requestedShipment.recipient.address.postalCode",
+		to, shipment.Recipient.Address.PostalCode)
+	validateServiceType(&errs, "requestedShipment.serviceType", shipment.ServiceType, from, to)
+
+	if shipment.PickupType == "" {
+		errs.add("requestedShipment.pickupType", "is required")
+	} else if !shipment.PickupType.Valid() {
+		errs.add("requestedShipment.pickupType", "unknown pickup type "+strconv.Quote(string(shipment.PickupType)))
+	}
+	if shipment.PackagingType != "" && !shipment.PackagingType.Valid() {
+		errs.add("requestedShipment.packagingType", "unknown packaging type "+strconv.Quote(string(shipment.PackagingType)))
+	}
+	for i, t := range shipment.RateRequestType {
+		if !t.Valid() || t == RateNone {
+			errs.add("requestedShipment.rateRequestType["+strconv.Itoa(i)+"]", "unknown rate request type "+strconv.Quote(string(t)))
+		}
+	}
+	maxWeight := maxPackageWeight(shipment.ServiceType, shipment.PackagingType)
+	validateCurrency(&errs, "requestedShipment.preferredCurrency", shipment.PreferredCurrency)
+	if t := shipment.EdtRequestType; t != "" && t != "ALL" && t != "NONE" {
+		errs.add("requestedShipment.edtRequestType", "must be ALL or NONE")
+	}
+
+	if len(shipment.RequestedPackageLineItems) == 0 {
+		errs.add("requestedShipment.requestedPackageLineItems", "at least one package is required")
+	}
+	packageCount := 0
+	for i, p := range shipment.RequestedPackageLineItems {
+		path := "requestedShipment.requestedPackageLineItems[" + strconv.Itoa(i) + "]"
+		if p.GroupPackageCount < 0 {
+			errs.add(path+".groupPackageCount", "must not be negative")
+		}
+		if p.GroupPackageCount > 0 {
+			packageCount += p.GroupPackageCount
+		} else {
+			packageCount++
+		}
+		validateWeight(&errs, path+".weight.value", path+".weight.units", p.Weight.Units, p.Weight.Value, maxWeight)
+		validateDimensions(&errs, path+".dimensions", jsonDimensionNames, p.Dimensions.Units,
+			float64(p.Dimensions.Length), float64(p.Dimensions.Width), float64(p.Dimensions.Height))
+		validateCurrency(&errs, path+".declaredValue.currency", p.DeclaredValue.Currency)
+		validateCurrency(&errs, path+".packageSpecialServices.packageCODDetail.codCollectionAmount.currency",
+			p.PackageSpecialServices.PackageCODDetail.CodCollectionAmount.Currency)
+
+		services := p.PackageSpecialServices
+		validateSpecialServices(&errs, path+".packageSpecialServices.specialServiceTypes", services.SpecialServiceTypes)
+		if hasSpecialService(services.SpecialServiceTypes, DryIce) && services.DryIceWeight.Value <= 0 {
+			errs.add(path+".packageSpecialServices.dryIceWeight", "is required for DRY_ICE")
+		}
+		if hasSpecialService(services.SpecialServiceTypes, COD) && services.PackageCODDetail.CodCollectionAmount.Amount <= 0 {
+			errs.add(path+".packageSpecialServices.packageCODDetail.codCollectionAmount", "is required for COD")
+		}
+		if hasSpecialService(services.SpecialServiceTypes, Alcohol) && services.AlcoholDetail.AlcoholRecipientType == "" {
+			errs.add(path+".packageSpecialServices.alcoholDetail.alcoholRecipientType", "is required for ALCOHOL")
+		}
+		if hasSpecialService(services.SpecialServiceTypes, DangerousGoods) && len(services.DangerousGoodsDetail.Options) == 0 &&
+			len(services.DangerousGoodsDetail.Containers) == 0 {
+			errs.add(path+".packageSpecialServices.dangerousGoodsDetail", "is required for DANGEROUS_GOODS")
+		}
+	}
+	if shipment.TotalPackageCount != 0 && len(shipment.RequestedPackageLineItems) > 0 && shipment.TotalPackageCount != packageCount {
+		errs.add("requestedShipment.totalPackageCount",
+			"is "+strconv.Itoa(shipment.TotalPackageCount)+" but requestedPackageLineItems describe "+strconv.Itoa(packageCount)+" packages")
+	}
+
+	special := shipment.ShipmentSpecialServices
+	const specialPath = "requestedShipment.shipmentSpecialServices"
+	validateSpecialServices(&errs, specialPath+".specialServiceTypes", special.SpecialServiceTypes)
+	if hasSpecialService(special.SpecialServiceTypes, COD) && special.ShipmentCODDetail.CodCollectionType == "" {
+		errs.add(specialPath+".shipmentCODDetail.codCollectionType", "is required for COD")
+	}
+	if hasSpecialService(special.SpecialServiceTypes, DryIce) && special.ShipmentDryIceDetail.TotalWeight.Value <= 0 {
+		errs.add(specialPath+".shipmentDryIceDetail.totalWeight", "is required for DRY_ICE")
+	}
+	if hasSpecialService(special.SpecialServiceTypes, HoldAtLocation) && (special.HoldAtLocationDetail == nil || special.HoldAtLocationDetail.LocationID == "") {
+		errs.add(specialPath+".holdAtLocationDetail.locationId", "is required for HOLD_AT_LOCATION")
+	}
+	if hasSpecialService(special.SpecialServiceTypes, HomeDeliveryPremium) && shipment.ServiceType != "" &&
+		shipment.ServiceType != GroundHomeDelivery {
+		errs.add(specialPath+".specialServiceTypes", "HOME_DELIVERY_PREMIUM requires GROUND_HOME_DELIVERY")
+	}
+	if hasSpecialService(special.SpecialServiceTypes, FedexOneRate) && (shipment.PackagingType == "" || shipment.PackagingType == YourPackaging) {
+		errs.add(specialPath+".specialServiceTypes", "FEDEX_ONE_RATE requires FedEx packaging")
+	}
+	if hasSpecialService(special.SpecialServiceTypes, COD) && hasSpecialService(special.SpecialServiceTypes, HoldAtLocation) &&
+		from != "" && from != to {
+		errs.add(specialPath+".specialServiceTypes", "COD cannot be combined with HOLD_AT_LOCATION internationally")
+	}
+
+	customs := shipment.CustomsClearanceDetail
+	if purpose := customs.CommercialInvoice.ShipmentPurpose; purpose != "" && !purpose.Valid() {
+		errs.add("requestedShipment.customsClearanceDetail.commercialInvoice.shipmentPurpose",
+			"unknown shipment purpose "+strconv.Quote(string(purpose)))
+	}
+	if from != "" && to != "" && from != to && !shipment.DocumentShipment && len(customs.Commodities) == 0 {
+		errs.add("requestedShipment.customsClearanceDetail.commodities", "is required for international shipments")
+	}
+	for i, commodity := range customs.Commodities {
+		path := "requestedShipment.customsClearanceDetail.commodities[" + strconv.Itoa(i) + "]"
+		if commodity.Quantity < 0 {
+			errs.add(path+".quantity", "must not be negative")
+		}
+		validateCurrency(&errs, path+".customsValue.currency", commodity.CustomsValue.Currency)
+		validateCurrency(&errs, path+".unitPrice.currency", commodity.UnitPrice.Currency)
+	}
+
+	return errs.err()
+}
+
+func (c RateXMLRequest) Validate() error {
+	var errs ValidationErrors
+	request := c.Body.RateRequest
+	shipment := request.RequestedShipment
+	const path = "RateRequest"
+	shipper, recipient := shipment.Shipper.address(), shipment.Recipient.address()
+	from := shipper.CountryCode
+	to := recipient.CountryCode
+
+	if request.WebAuthenticationDetail.UserCredential.Key == "" {
+		errs.add(path+".WebAuthenticationDetail.UserCredential.Key", "is required")
+	}
+	if request.WebAuthenticationDetail.UserCredential.Password == "" {
+		errs.add(path+".WebAuthenticationDetail.UserCredential.Password", "is required")
+	}
+	if request.ClientDetail.AccountNumber == "" {
+		errs.add(path+".ClientDetail.AccountNumber", "is required")
+	}
+	if request.ClientDetail.MeterNumber == "" {
+		errs.add(path+".ClientDetail.MeterNumber", "is required")
+	}
+
+	validateAddress(&errs, path+".RequestedShipment.Shipper.Address.CountryCode", path+".RequestedShipment.Shipper.Address.PostalCode",
+		from, shipper.PostalCode)
+	validateAddress(&errs, path+".RequestedShipment.Recipient.Address.CountryCode", path+".RequestedShipment.Recipient.Address.PostalCode",
+		to, recipient.PostalCode)
+	validateServiceType(&errs, path+".RequestedShipment.ServiceType", shipment.ServiceType, from, to)
+
+	if shipment.DropoffType != "" && !shipment.DropoffType.Valid() {
+		errs.add(path+".RequestedShipment.DropoffType", "unknown dropoff type "+strconv.Quote(string(shipment.DropoffType)))
+	}
+	if shipment.PackagingType != "" && !shipment.PackagingType.Valid() {
+		errs.add(path+".RequestedShipment.PackagingType", "unknown packaging type "+strconv.Quote(string(shipment.PackagingType)))
+	}
+	for i, t := range shipment.RateRequestTypes {
+		if !t.Valid() {
+			errs.add(path+".RequestedShipment.RateRequestTypes["+strconv.Itoa(i)+"]", "unknown rate request type "+strconv.Quote(string(t)))
+		}
+	}
+
+	if len(shipment.RequestedPackageLineItems) == 0 {
+		errs.add(path+".RequestedShipment.RequestedPackageLineItems", "at least one package is required")
+	}
+	maxWeight := maxPackageWeight(shipment.ServiceType, shipment.PackagingType)
+	packageCount := 0
+	for i, item := range shipment.RequestedPackageLineItems {
+		itemPath := path + ".RequestedShipment.RequestedPackageLineItems[" + strconv.Itoa(i) + "]"
+		validateWeight(&errs, itemPath+".Weight.Value", itemPath+".Weight.Units", item.Weight.Units, parseFloat(item.Weight.Value), maxWeight)
+		validateDimensions(&errs, itemPath+".Dimensions", xmlDimensionNames, item.Dimensions.Units,
+			parseFloat(item.Dimensions.Length), parseFloat(item.Dimensions.Width), parseFloat(item.Dimensions.Height))
+
+		if item.GroupPackageCount == "" {
+			packageCount++
+			continue
+		}
+		n, err := strconv.Atoi(item.GroupPackageCount)
+		if err != nil || n < 1 {
+			errs.add(itemPath+".GroupPackageCount", "must be a positive integer")
+			continue
+		}
+		packageCount += n
+	}
+	if shipment.PackageCount != "" {
+		n, err := strconv.Atoi(shipment.PackageCount)
+		if err != nil || n < 1 {
+			errs.add(path+".RequestedShipment.PackageCount", "must be a positive integer")
+		} else if len(shipment.RequestedPackageLineItems) > 0 && n != packageCount {
+			errs.add(path+".RequestedShipment.PackageCount",
+				"is "+shipment.PackageCount+" but RequestedPackageLineItems describe "+strconv.Itoa(packageCount)+" packages")
+		}
+	}
+
+	return errs.err()
+}
+
+// SOAP amounts are strings; anything unparseable is treated as zero so it is
+// reported as a missing value.
+func parseFloat(value string) float64 {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
@@ -0,0 +1,173 @@
+package rate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func validRequest(t *testing.T) RateRequest {
+	request, err := NewShipment("123456789").
+		From(Address{CountryCode: "US", PostalCode: "38017"}).
+		To(Address{CountryCode: "US", PostalCode: "90210"}).
+		Service(FedexGround).
+		AddPackage(NewPackage(5, "LB").WithDimensions(12, 10, 8, "IN")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return request
+}
+
+// paths returns the paths of the ValidationErrors in err.
+func paths(t *testing.T, err error) []string {
+	if err == nil {
+		return nil
+	}
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("Validate() = %T %v, want ValidationErrors", err, err)
+	}
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Path)
+	}
+	return got
+}
+
+func TestValidate(t *testing.T) {
+	const (
+		shipment = "requestedShipment."
+		item     = shipment + "requestedPackageLineItems[0]."
+		special  = shipment + "shipmentSpecialServices."
+	)
+	tests := []struct {
+		name   string
+		modify func(r *RateRequest)
+		paths  []string
+	}{
+		{"valid", func(r *RateRequest) {}, nil},
+		{"missing postal code", func(r *RateRequest) {
+			r.RequestedShipment.Recipient.Address.PostalCode = ""
+		}, []string{shipment + "recipient.address.postalCode"}},
+		{"country without postal codes", func(r *RateRequest) {
+			r.RequestedShipment.Recipient.Address = Address{CountryCode: "HK"}
+			r.RequestedShipment.ServiceType = InternationalPriority
+			r.RequestedShipment.DocumentShipment = true
+		}, nil},
+		{"bad country code", func(r *RateRequest) {
+			r.RequestedShipment.Shipper.Address.CountryCode = "usa"
+			r.RequestedShipment.DocumentShipment = true
+		}, []string{shipment + "shipper.address.countryCode"}},
+		{"domestic service abroad", func(r *RateRequest) {
+			r.RequestedShipment.Recipient.Address = Address{CountryCode: "CA", PostalCode: "M5V 3L9"}
+			r.RequestedShipment.ServiceType = StandardOvernight
+			r.RequestedShipment.DocumentShipment = true
+		}, []string{shipment + "serviceType"}},
+		{"international service at home", func(r *RateRequest) {
+			r.RequestedShipment.ServiceType = InternationalPriority
+		}, []string{shipment + "serviceType"}},
+		{"package count mismatch", func(r *RateRequest) {
+			r.RequestedShipment.TotalPackageCount = 3
+		}, []string{shipment + "totalPackageCount"}},
+		{"zero weight", func(r *RateRequest) {
+			r.RequestedShipment.RequestedPackageLineItems[0].Weight.Value = 0
+		}, []string{item + "weight.value"}},
+		{"weight over the service limit", func(r *RateRequest) {
+			r.RequestedShipment.RequestedPackageLineItems[0].Weight.Value = 151
+		}, []string{item + "weight.value"}},
+		{"weight over the packaging limit", func(r *RateRequest) {
+			r.RequestedShipment.PackagingType = FedexEnvelope
+			r.RequestedShipment.RequestedPackageLineItems[0].Weight = Weight{Units: "KG", Value: 5}
+		}, []string{item + "weight.value"}},
+		{"bad weight units", func(r *RateRequest) {
+			r.RequestedShipment.RequestedPackageLineItems[0].Weight.Units = "OZ"
+		}, []string{item + "weight.units"}},
+		{"zero dimension", func(r *RateRequest) {
+			r.RequestedShipment.RequestedPackageLineItems[0].Dimensions.Width = 0
+		}, []string{item + "dimensions.width"}},
+		{"bad dimension units", func(r *RateRequest) {
+			r.RequestedShipment.RequestedPackageLineItems[0].Dimensions.Units = "FT"
+		}, []string{item + "dimensions.units"}},
+		{"bad preferred currency", func(r *RateRequest) {
+			r.RequestedShipment.PreferredCurrency = "usd"
+		}, []string{shipment + "preferredCurrency"}},
+		{"bad declared value currency", func(r *RateRequest) {
+			r.RequestedShipment.RequestedPackageLineItems[0].DeclaredValue = Money{Amount: 100, Currency: "DOLLAR"}
+		}, []string{item + "declaredValue.currency"}},
+		{"home delivery premium on another service", func(r *RateRequest) {
+			r.RequestedShipment.ShipmentSpecialServices.SpecialServiceTypes = []SpecialServiceType{HomeDeliveryPremium}
+		}, []string{special + "specialServiceTypes"}},
+		{"one rate in own packaging", func(r *RateRequest) {
+			r.RequestedShipment.ShipmentSpecialServices.SpecialServiceTypes = []SpecialServiceType{FedexOneRate}
+		}, []string{special + "specialServiceTypes"}},
+		{"COD with hold at location abroad", func(r *RateRequest) {
+			r.RequestedShipment.Recipient.Address = Address{CountryCode: "CA", PostalCode: "M5V 3L9"}
+			r.RequestedShipment.ServiceType = InternationalPriority
+			r.RequestedShipment.DocumentShipment = true
+			s := &r.RequestedShipment.ShipmentSpecialServices
+			s.SpecialServiceTypes = []SpecialServiceType{COD, HoldAtLocation}
+			s.ShipmentCODDetail.CodCollectionType = "CASH"
+			s.HoldAtLocationDetail = &HoldAtLocationDetail{LocationID: "YYZA"}
+		}, []string{special + "specialServiceTypes"}},
+		{"COD without details", func(r *RateRequest) {
+			r.RequestedShipment.ShipmentSpecialServices.SpecialServiceTypes = []SpecialServiceType{COD}
+		}, []string{special + "shipmentCODDetail.codCollectionType"}},
+		{"unknown special service", func(r *RateRequest) {
+			r.RequestedShipment.RequestedPackageLineItems[0].PackageSpecialServices.SpecialServiceTypes = []SpecialServiceType{"TELEPORT"}
+		}, []string{item + "packageSpecialServices.specialServiceTypes[0]"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := validRequest(t)
+			tt.modify(&request)
+			if got := paths(t, request.Validate()); !reflect.DeepEqual(got, tt.paths) {
+				t.Errorf("Validate() paths = %v, want %v", got, tt.paths)
+			}
+		})
+	}
+}
+
+func TestValidateXML(t *testing.T) {
+	const shipment = "RateRequest.RequestedShipment."
+	tests := []struct {
+		name   string
+		modify func(x *RateXMLRequest)
+		paths  []string
+	}{
+		{"valid", func(x *RateXMLRequest) {}, nil},
+		{"missing meter", func(x *RateXMLRequest) {
+			x.Body.RateRequest.ClientDetail.MeterNumber = ""
+		}, []string{"RateRequest.ClientDetail.MeterNumber"}},
+		{"missing postal code", func(x *RateXMLRequest) {
+			x.Body.RateRequest.RequestedShipment.Shipper.Address.PostalCode = ""
+		}, []string{shipment + "Shipper.Address.PostalCode"}},
+		{"service invalid for the lane", func(x *RateXMLRequest) {
+			x.Body.RateRequest.RequestedShipment.ServiceType = InternationalPriority
+		}, []string{shipment + "ServiceType"}},
+		{"package count mismatch", func(x *RateXMLRequest) {
+			x.Body.RateRequest.RequestedShipment.PackageCount = "2"
+		}, []string{shipment + "PackageCount"}},
+		{"unparseable weight", func(x *RateXMLRequest) {
+			x.Body.RateRequest.RequestedShipment.RequestedPackageLineItems[0].Weight.Value = "five"
+		}, []string{shipment + "RequestedPackageLineItems[0].Weight.Value"}},
+		{"over-limit weight", func(x *RateXMLRequest) {
+			x.Body.RateRequest.RequestedShipment.RequestedPackageLineItems[0].Weight.Value = "200"
+		}, []string{shipment + "RequestedPackageLineItems[0].Weight.Value"}},
+		{"zero dimension", func(x *RateXMLRequest) {
+			x.Body.RateRequest.RequestedShipment.RequestedPackageLineItems[0].Dimensions.Height = "0"
+		}, []string{shipment + "RequestedPackageLineItems[0].Dimensions.Height"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := validRequest(t)
+			x, err := NewShipmentFrom(request).BuildXML("key", "password", "meter")
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.modify(&x)
+			if got := paths(t, x.Validate()); !reflect.DeepEqual(got, tt.paths) {
+				t.Errorf("Validate() paths = %v, want %v", got, tt.paths)
+			}
+		})
+	}
+}
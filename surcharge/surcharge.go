@@ -0,0 +1,196 @@
+// Package surcharge classifies the free-form surcharges FedEx returns into
+// a few categories and breaks quotes down by them, to explain what drives
+// shipping costs.
+package surcharge
+
+import (
+	"errors"
+	"math"
+	"strings"
+
+	"github.com/tirpitz0509/go-fedex/rate"
+)
+
+type Category string
+
+const (
+	Fuel               Category = "FUEL"
+	Residential        Category = "RESIDENTIAL"
+	DeliveryArea       Category = "DELIVERY_AREA"
+	AdditionalHandling Category = "ADDITIONAL_HANDLING"
+	Oversize           Category = "OVERSIZE"
+	Signature          Category = "SIGNATURE"
+	DeclaredValue      Category = "DECLARED_VALUE"
+	Peak               Category = "PEAK"
+	Other              Category = "OTHER"
+)
+
+// Categories lists every category in the order breakdowns report them.
+var Categories = []Category{Fuel, Residential, DeliveryArea, AdditionalHandling, Oversize, Signature, DeclaredValue, Peak, Other}
+
+// rules are tried in order, so peak and demand surcharges named after
+// another surcharge, e.g. "Demand Surcharge - Additional Handling", count
+// as peak.
+var rules = []struct {
+	category Category
+	words    []string
+}{
+	{Peak, []string{"PEAK", "DEMAND"}},
+	{Fuel, []string{"FUEL"}},
+	{Residential, []string{"RESIDENTIAL"}},
+	{DeliveryArea, []string{"DELIVERY AREA", "DAS", "REMOTE AREA", "OUT OF DELIVERY AREA"}},
+	{AdditionalHandling, []string{"ADDITIONAL HANDLING", "AHS"}},
+	{Oversize, []string{"OVERSIZE", "OVER SIZE", "LARGE PACKAGE"}},
+	{Signature, []string{"SIGNATURE"}},
+	{DeclaredValue, []string{"DECLARED VALUE", "INSURED VALUE"}},
+}
+
+// Classify returns the category of a surcharge from its type code, such as
+// DELIVERY_AREA, or failing that from its description.
+func Classify(surchargeType string, description string) Category {
+	for _, text := range []string{surchargeType, description} {
+		text = normalize(text)
+		for _, rule := range rules {
+			for _, word := range rule.words {
+				if strings.Contains(text, " "+word+" ") {
+					return rule.category
+				}
+			}
+		}
+	}
+	return Other
+}
+
+// normalize upper-cases s and turns everything but letters and digits into
+// single spaces, with a space at both ends.
+func normalize(s string) string {
+	fields := strings.FieldsFunc(strings.ToUpper(s), func(r rune) bool {
+		return !(r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	})
+	return " " + strings.Join(fields, " ") + " "
+}
+
+// Line is the total of one category.
+type Line struct {
+	Category Category           `json:"category"` //
+	Amount   float64            `json:"amount"`   //
+	Share    float64            `json:"share"`    // of the net charge, 0 to 1
+	Charges  []rate.QuoteCharge `json:"charges"`  // as FedEx returned them
+}
+
+// Breakdown splits the net charge of one or more quotes into the base
+// charge and surcharges by category.
+type Breakdown struct {
+	ServiceType    rate.ServiceType     `json:"serviceType,omitempty"` // empty for a Total over services
+	RateType       rate.RateRequestType `json:"rateType,omitempty"`    //
+	Currency       string               `json:"currency"`              //
+	NetCharge      float64              `json:"netCharge"`             //
+	BaseCharge     float64              `json:"baseCharge"`            //
+	BaseShare      float64              `json:"baseShare"`             //
+	Surcharges     float64              `json:"surcharges"`            //
+	SurchargeShare float64              `json:"surchargeShare"`        //
+	Categories     []Line               `json:"categories"`            // in the order of Categories, those present
+	Packages       []PackageBreakdown   `json:"packages,omitempty"`    // only when FedEx rated packages separately
+}
+
+type PackageBreakdown struct {
+	GroupNumber int     `json:"groupNumber"` //
+	NetCharge   float64 `json:"netCharge"`   //
+	Surcharges  float64 `json:"surcharges"`  //
+	Categories  []Line  `json:"categories"`  // shares are of the package net charge
+}
+
+// Of breaks down a quote.
+func Of(q rate.Quote) Breakdown {
+	b := Breakdown{
+		ServiceType: q.ServiceType,
+		RateType:    q.RateType,
+		Currency:    q.Currency,
+		NetCharge:   q.NetCharge,
+		BaseCharge:  q.BaseCharge,
+	}
+	b.Categories, b.Surcharges = lines(q.Surcharges, q.NetCharge)
+	b.BaseShare = share(b.BaseCharge, b.NetCharge)
+	b.SurchargeShare = share(b.Surcharges, b.NetCharge)
+	for _, p := range q.Packages {
+		pb := PackageBreakdown{GroupNumber: p.GroupNumber, NetCharge: p.NetCharge}
+		pb.Categories, pb.Surcharges = lines(p.Surcharges, p.NetCharge)
+		b.Packages = append(b.Packages, pb)
+	}
+	return b
+}
+
+// Total adds up quotes, e.g. a month of shipments, into one breakdown
+// without packages. The quotes must be in one currency.
+func Total(quotes ...rate.Quote) (Breakdown, error) {
+	var b Breakdown
+	var charges []rate.QuoteCharge
+	for i, q := range quotes {
+		if i == 0 {
+			b.ServiceType, b.RateType, b.Currency = q.ServiceType, q.RateType, q.Currency
+		}
+		if q.Currency != b.Currency {
+			return Breakdown{}, errors.New("surcharge: quotes in both " + b.Currency + " and " + q.Currency)
+		}
+		if q.ServiceType != b.ServiceType {
+			b.ServiceType = ""
+		}
+		if q.RateType != b.RateType {
+			b.RateType = ""
+		}
+		b.NetCharge += q.NetCharge
+		b.BaseCharge += q.BaseCharge
+		charges = append(charges, q.Surcharges...)
+	}
+	b.NetCharge, b.BaseCharge = round(b.NetCharge), round(b.BaseCharge)
+	b.Categories, b.Surcharges = lines(charges, b.NetCharge)
+	b.BaseShare = share(b.BaseCharge, b.NetCharge)
+	b.SurchargeShare = share(b.Surcharges, b.NetCharge)
+	return b, nil
+}
+
+// Line returns the line of category, zero if there is none.
+func (b Breakdown) Line(category Category) Line {
+	for _, l := range b.Categories {
+		if l.Category == category {
+			return l
+		}
+	}
+	return Line{Category: category}
+}
+
+func lines(charges []rate.QuoteCharge, netCharge float64) ([]Line, float64) {
+	byCategory := map[Category]*Line{}
+	var total float64
+	for _, c := range charges {
+		category := Classify(c.Type, c.Description)
+		l, ok := byCategory[category]
+		if !ok {
+			l = &Line{Category: category}
+			byCategory[category] = l
+		}
+		l.Amount += c.Amount
+		l.Charges = append(l.Charges, c)
+		total += c.Amount
+	}
+	var result []Line
+	for _, category := range Categories {
+		if l, ok := byCategory[category]; ok {
+			l.Amount = round(l.Amount)
+			l.Share = share(l.Amount, netCharge)
+			result = append(result, *l)
+		}
+	}
+	return result, round(total)
+}
+
+func share(amount float64, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return amount / total
+}
+
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
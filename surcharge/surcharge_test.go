@@ -0,0 +1,129 @@
+package surcharge_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tirpitz0509/go-fedex/rate"
+	"github.com/tirpitz0509/go-fedex/surcharge"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		surchargeType string
+		description   string
+		want          surcharge.Category
+	}{
+		{"FUEL", "Fuel Surcharge", surcharge.Fuel},
+		{"RESIDENTIAL_DELIVERY", "Residential delivery", surcharge.Residential},
+		{"DELIVERY_AREA", "Delivery Area Surcharge Extended", surcharge.DeliveryArea},
+		{"", "DAS Extended Commercial", surcharge.DeliveryArea},
+		{"", "Out of delivery area tier A", surcharge.DeliveryArea},
+		{"ADDITIONAL_HANDLING", "Additional Handling Surcharge - Weight", surcharge.AdditionalHandling},
+		{"", "AHS - Dimensions", surcharge.AdditionalHandling},
+		{"OVERSIZE", "Oversize charge", surcharge.Oversize},
+		{"", "Large Package Surcharge", surcharge.Oversize},
+		{"SIGNATURE_OPTION", "Direct signature required", surcharge.Signature},
+		{"INSURED_VALUE", "Declared value", surcharge.DeclaredValue},
+		{"PEAK", "Peak Surcharge", surcharge.Peak},
+		// peak and demand surcharges named after another surcharge are peak
+		{"DEMAND", "Demand Surcharge - Additional Handling", surcharge.Peak},
+		{"", "Demand Surcharge - Residential", surcharge.Peak},
+		{"", "Peak - Oversize Charge", surcharge.Peak},
+		// abbreviations match whole words only
+		{"", "Dashboard fee", surcharge.Other},
+		{"", "Ahsoka handling", surcharge.Other},
+		{"", "Fuelish charge", surcharge.Other},
+		// the type code wins over the description
+		{"FUEL", "Residential delivery", surcharge.Fuel},
+		{"SATURDAY_DELIVERY", "Saturday delivery", surcharge.Other},
+		{"", "", surcharge.Other},
+	}
+	for _, tt := range tests {
+		if got := surcharge.Classify(tt.surchargeType, tt.description); got != tt.want {
+			t.Errorf("Classify(%q, %q) = %s, want %s", tt.surchargeType, tt.description, got, tt.want)
+		}
+	}
+}
+
+func groundQuote() rate.Quote {
+	return rate.Quote{
+		ServiceType: rate.FedexGround,
+		RateType:    rate.RateAccount,
+		Currency:    "USD",
+		BaseCharge:  20,
+		NetCharge:   32,
+		Surcharges: []rate.QuoteCharge{
+			{Type: "FUEL", Description: "Fuel Surcharge", Amount: 3},
+			{Type: "RESIDENTIAL_DELIVERY", Description: "Residential delivery", Amount: 5},
+			{Type: "DEMAND", Description: "Demand Surcharge - Residential", Amount: 1.5},
+			{Type: "PEAK", Description: "Peak Surcharge", Amount: 0.5},
+			{Type: "SATURDAY_DELIVERY", Description: "Saturday delivery", Amount: 2},
+		},
+		Packages: []rate.PackageQuote{
+			{GroupNumber: 1, NetCharge: 32, Surcharges: []rate.QuoteCharge{{Type: "FUEL", Amount: 3}, {Type: "RESIDENTIAL_DELIVERY", Amount: 5}}},
+		},
+	}
+}
+
+func TestOf(t *testing.T) {
+	b := surcharge.Of(groundQuote())
+
+	if b.ServiceType != rate.FedexGround || b.Currency != "USD" || b.NetCharge != 32 || b.BaseCharge != 20 || b.Surcharges != 12 {
+		t.Errorf("breakdown = %+v", b)
+	}
+	if b.BaseShare != 0.625 || b.SurchargeShare != 0.375 {
+		t.Errorf("shares = %v and %v, want 0.625 and 0.375", b.BaseShare, b.SurchargeShare)
+	}
+
+	var categories []string
+	for _, l := range b.Categories {
+		categories = append(categories, string(l.Category))
+	}
+	if got := strings.Join(categories, ","); got != "FUEL,RESIDENTIAL,PEAK,OTHER" {
+		t.Errorf("categories = %s, want FUEL,RESIDENTIAL,PEAK,OTHER", got)
+	}
+	if peak := b.Line(surcharge.Peak); peak.Amount != 2 || peak.Share != 0.0625 || len(peak.Charges) != 2 {
+		t.Errorf("peak line = %+v, want both peak charges", peak)
+	}
+	if oversize := b.Line(surcharge.Oversize); oversize.Amount != 0 || oversize.Category != surcharge.Oversize {
+		t.Errorf("missing line = %+v, want zero", oversize)
+	}
+
+	if len(b.Packages) != 1 {
+		t.Fatalf("packages = %+v, want 1", b.Packages)
+	}
+	if p := b.Packages[0]; p.GroupNumber != 1 || p.Surcharges != 8 || len(p.Categories) != 2 || p.Categories[1].Share != 5.0/32 {
+		t.Errorf("package = %+v", p)
+	}
+}
+
+func TestTotal(t *testing.T) {
+	express := groundQuote()
+	express.ServiceType = rate.PriorityOvernight
+	express.NetCharge, express.BaseCharge = 50.1, 38.1
+
+	b, err := surcharge.Total(groundQuote(), express)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.ServiceType != "" || b.RateType != rate.RateAccount || b.Currency != "USD" {
+		t.Errorf("breakdown = %+v, want no service type and ACCOUNT rates", b)
+	}
+	if b.NetCharge != 82.1 || b.BaseCharge != 58.1 || b.Surcharges != 24 || b.Packages != nil {
+		t.Errorf("totals = %v net, %v base, %v surcharges, packages %v", b.NetCharge, b.BaseCharge, b.Surcharges, b.Packages)
+	}
+	if fuel := b.Line(surcharge.Fuel); fuel.Amount != 6 || len(fuel.Charges) != 2 {
+		t.Errorf("fuel line = %+v", fuel)
+	}
+
+	if b, err := surcharge.Total(); err != nil || b.NetCharge != 0 || b.Categories != nil {
+		t.Errorf("Total() = %+v, %v, want an empty breakdown", b, err)
+	}
+
+	euros := groundQuote()
+	euros.Currency = "EUR"
+	if _, err := surcharge.Total(groundQuote(), euros); err == nil || !strings.Contains(err.Error(), "USD and EUR") {
+		t.Errorf("Total in two currencies: err = %v, want one naming USD and EUR", err)
+	}
+}
@@ -0,0 +1,361 @@
+// Package tenant keeps FedEx credentials for many merchants apart: each
+// tenant gets its own account number, environment, token cache and rate
+// limit, loaded from a pluggable secret provider.
+package tenant
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/tirpitz0509/go-fedex/auth"
+	"github.com/tirpitz0509/go-fedex/common"
+	"github.com/tirpitz0509/go-fedex/rate"
+	"github.com/tirpitz0509/go-fedex/track"
+)
+
+var ErrUnknownTenant = errors.New("tenant: unknown tenant")
+
+// Credentials are everything FedEx needs to act for one tenant. The SOAP
+// fields are only needed for RateXML.
+type Credentials struct {
+	GrantType         string `json:"grantType,omitempty"`         // client_credentials if empty
+	ClientId          string `json:"clientId"`                    //
+	ClientSecret      string `json:"clientSecret"`                //
+	ChildKey          string `json:"childKey,omitempty"`          //
+	ChildSecret       string `json:"childSecret,omitempty"`       //
+	AccountNumber     string `json:"accountNumber"`               //
+	Key               string `json:"key,omitempty"`               // Web Services key
+	Password          string `json:"password,omitempty"`          //
+	MeterNumber       string `json:"meterNumber,omitempty"`       //
+	TestMode          bool   `json:"testMode,omitempty"`          //
+	Locale            string `json:"locale,omitempty"`            // X-locale of REST calls, en_US if empty
+	BaseURL           string `json:"baseUrl,omitempty"`           // overrides the REST URL
+	SOAPURL           string `json:"soapUrl,omitempty"`           // overrides the Web Services URL
+	RESTCompatibility bool   `json:"restCompatibility,omitempty"` // RateXML goes to the REST API
+}
+
+// SecretProvider looks up a tenant's credentials, e.g. in a vault. It is
+// asked again whenever a tenant is rotated.
+type SecretProvider interface {
+	Credentials(tenantId string) (Credentials, error)
+}
+
+type ProviderFunc func(tenantId string) (Credentials, error)
+
+func (f ProviderFunc) Credentials(tenantId string) (Credentials, error) {
+	return f(tenantId)
+}
+
+// StaticProvider serves credentials from memory.
+type StaticProvider map[string]Credentials
+
+func (p StaticProvider) Credentials(tenantId string) (Credentials, error) {
+	c, ok := p[tenantId]
+	if !ok {
+		return c, ErrUnknownTenant
+	}
+	return c, nil
+}
+
+// Registry hands out Tenants, loading them from Provider on first use.
+type Registry struct {
+	Provider  SecretProvider //
+	RateLimit float64        // requests per second per tenant, unlimited if 0
+	Client    *http.Client   // shared by all tenants
+
+	mu      sync.Mutex
+	tenants map[string]*Tenant
+}
+
+func NewRegistry(provider SecretProvider) *Registry {
+	return &Registry{Provider: provider, tenants: map[string]*Tenant{}}
+}
+
+func (r *Registry) Tenant(id string) (*Tenant, error) {
+	r.mu.Lock()
+	t, ok := r.tenants[id]
+	r.mu.Unlock()
+	if ok {
+		return t, nil
+	}
+	if r.Provider == nil {
+		return nil, ErrUnknownTenant
+	}
+	credentials, err := r.Provider.Credentials(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// another goroutine may have loaded it meanwhile
+	if t, ok := r.tenants[id]; ok {
+		return t, nil
+	}
+	return r.register(id, credentials), nil
+}
+
+// Register adds or replaces a tenant without asking the provider.
+func (r *Registry) Register(id string, credentials Credentials) *Tenant {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.register(id, credentials)
+}
+
+// Rotate reloads a tenant's credentials from the provider. Tokens issued for
+// the old credentials are dropped; Tenants handed out earlier keep working
+// with the old ones.
+func (r *Registry) Rotate(id string) (*Tenant, error) {
+	if r.Provider == nil {
+		return nil, ErrUnknownTenant
+	}
+	credentials, err := r.Provider.Credentials(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.Register(id, credentials), nil
+}
+
+func (r *Registry) Remove(id string) {
+	r.mu.Lock()
+	delete(r.tenants, id)
+	r.mu.Unlock()
+}
+
+func (r *Registry) register(id string, credentials Credentials) *Tenant {
+	if r.tenants == nil {
+		r.tenants = map[string]*Tenant{}
+	}
+	t := &Tenant{ID: id, Credentials: credentials, client: r.Client}
+	t.tokens = auth.NewTokenCache(t.Auth())
+	if r.RateLimit > 0 {
+		t.limiter = &limiter{interval: time.Duration(float64(time.Second) / r.RateLimit)}
+	}
+	// keep the limiter across rotations so they cannot be used to burst
+	if old, ok := r.tenants[id]; ok && old.limiter != nil && t.limiter != nil {
+		t.limiter = old.limiter
+	}
+	r.tenants[id] = t
+	return t
+}
+
+// Tenant is a FedEx client bound to one merchant's credentials.
+type Tenant struct {
+	ID          string      //
+	Credentials Credentials //
+
+	client  *http.Client
+	tokens  *auth.TokenCache
+	limiter *limiter
+}
+
+func (t *Tenant) Auth() auth.FedEXAuth {
+	c := t.Credentials
+	return auth.FedEXAuth{
+		GrantType:    c.GrantType,
+		ClientId:     c.ClientId,
+		ClientSecret: c.ClientSecret,
+		ChildKey:     c.ChildKey,
+		ChildSecret:  c.ChildSecret,
+		TestMode:     c.TestMode,
+		BaseURL:      c.BaseURL,
+		Client:       t.client,
+	}
+}
+
+// TokenSource hands out this tenant's cached tokens; it fits
+// rate.TokenSource.
+func (t *Tenant) TokenSource() func() (string, string, error) {
+	return t.tokens.TokenSource(t.Auth().GrantStrategy())
+}
+
+// InvalidateToken drops the tenant's cached token, e.g. after FedEx
+// rejected it before it was due to expire.
+func (t *Tenant) InvalidateToken() {
+	t.tokens.Invalidate(t.Auth().GrantStrategy())
+}
+
+// Rate quotes request on the tenant's account.
+func (t *Tenant) Rate(request rate.RateRequest) (rate.RateResponse, error) {
+	return t.RateContext(context.Background(), request)
+}
+
+// RateContext is Rate bound to ctx, which may carry a customer transaction
+// ID from common.WithTransactionID. If FedEx refuses the cached token, a new
+// one is fetched and the request is sent once more. A token that cannot be
+// fetched at all is returned as a *TokenError.
+func (t *Tenant) RateContext(ctx context.Context, request rate.RateRequest) (rate.RateResponse, error) {
+	request.AccountNumber.Value = t.Credentials.AccountNumber
+	var response rate.RateResponse
+	err := t.retryToken(common.OperationRate, func() (string, error) {
+		fedex, token, err := t.rest(ctx)
+		if err != nil {
+			return "", err
+		}
+		response, err = request.RateWith(fedex, token)
+		if err != nil && len(response.Errors) > 0 {
+			return response.Errors[0].Code, err
+		}
+		return "", err
+	})
+	return response, err
+}
+
+// Track looks shipments up with the tenant's token.
+func (t *Tenant) Track(request track.TrackRequest) (track.TrackResponse, error) {
+	return t.TrackContext(context.Background(), request)
+}
+
+// TrackContext is Track bound to ctx, retrying a refused token like
+// RateContext.
+func (t *Tenant) TrackContext(ctx context.Context, request track.TrackRequest) (track.TrackResponse, error) {
+	var response track.TrackResponse
+	err := t.retryToken(common.OperationTrack, func() (string, error) {
+		fedex, token, err := t.rest(ctx)
+		if err != nil {
+			return "", err
+		}
+		response, err = request.TrackWith(fedex, token)
+		if err != nil && len(response.Errors) > 0 {
+			return response.Errors[0].Code, err
+		}
+		return "", err
+	})
+	return response, err
+}
+
+// TokenError is a failure to get a token for the tenant, as opposed to a
+// failed call with one.
+type TokenError struct {
+	Err error
+}
+
+func (e *TokenError) Error() string {
+	return "tenant: token: " + e.Err.Error()
+}
+
+func (e *TokenError) Unwrap() error {
+	return e.Err
+}
+
+// retryToken runs call, which returns FedEx's error code if it failed with
+// one, and runs it once more with a new token if FedEx refused the cached
+// one.
+func (t *Tenant) retryToken(operation string, call func() (string, error)) error {
+	code, err := call()
+	if err != nil && tokenRefused(code, err) {
+		t.InvalidateToken()
+		common.Count(nil, common.MetricRetries, 1, map[string]string{"operation": operation, "reason": "token"})
+		_, err = call()
+	}
+	return err
+}
+
+// tokenRefused reports whether a call failed on the token rather than on
+// the request.
+func tokenRefused(fedexCode string, err error) bool {
+	if fedexCode != "" {
+		return fedexCode == "NOT.AUTHORIZED.ERROR" || fedexCode == "LOGIN.REAUTHENTICATE.ERROR"
+	}
+	var transaction *common.TransactionError
+	return errors.As(err, &transaction) && transaction.StatusCode == http.StatusUnauthorized
+}
+
+// rest returns the transport and token for one REST call, once the rate
+// limit allows it.
+func (t *Tenant) rest(ctx context.Context) (common.Fedex, string, error) {
+	token, apiUrl, err := t.TokenSource()()
+	if err != nil {
+		return common.Fedex{}, "", &TokenError{Err: err}
+	}
+	if err := t.limiter.wait(ctx); err != nil {
+		return common.Fedex{}, "", err
+	}
+	fedex := common.Fedex{TestMode: t.Credentials.TestMode, BaseURL: apiUrl, Client: t.client, Context: ctx}
+	if locale := t.Credentials.Locale; locale != "" {
+		fedex.Middleware = []common.Middleware{func(next common.Handler) common.Handler {
+			return func(ex *common.Exchange) error {
+				ex.Header.Set("X-locale", locale)
+				return next(ex)
+			}
+		}}
+	}
+	return fedex, token, nil
+}
+
+// RateXML quotes a SOAP request with the tenant's Web Services credentials
+// and account, or through the REST API with the tenant's own tokens when
+// Credentials.RESTCompatibility is set. A SENDER payment is billed to the
+// tenant unless it names another payor; other payments are left alone.
+func (t *Tenant) RateXML(request rate.RateXMLRequest) (rate.RateXMLResponse, error) {
+	return t.RateXMLContext(context.Background(), request)
+}
+
+// RateXMLContext is RateXML bound to ctx.
+func (t *Tenant) RateXMLContext(ctx context.Context, request rate.RateXMLRequest) (rate.RateXMLResponse, error) {
+	if err := t.limiter.wait(ctx); err != nil {
+		return rate.RateXMLResponse{}, err
+	}
+	c := t.Credentials
+	rr := &request.Body.RateRequest
+	payment := &rr.RequestedShipment.ShippingChargesPayment
+	// the account the request came with pays for itself, which is now the tenant
+	if payor := &payment.Payor.ResponsibleParty; payment.PaymentType == "SENDER" &&
+		(payor.AccountNumber == "" || payor.AccountNumber == rr.ClientDetail.AccountNumber) {
+		payor.AccountNumber = c.AccountNumber
+	}
+	rr.WebAuthenticationDetail.UserCredential.Key = c.Key
+	rr.WebAuthenticationDetail.UserCredential.Password = c.Password
+	rr.ClientDetail.AccountNumber = c.AccountNumber
+	rr.ClientDetail.MeterNumber = c.MeterNumber
+	rr.RequestedShipment.Shipper.AccountNumber = c.AccountNumber
+	fedex := common.Fedex{TestMode: c.TestMode, BaseURL: c.SOAPURL, Client: t.client, Context: ctx}
+	if c.RESTCompatibility {
+		fedex.RESTTokens = t.TokenSource()
+	}
+	return request.RateWith(fedex, "/rate")
+}
+
+// limiter spaces out a tenant's requests to at most one per interval.
+type limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// wait blocks until the caller's turn, or returns ctx.Err() when ctx is done
+// first, handing the turn back if no one has queued behind it.
+func (l *limiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	slot := l.next
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := slot.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		if l.next.Equal(slot.Add(l.interval)) {
+			l.next = slot
+		}
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,254 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tirpitz0509/go-fedex/fedextest"
+	"github.com/tirpitz0509/go-fedex/rate"
+)
+
+func groundRequest(t *testing.T) rate.RateRequest {
+	request, err := rate.NewShipment("000000000").
+		From(rate.Address{CountryCode: "US", PostalCode: "38017"}).
+		To(rate.Address{CountryCode: "US", PostalCode: "90210"}).
+		Service(rate.FedexGround).
+		Packaging(rate.YourPackaging).
+		AddPackage(rate.NewPackage(5, "LB")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return request
+}
+
+// tokenClients returns the client id of every token request s received.
+func tokenClients(s *fedextest.Server) []string {
+	var ids []string
+	for _, r := range s.Requests() {
+		if r.Path == fedextest.TokenPath {
+			form, _ := url.ParseQuery(string(r.Body))
+			ids = append(ids, form.Get("client_id"))
+		}
+	}
+	return ids
+}
+
+// rateAuthorization returns the bearer token of every rate request s received.
+func rateAuthorization(s *fedextest.Server) []string {
+	var tokens []string
+	for _, r := range s.Requests() {
+		if r.Path == fedextest.RatePath {
+			tokens = append(tokens, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		}
+	}
+	return tokens
+}
+
+func TestTenantsHaveTheirOwnTokens(t *testing.T) {
+	s := fedextest.NewServer()
+	defer s.Close()
+	registry := NewRegistry(nil)
+	registry.Client = s.Client()
+	a := registry.Register("a", Credentials{ClientId: "client-a", ClientSecret: "secret-a", AccountNumber: "111111111", BaseURL: s.URL})
+	b := registry.Register("b", Credentials{ClientId: "client-b", ClientSecret: "secret-b", AccountNumber: "222222222", BaseURL: s.URL})
+
+	for _, tenant := range []*Tenant{a, b, a, b} {
+		if _, err := tenant.Rate(groundRequest(t)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := strings.Join(tokenClients(s), ","); got != "client-a,client-b" {
+		t.Errorf("token requests by %s, want one each by client-a,client-b", got)
+	}
+	tokens := rateAuthorization(s)
+	if len(tokens) != 4 || tokens[0] != tokens[2] || tokens[1] != tokens[3] || tokens[0] == tokens[1] {
+		t.Errorf("rate requests used tokens %v, want a's and b's alternating", tokens)
+	}
+
+	a.InvalidateToken()
+	if _, err := b.Rate(groundRequest(t)); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(tokenClients(s)); n != 2 {
+		t.Errorf("invalidating a's token made b fetch a new one: %d token requests", n)
+	}
+}
+
+func TestRateRetriesRefusedToken(t *testing.T) {
+	s := fedextest.NewServer()
+	defer s.Close()
+	registry := NewRegistry(nil)
+	registry.Client = s.Client()
+	a := registry.Register("a", Credentials{ClientId: "client-a", ClientSecret: "secret-a", AccountNumber: "111111111", BaseURL: s.URL})
+	if _, err := a.Rate(groundRequest(t)); err != nil {
+		t.Fatal(err)
+	}
+
+	s.ExpireTokens()
+	if _, err := a.Rate(groundRequest(t)); err != nil {
+		t.Fatalf("Rate() after the token expired = %v, want a retry with a new token", err)
+	}
+	tokens := rateAuthorization(s)
+	if len(tokens) != 3 || tokens[1] != tokens[0] || tokens[2] == tokens[1] {
+		t.Errorf("rate requests used tokens %v, want the old one refused and a new one", tokens)
+	}
+
+	s.Fail(http.StatusUnauthorized, "NOT.AUTHORIZED.ERROR", "refused")
+	s.Fail(http.StatusUnauthorized, "NOT.AUTHORIZED.ERROR", "refused")
+	if _, err := a.Rate(groundRequest(t)); err == nil {
+		t.Error("Rate() = nil, want the second refusal returned")
+	}
+	if n := len(rateAuthorization(s)); n != 5 {
+		t.Errorf("%d rate requests, want a single retry", n)
+	}
+
+	s.Unavailable(true)
+	a.InvalidateToken()
+	var tokenErr *TokenError
+	if _, err := a.Rate(groundRequest(t)); !errors.As(err, &tokenErr) {
+		t.Errorf("Rate() without a token = %v, want a *TokenError", err)
+	}
+}
+
+func TestRotate(t *testing.T) {
+	s := fedextest.NewServer()
+	defer s.Close()
+	secrets := map[string]Credentials{
+		"a": {ClientId: "client-a", ClientSecret: "old", AccountNumber: "111111111", BaseURL: s.URL},
+	}
+	registry := NewRegistry(ProviderFunc(func(id string) (Credentials, error) {
+		c, ok := secrets[id]
+		if !ok {
+			return c, ErrUnknownTenant
+		}
+		return c, nil
+	}))
+	registry.Client = s.Client()
+
+	old, err := registry.Tenant("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := old.Rate(groundRequest(t)); err != nil {
+		t.Fatal(err)
+	}
+
+	secrets["a"] = Credentials{ClientId: "client-a2", ClientSecret: "new", AccountNumber: "333333333", BaseURL: s.URL}
+	rotated, err := registry.Rotate("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current, _ := registry.Tenant("a"); current != rotated || rotated.Credentials.ClientSecret != "new" {
+		t.Fatalf("Tenant after Rotate = %+v, want the new credentials", current.Credentials)
+	}
+	if _, err := rotated.Rate(groundRequest(t)); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(tokenClients(s), ","); got != "client-a,client-a2" {
+		t.Errorf("token requests by %s, want client-a,client-a2", got)
+	}
+	if old.Credentials.ClientId != "client-a" {
+		t.Errorf("tenant handed out before Rotate now has %q", old.Credentials.ClientId)
+	}
+
+	if _, err := registry.Rotate("missing"); err != ErrUnknownTenant {
+		t.Errorf("Rotate(missing) = %v, want ErrUnknownTenant", err)
+	}
+}
+
+func TestRateXMLPayor(t *testing.T) {
+	tests := []struct {
+		name        string
+		paymentType string
+		account     string
+		want        string
+	}{
+		{"sender billed to the request account", "SENDER", "000000000", "111111111"},
+		{"sender without account", "SENDER", "", "111111111"},
+		{"sender naming another payor", "SENDER", "444444444", "444444444"},
+		{"third party", "THIRD_PARTY", "555555555", "555555555"},
+		{"recipient", "RECIPIENT", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := fedextest.NewServer()
+			defer s.Close()
+			registry := NewRegistry(nil)
+			registry.Client = s.Client()
+			tenant := registry.Register("a", Credentials{Key: "key", Password: "password", MeterNumber: "meter", AccountNumber: "111111111", SOAPURL: s.URL + "/web-services"})
+
+			x, err := rate.NewShipment("000000000").
+				From(rate.Address{CountryCode: "US", PostalCode: "38017"}).
+				To(rate.Address{CountryCode: "US", PostalCode: "90210"}).
+				Service(rate.FedexGround).
+				Packaging(rate.YourPackaging).
+				AddPackage(rate.NewPackage(5, "LB")).
+				BuildXML("placeholder", "placeholder", "placeholder")
+			if err != nil {
+				t.Fatal(err)
+			}
+			payment := &x.Body.RateRequest.RequestedShipment.ShippingChargesPayment
+			payment.PaymentType = tt.paymentType
+			payment.Payor.ResponsibleParty.AccountNumber = tt.account
+			if _, err := tenant.RateXML(x); err != nil {
+				t.Fatal(err)
+			}
+
+			requests := s.Requests()
+			body := string(requests[len(requests)-1].Body)
+			payor := body[strings.Index(body, "<ShippingChargesPayment>"):strings.Index(body, "</ShippingChargesPayment>")]
+			if tt.want == "" && strings.Contains(payor, "<AccountNumber>") {
+				t.Errorf("payment %s, want no payor account", payor)
+			}
+			if tt.want != "" && !strings.Contains(payor, "<AccountNumber>"+tt.want+"</AccountNumber>") {
+				t.Errorf("payment %s, want payor %s", payor, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimiterSpacesRequests(t *testing.T) {
+	l := &limiter{interval: 20 * time.Millisecond}
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := l.wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("4 requests took %v, want at least 3 intervals", elapsed)
+	}
+}
+
+func TestLimiterGivesBackCancelledTurns(t *testing.T) {
+	l := &limiter{interval: time.Hour}
+	if err := l.wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	next := l.next
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := l.wait(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("wait() = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("cancelled wait took %v", elapsed)
+	}
+	if !l.next.Equal(next) {
+		t.Errorf("next turn = %v, want the cancelled one back at %v", l.next, next)
+	}
+
+	var unlimited *limiter
+	if err := unlimited.wait(ctx); err != nil {
+		t.Errorf("unlimited wait() = %v, want nil", err)
+	}
+}
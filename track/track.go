@@ -0,0 +1,237 @@
+// Package track looks shipments up with the FedEx Track API.
+//
+//	request := track.NewRequest("794843185271", "794843185272")
+//	reply, err := request.Track(token, apiUrl)
+//	for _, r := range reply.Results() {
+//		fmt.Println(r.TrackingNumberInfo.TrackingNumber, r.LatestStatusDetail.Description)
+//	}
+package track
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/tirpitz0509/go-fedex/common"
+)
+
+// MaxTrackingNumbers is the most tracking numbers FedEx takes in one request.
+const MaxTrackingNumbers = 30
+
+type TrackingNumberInfo struct {
+	TrackingNumber         string `json:"trackingNumber"`                   //
+	CarrierCode            string `json:"carrierCode,omitempty"`            // FDXE, FDXG, ...
+	TrackingNumberUniqueId string `json:"trackingNumberUniqueId,omitempty"` // tells reused numbers apart
+}
+
+type TrackingInfo struct {
+	TrackingNumberInfo TrackingNumberInfo `json:"trackingNumberInfo"`      //
+	ShipDateBegin      string             `json:"shipDateBegin,omitempty"` // YYYY-MM-DD
+	ShipDateEnd        string             `json:"shipDateEnd,omitempty"`   // YYYY-MM-DD
+}
+
+type TrackRequest struct {
+	IncludeDetailedScans bool           `json:"includeDetailedScans"` //
+	TrackingInfo         []TrackingInfo `json:"trackingInfo"`         //
+}
+
+// NewRequest tracks the given numbers with their scan events.
+func NewRequest(trackingNumbers ...string) TrackRequest {
+	request := TrackRequest{IncludeDetailedScans: true}
+	for _, n := range trackingNumbers {
+		request.TrackingInfo = append(request.TrackingInfo, TrackingInfo{TrackingNumberInfo: TrackingNumberInfo{TrackingNumber: n}})
+	}
+	return request
+}
+
+type Message struct {
+	Code    string `json:"code,omitempty"`    //
+	Message string `json:"message,omitempty"` //
+}
+
+type Location struct {
+	StreetLines         []string `json:"streetLines,omitempty"`         //
+	City                string   `json:"city,omitempty"`                //
+	StateOrProvinceCode string   `json:"stateOrProvinceCode,omitempty"` //
+	PostalCode          string   `json:"postalCode,omitempty"`          //
+	CountryCode         string   `json:"countryCode,omitempty"`         //
+	Residential         bool     `json:"residential,omitempty"`         //
+}
+
+type StatusDetail struct {
+	Code           string   `json:"code,omitempty"`           // e.g. DL, IT, PU
+	DerivedCode    string   `json:"derivedCode,omitempty"`    //
+	StatusByLocale string   `json:"statusByLocale,omitempty"` //
+	Description    string   `json:"description,omitempty"`    //
+	ScanLocation   Location `json:"scanLocation,omitempty"`   //
+}
+
+type DateAndTime struct {
+	Type     string `json:"type"`     // e.g. ACTUAL_PICKUP, ESTIMATED_DELIVERY, ACTUAL_DELIVERY
+	DateTime string `json:"dateTime"` // RFC 3339 with the local offset
+}
+
+type ScanEvent struct {
+	Date                 string   `json:"date"`                           //
+	EventType            string   `json:"eventType"`                      //
+	EventDescription     string   `json:"eventDescription"`               //
+	ExceptionCode        string   `json:"exceptionCode,omitempty"`        //
+	ExceptionDescription string   `json:"exceptionDescription,omitempty"` //
+	ScanLocation         Location `json:"scanLocation,omitempty"`         //
+	DerivedStatusCode    string   `json:"derivedStatusCode,omitempty"`    //
+	DerivedStatus        string   `json:"derivedStatus,omitempty"`        //
+}
+
+type ServiceDetail struct {
+	Type        string `json:"type,omitempty"`        // e.g. FEDEX_GROUND
+	Description string `json:"description,omitempty"` //
+}
+
+type TimeWindow struct {
+	Begins string `json:"begins,omitempty"` //
+	Ends   string `json:"ends,omitempty"`   //
+}
+
+type DeliveryTimeWindow struct {
+	Type   string     `json:"type,omitempty"`   //
+	Window TimeWindow `json:"window,omitempty"` //
+}
+
+// TrackResult is what FedEx knows of one shipment. Error is set instead of
+// the rest when the number was not found.
+type TrackResult struct {
+	TrackingNumberInfo          TrackingNumberInfo `json:"trackingNumberInfo"`                    //
+	LatestStatusDetail          StatusDetail       `json:"latestStatusDetail,omitempty"`          //
+	DateAndTimes                []DateAndTime      `json:"dateAndTimes,omitempty"`                //
+	ScanEvents                  []ScanEvent        `json:"scanEvents,omitempty"`                  // most recent first
+	ServiceDetail               ServiceDetail      `json:"serviceDetail,omitempty"`               //
+	EstimatedDeliveryTimeWindow DeliveryTimeWindow `json:"estimatedDeliveryTimeWindow,omitempty"` //
+	Error                       *Message           `json:"error,omitempty"`                       //
+}
+
+// DateAndTime returns the time of the given type, e.g. ACTUAL_DELIVERY, or
+// "" if FedEx sent none.
+func (r TrackResult) DateAndTime(dateType string) string {
+	for _, d := range r.DateAndTimes {
+		if d.Type == dateType {
+			return d.DateTime
+		}
+	}
+	return ""
+}
+
+type CompleteTrackResult struct {
+	TrackingNumber string        `json:"trackingNumber"` //
+	TrackResults   []TrackResult `json:"trackResults"`   // more than one if the number was reused
+}
+
+type TrackOutput struct {
+	CompleteTrackResults []CompleteTrackResult `json:"completeTrackResults"` //
+}
+
+type TrackResponse struct {
+	TransactionID         string      `json:"transactionId"`         //
+	CustomerTransactionID string      `json:"customerTransactionId"` //
+	Output                TrackOutput `json:"output,omitempty"`      //
+	Errors                []Message   `json:"errors,omitempty"`      //
+}
+
+// Results flattens the results of every tracking number, in request order.
+func (r TrackResponse) Results() []TrackResult {
+	var results []TrackResult
+	for _, c := range r.Output.CompleteTrackResults {
+		results = append(results, c.TrackResults...)
+	}
+	return results
+}
+
+func (c TrackRequest) Track(token string, apiUrl string) (TrackResponse, error) {
+	return c.TrackWith(common.Fedex{BaseURL: apiUrl}, token)
+}
+
+// TrackWith sends the request through the given transport; fedex.BaseURL is
+// the REST API URL, e.g. auth.FedexAuthResponse.Url.
+func (c TrackRequest) TrackWith(fedex common.Fedex, token string) (TrackResponse, error) {
+	if len(c.TrackingInfo) == 0 {
+		return TrackResponse{}, errors.New("track: no tracking numbers")
+	}
+	if len(c.TrackingInfo) > MaxTrackingNumbers {
+		return TrackResponse{}, errors.New("track: at most " + strconv.Itoa(MaxTrackingNumbers) + " tracking numbers per request")
+	}
+	fedex.CustomerTransactionID = fedex.TransactionID()
+	call := fedex.StartCall(common.OperationTrack)
+	fedex.Context = call.Context()
+
+	var response TrackResponse
+	var statusCode int
+	ex := &common.Exchange{Operation: common.OperationTrack, Request: &c}
+	err := fedex.Run(ex, func(ex *common.Exchange) error {
+		var err error
+		response, statusCode, err = c.send(fedex, token, ex)
+		ex.Result = &response
+		return err
+	})
+	if r, ok := ex.Result.(*TrackResponse); ok {
+		response = *r
+	}
+
+	if response.CustomerTransactionID == "" {
+		response.CustomerTransactionID = fedex.CustomerTransactionID
+	}
+	if err != nil {
+		err = &common.TransactionError{Err: err, CustomerTransactionID: response.CustomerTransactionID, TransactionID: response.TransactionID, StatusCode: statusCode}
+	}
+
+	call.SetAttribute(common.AttributeTransactionID, response.TransactionID)
+	call.SetAttribute(common.AttributeCustomerTransactionID, response.CustomerTransactionID)
+	if len(response.Errors) > 0 {
+		call.ErrorCode(response.Errors[0].Code)
+	}
+	call.End(statusCode, err)
+	return response, err
+}
+
+func (c TrackRequest) send(fedex common.Fedex, token string, ex *common.Exchange) (TrackResponse, int, error) {
+	var _response TrackResponse
+	reqUrl := fedex.BaseURL + "/track/v1/trackingnumbers"
+
+	request, err := json.Marshal(c)
+	if err != nil {
+		return _response, 0, err
+	}
+
+	req, err := http.NewRequest("POST", reqUrl, bytes.NewBuffer(request))
+	if err != nil {
+		return _response, 0, err
+	}
+	req.Header.Add("Content-type", "application/json")
+	req.Header.Add("Accept", "application/json")
+	req.Header.Add("Authorization", "Bearer "+token)
+	req.Header.Add("X-locale", "en_US")
+	req.Header.Add(common.HeaderCustomerTransactionID, fedex.CustomerTransactionID)
+	ex.Sent(req, request)
+
+	resp, err := fedex.Do(req)
+	if err != nil {
+		return _response, 0, err
+	}
+	defer resp.Body.Close()
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return _response, resp.StatusCode, err
+	}
+	ex.Received(resp, content)
+
+	if errjson := json.Unmarshal(content, &_response); errjson != nil {
+		log.Println(errjson)
+	}
+	if resp.StatusCode != 200 {
+		return _response, resp.StatusCode, errors.New(resp.Status)
+	}
+	return _response, resp.StatusCode, nil
+}
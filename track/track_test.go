@@ -0,0 +1,95 @@
+package track_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/tirpitz0509/go-fedex/common"
+	"github.com/tirpitz0509/go-fedex/fedextest"
+	"github.com/tirpitz0509/go-fedex/track"
+)
+
+var delivered = track.TrackResult{
+	TrackingNumberInfo: track.TrackingNumberInfo{TrackingNumber: "794843185271", CarrierCode: "FDXG"},
+	LatestStatusDetail: track.StatusDetail{Code: "DL", Description: "Delivered", ScanLocation: track.Location{City: "BEVERLY HILLS", CountryCode: "US"}},
+	DateAndTimes:       []track.DateAndTime{{Type: "ACTUAL_DELIVERY", DateTime: "2026-10-14T10:42:00-07:00"}},
+	ScanEvents: []track.ScanEvent{
+		{Date: "2026-10-14T10:42:00-07:00", EventType: "DL", EventDescription: "Delivered"},
+		{Date: "2026-10-12T18:03:00-05:00", EventType: "PU", EventDescription: "Picked up"},
+	},
+	ServiceDetail: track.ServiceDetail{Type: "FEDEX_GROUND"},
+}
+
+func TestTrack(t *testing.T) {
+	s := fedextest.NewServer()
+	defer s.Close()
+	s.Tracking(delivered)
+	token, err := s.Auth("id", "secret").Authorization()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reply, err := track.NewRequest("794843185271", "000000000000").TrackWith(s.REST(), token.AccessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := reply.Results()
+	if len(results) != 2 {
+		t.Fatalf("results = %+v, want 2", results)
+	}
+	if r := results[0]; r.LatestStatusDetail.Code != "DL" || len(r.ScanEvents) != 2 || r.Error != nil {
+		t.Errorf("first result = %+v, want the delivered shipment", r)
+	}
+	if got := results[0].DateAndTime("ACTUAL_DELIVERY"); got != "2026-10-14T10:42:00-07:00" {
+		t.Errorf("ACTUAL_DELIVERY = %q", got)
+	}
+	if got := results[0].DateAndTime("ESTIMATED_DELIVERY"); got != "" {
+		t.Errorf("ESTIMATED_DELIVERY = %q, want none", got)
+	}
+	if r := results[1]; r.Error == nil || r.Error.Code != "TRACKING.TRACKINGNUMBER.NOTFOUND" {
+		t.Errorf("second result = %+v, want not found", r)
+	}
+	if reply.TransactionID == "" || reply.CustomerTransactionID == "" {
+		t.Errorf("reply = %+v, want transaction IDs", reply)
+	}
+
+	requests := s.Requests()
+	if last := requests[len(requests)-1]; last.Path != fedextest.TrackPath || !strings.Contains(string(last.Body), `"includeDetailedScans":true`) {
+		t.Errorf("request = %s %s", last.Path, last.Body)
+	}
+}
+
+func TestTrackErrors(t *testing.T) {
+	s := fedextest.NewServer()
+	defer s.Close()
+	token, err := s.Auth("id", "secret").Authorization()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := track.NewRequest().TrackWith(s.REST(), token.AccessToken); err == nil {
+		t.Error("no tracking numbers: err = nil")
+	}
+	numbers := make([]string, track.MaxTrackingNumbers+1)
+	for i := range numbers {
+		numbers[i] = "794843185271"
+	}
+	if _, err := track.NewRequest(numbers...).TrackWith(s.REST(), token.AccessToken); err == nil {
+		t.Error("too many tracking numbers: err = nil")
+	}
+	if n := len(s.Requests()); n != 1 {
+		t.Errorf("%d requests, want only the token request", n)
+	}
+
+	s.Fail(http.StatusServiceUnavailable, "SERVICE.UNAVAILABLE.ERROR", "down")
+	reply, err := track.NewRequest("794843185271").TrackWith(s.REST(), token.AccessToken)
+	var transaction *common.TransactionError
+	if !errors.As(err, &transaction) || transaction.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("err = %v, want a TransactionError with status 503", err)
+	}
+	if len(reply.Errors) != 1 || reply.Errors[0].Code != "SERVICE.UNAVAILABLE.ERROR" {
+		t.Errorf("errors = %+v", reply.Errors)
+	}
+}
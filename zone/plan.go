@@ -0,0 +1,84 @@
+package zone
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+)
+
+// Choice is an origin and its zone to some destination.
+type Choice struct {
+	Origin string // as given to Plan
+	Zone   string //
+}
+
+// Plan ranks a fixed set of origins, such as warehouses, by zone for every
+// destination prefix, so picking the closest one for an order is a single
+// lookup.
+type Plan struct {
+	Network Network //
+
+	ranked [1000][]Choice
+}
+
+// Plan precomputes the ranking of origins on network. Numeric zones rank
+// by number, before lettered ones; ties keep the order of origins.
+func (x *Index) Plan(network Network, origins ...string) (*Plan, error) {
+	if len(origins) == 0 {
+		return nil, errors.New("zone: no origins to plan")
+	}
+	p := &Plan{Network: network}
+	for _, origin := range origins {
+		if _, err := prefix(origin); err != nil {
+			return nil, err
+		}
+		if _, err := x.Lookup(network, origin, "000"); err != nil && err != ErrNoZone {
+			return nil, err
+		}
+	}
+	for d := 0; d < 1000; d++ {
+		destination := pad(d)
+		for _, origin := range origins {
+			if z, err := x.Lookup(network, origin, destination); err == nil {
+				p.ranked[d] = append(p.ranked[d], Choice{Origin: origin, Zone: z})
+			}
+		}
+		sort.SliceStable(p.ranked[d], func(i, j int) bool {
+			return zoneLess(p.ranked[d][i].Zone, p.ranked[d][j].Zone)
+		})
+	}
+	return p, nil
+}
+
+// Choices returns the origins serving destination, closest first. Five
+// digit exceptions are not applied; use Index.Lookup for exact zones.
+func (p *Plan) Choices(destination string) []Choice {
+	d, err := prefix(destination)
+	if err != nil {
+		return nil
+	}
+	return p.ranked[d]
+}
+
+// Nearest returns the closest origin to destination.
+func (p *Plan) Nearest(destination string) (Choice, bool) {
+	choices := p.Choices(destination)
+	if len(choices) == 0 {
+		return Choice{}, false
+	}
+	return choices[0], true
+}
+
+func zoneLess(a string, b string) bool {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return na < nb
+	case errA == nil:
+		return true
+	case errB == nil:
+		return false
+	}
+	return a < b
+}
@@ -0,0 +1,340 @@
+// Package zone answers FedEx zone queries offline from the zone locator
+// files FedEx publishes per origin ZIP prefix, so zones are known before a
+// rate call, e.g. to choose the warehouse to ship from. An Index satisfies
+// estimate.Zoner.
+package zone
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/tirpitz0509/go-fedex/rate"
+)
+
+type Network int
+
+const (
+	Ground  Network = iota // FedEx Ground, Home Delivery and Ground Economy
+	Express                // FedEx Express services
+)
+
+func (n Network) String() string {
+	if n == Express {
+		return "Express"
+	}
+	return "Ground"
+}
+
+// NetworkOf returns the network whose zones apply to serviceType.
+func NetworkOf(serviceType rate.ServiceType) Network {
+	info, _ := serviceType.Info()
+	switch info.CarrierCode {
+	case rate.CarrierGround, rate.CarrierSmartPost, "":
+		return Ground
+	}
+	return Express
+}
+
+var ErrNoZone = errors.New("zone: no zone for the lane")
+
+// Index holds the zones of every loaded origin in memory. Zones are kept as
+// small integers into a shared name table, so a lookup is two array reads.
+// It is safe for concurrent use.
+type Index struct {
+	mu      sync.RWMutex
+	names   []string // zone names; 0 means no zone
+	ids     map[string]uint8
+	origins map[int]*chart
+}
+
+// chart is the zone locator of one origin prefix.
+type chart struct {
+	prefixes   [2][1000]uint8      // by network and destination prefix
+	exceptions [2][]exceptionRange // 5-digit ranges that override prefixes
+}
+
+type exceptionRange struct {
+	from int   //
+	to   int   // inclusive
+	zone uint8 //
+}
+
+func NewIndex() *Index {
+	return &Index{names: []string{""}, ids: map[string]uint8{}, origins: map[int]*chart{}}
+}
+
+// LoadDir loads every .csv file in dir.
+func (x *Index) LoadDir(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.csv"))
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return errors.New("zone: no .csv files in " + dir)
+	}
+	for _, path := range paths {
+		if err := x.Load(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var digits = regexp.MustCompile(`\d{3,5}`)
+
+// Load reads a zone locator file. The origin is taken from an "Origin ZIP"
+// line above the header if there is one, otherwise from the file name, e.g.
+// 380.csv or zones_38017.csv. A name with several runs of digits, such as
+// zones_2024_38017.csv, is ambiguous; such files need the Origin line.
+func (x *Index) Load(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var origin string
+	if runs := digits.FindAllString(filepath.Base(path), -1); len(runs) == 1 {
+		origin = runs[0]
+	}
+	if err := x.Read(origin, f); err != nil {
+		return errors.New(path + ": " + err.Error())
+	}
+	return nil
+}
+
+// entry is one zone of a locator row, before its name is interned.
+type entry struct {
+	network  Network
+	from, to int
+	five     bool
+	zone     string
+}
+
+// Read reads a zone locator in CSV: a header with a destination ZIP column
+// and Ground and/or Express columns, then a row per destination prefix or
+// range such as 004-005 or 96700-96799. Zones such as NA or "-" mean no
+// service. origin may be empty if the file names it. Each origin may be
+// read once; a failed Read leaves the index unchanged.
+func (x *Index) Read(origin string, r io.Reader) error {
+	c := csv.NewReader(r)
+	c.FieldsPerRecord = -1
+	c.TrimLeadingSpace = true
+	records, err := c.ReadAll()
+	if err != nil {
+		return err
+	}
+
+	destColumn, columns := -1, [2]int{-1, -1}
+	var rows [][]string
+	for i, record := range records {
+		if destColumn >= 0 {
+			rows = records[i:]
+			break
+		}
+		for j, cell := range record {
+			cell = strings.ToLower(cell)
+			switch {
+			case strings.Contains(cell, "origin"):
+				if d := digits.FindString(strings.Join(record, " ")); d != "" {
+					origin = d
+				}
+			case strings.Contains(cell, "dest"):
+				destColumn = j
+			case strings.Contains(cell, "ground"):
+				columns[Ground] = j
+			case strings.Contains(cell, "express"):
+				columns[Express] = j
+			}
+		}
+		if destColumn < 0 {
+			columns = [2]int{-1, -1}
+		}
+	}
+	if destColumn < 0 || columns[Ground] < 0 && columns[Express] < 0 {
+		return errors.New("zone: no header with a destination ZIP column and Ground or Express zones")
+	}
+	if origin == "" {
+		return errors.New("zone: no origin; add an Origin ZIP line or name the file after the origin")
+	}
+	originPrefix, err := prefix(origin)
+	if err != nil {
+		return errors.New("zone: origin: " + err.Error())
+	}
+
+	var entries []entry
+	for n, record := range rows {
+		if destColumn >= len(record) || strings.TrimSpace(record[destColumn]) == "" {
+			continue
+		}
+		from, to, five, err := parseRange(record[destColumn])
+		if err != nil {
+			return errors.New("zone: row " + strconv.Itoa(n+1) + ": " + err.Error())
+		}
+		for network, column := range columns {
+			if column < 0 || column >= len(record) {
+				continue
+			}
+			if zone := zoneName(record[column]); zone != "" {
+				entries = append(entries, entry{network: Network(network), from: from, to: to, five: five, zone: zone})
+			}
+		}
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if _, ok := x.origins[originPrefix]; ok {
+		return errors.New("zone: origin " + pad(originPrefix) + " is already loaded")
+	}
+	added := map[string]bool{}
+	for _, e := range entries {
+		if _, ok := x.ids[e.zone]; !ok {
+			added[e.zone] = true
+		}
+	}
+	if len(x.names)+len(added) > 256 {
+		return errors.New("zone: too many zone names")
+	}
+	ch := &chart{}
+	for _, e := range entries {
+		id := x.id(e.zone)
+		if e.five {
+			ch.exceptions[e.network] = append(ch.exceptions[e.network], exceptionRange{from: e.from, to: e.to, zone: id})
+			continue
+		}
+		for p := e.from; p <= e.to; p++ {
+			ch.prefixes[e.network][p] = id
+		}
+	}
+	x.origins[originPrefix] = ch
+	return nil
+}
+
+// zoneName normalizes a zone cell, e.g. "Zone 05" to "5"; it is empty for
+// no service.
+func zoneName(zone string) string {
+	zone = strings.ToUpper(strings.TrimSpace(zone))
+	zone = strings.TrimSpace(strings.TrimPrefix(zone, "ZONE"))
+	if trimmed := strings.TrimLeft(zone, "0"); trimmed != "" {
+		zone = trimmed
+	}
+	switch zone {
+	case "NA", "N/A", "-", "*", "NONE":
+		return ""
+	}
+	return zone
+}
+
+// id interns a normalized zone name; callers hold mu and have checked
+// there is room.
+func (x *Index) id(zone string) uint8 {
+	if id, ok := x.ids[zone]; ok {
+		return id
+	}
+	x.names = append(x.names, zone)
+	x.ids[zone] = uint8(len(x.names) - 1)
+	return x.ids[zone]
+}
+
+// Lookup returns the zone from origin to destination on network, or
+// ErrNoZone. Postal codes are US ZIP codes, ZIP+4 allowed.
+func (x *Index) Lookup(network Network, origin string, destination string) (string, error) {
+	o, err := prefix(origin)
+	if err != nil {
+		return "", err
+	}
+	d, err := prefix(destination)
+	if err != nil {
+		return "", err
+	}
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	ch, ok := x.origins[o]
+	if !ok {
+		return "", errors.New("zone: no zone locator for origin " + origin)
+	}
+	if exceptions := ch.exceptions[network]; len(exceptions) > 0 {
+		if five, err := strconv.Atoi(zip5(destination)); err == nil {
+			for _, e := range exceptions {
+				if five >= e.from && five <= e.to {
+					return x.names[e.zone], nil
+				}
+			}
+		}
+	}
+	if id := ch.prefixes[network][d]; id != 0 {
+		return x.names[id], nil
+	}
+	return "", ErrNoZone
+}
+
+// Zone looks up the zone on the network of serviceType.
+func (x *Index) Zone(serviceType rate.ServiceType, origin string, destination string) (string, error) {
+	return x.Lookup(NetworkOf(serviceType), origin, destination)
+}
+
+// Origins returns the loaded origin prefixes.
+func (x *Index) Origins() []string {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	var origins []string
+	for o := range x.origins {
+		origins = append(origins, pad(o))
+	}
+	sort.Strings(origins)
+	return origins
+}
+
+func prefix(postalCode string) (int, error) {
+	postalCode = strings.TrimSpace(postalCode)
+	if len(postalCode) < 3 {
+		return 0, errors.New("zone: " + strconv.Quote(postalCode) + " is not a ZIP code")
+	}
+	p, err := strconv.Atoi(postalCode[:3])
+	if err != nil || p < 0 {
+		return 0, errors.New("zone: " + strconv.Quote(postalCode) + " is not a ZIP code")
+	}
+	return p, nil
+}
+
+func zip5(postalCode string) string {
+	postalCode = strings.TrimSpace(postalCode)
+	if len(postalCode) > 5 {
+		return postalCode[:5]
+	}
+	return postalCode
+}
+
+// parseRange parses "004", "004-005" or the 5-digit forms, reporting
+// whether they are 5-digit.
+func parseRange(s string) (int, int, bool, error) {
+	s = strings.TrimSpace(s)
+	fromText, toText := s, s
+	if i := strings.Index(s, "-"); i > 0 {
+		fromText, toText = strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:])
+	}
+	if len(fromText) != len(toText) || len(fromText) != 3 && len(fromText) != 5 {
+		return 0, 0, false, errors.New("bad destination " + strconv.Quote(s))
+	}
+	from, err1 := strconv.Atoi(fromText)
+	to, err2 := strconv.Atoi(toText)
+	if err1 != nil || err2 != nil || from > to {
+		return 0, 0, false, errors.New("bad destination " + strconv.Quote(s))
+	}
+	return from, to, len(fromText) == 5, nil
+}
+
+func pad(p int) string {
+	s := strconv.Itoa(p)
+	for len(s) < 3 {
+		s = "0" + s
+	}
+	return s
+}
@@ -0,0 +1,122 @@
+package zone
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const locator = `Dest. ZIP,Ground,Express
+900-961,8,8
+100-149,5,Zone 06
+96700-96799,9,NA
+`
+
+func TestLoadOrigin(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		origin  string // loaded, or empty if Load fails
+	}{
+		{"prefix", "380.csv", locator, "380"},
+		{"zip in name", "zones_38017.csv", locator, "380"},
+		{"several digit runs", "zones_2024_38017.csv", locator, ""},
+		{"several digit runs with origin line", "zones_2024_38017.csv", "Origin ZIP: 38017\n" + locator, "380"},
+		{"origin line wins", "100.csv", "Origin ZIP: 38017\n" + locator, "380"},
+		{"no origin", "zones.csv", locator, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := ioutil.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			x := NewIndex()
+			err := x.Load(path)
+			if tt.origin == "" {
+				if err == nil {
+					t.Fatalf("Load() loaded origins %v, want an error", x.Origins())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if origins := x.Origins(); len(origins) != 1 || origins[0] != tt.origin {
+				t.Errorf("origins = %v, want %s", origins, tt.origin)
+			}
+		})
+	}
+}
+
+func TestReadRejectsSecondFileForOrigin(t *testing.T) {
+	x := NewIndex()
+	if err := x.Read("38017", strings.NewReader(locator)); err != nil {
+		t.Fatal(err)
+	}
+	err := x.Read("38000", strings.NewReader("Dest. ZIP,Ground\n900-961,2\n"))
+	if err == nil || !strings.Contains(err.Error(), "already loaded") {
+		t.Errorf("second Read() = %v, want already loaded", err)
+	}
+	if zone, err := x.Lookup(Ground, "38017", "90210"); err != nil || zone != "8" {
+		t.Errorf("Lookup() = %q, %v, want the first file's 8", zone, err)
+	}
+}
+
+func TestFailedReadLeavesNames(t *testing.T) {
+	x := NewIndex()
+	if err := x.Read("380", strings.NewReader(locator)); err != nil {
+		t.Fatal(err)
+	}
+	names := len(x.names)
+	tests := []struct {
+		name    string
+		origin  string
+		content string
+	}{
+		{"bad row", "100", "Dest. ZIP,Ground\n004,41\n005,42\nbad,43\n"},
+		{"duplicate origin", "38017", "Dest. ZIP,Ground\n004,44\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := x.Read(tt.origin, strings.NewReader(tt.content)); err == nil {
+				t.Fatal("Read() succeeded")
+			}
+			if len(x.names) != names {
+				t.Errorf("zone names = %v, want the %d from the first file", x.names, names-1)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	x := NewIndex()
+	if err := x.Read("380", strings.NewReader(locator)); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		network     Network
+		destination string
+		want        string
+	}{
+		{Ground, "90210", "8"},
+		{Express, "10001", "6"},
+		{Ground, "96701", "9"},
+		{Express, "96701", ""},
+		{Ground, "50001", ""},
+	}
+	for _, tt := range tests {
+		zone, err := x.Lookup(tt.network, "38017", tt.destination)
+		if tt.want == "" {
+			if err != ErrNoZone {
+				t.Errorf("Lookup(%v, %s) = %q, %v, want ErrNoZone", tt.network, tt.destination, zone, err)
+			}
+			continue
+		}
+		if err != nil || zone != tt.want {
+			t.Errorf("Lookup(%v, %s) = %q, %v, want %s", tt.network, tt.destination, zone, err, tt.want)
+		}
+	}
+}